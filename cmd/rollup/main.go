@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/yhonda-ohishi/dtako_rows/v3/internal/service"
+)
+
+// rollup ロールアップ（車両・日ごとの集計）の管理コマンド
+//
+//	rollup rebuild  全運行データからロールアップを作り直す（集計ロジックを変更した場合など）
+//	rollup status   ロールアップの状態を表示する
+//
+// 保存先は -store または環境変数 ROLLUP_STORE_PATH で指定します。
+// 稼働中のサーバーは次回の更新時に再構築されたファイルを読み込み直します。
+func main() {
+	// .envファイルの読み込み
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using environment variables")
+	}
+
+	storePath := flag.String("store", os.Getenv("ROLLUP_STORE_PATH"), "ロールアップの保存先 (ROLLUP_STORE_PATH)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-store path] rebuild|status\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *storePath == "" {
+		log.Fatal("ROLLUP_STORE_PATH (or -store) is required")
+	}
+
+	switch flag.Arg(0) {
+	case "rebuild":
+		rebuild(*storePath)
+	case "status":
+		printStatus(*storePath)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// rebuild 全運行データからロールアップを構築して保存
+func rebuild(storePath string) {
+	// db_serviceアドレス設定
+	dbServiceAddr := os.Getenv("DB_SERVICE_ADDR")
+	if dbServiceAddr == "" {
+		dbServiceAddr = "localhost:50051"
+	}
+
+	svc, err := service.NewDtakoRowsService(dbServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to create service: %v", err)
+	}
+
+	started := time.Now()
+	store, err := svc.BuildRollups(context.Background(), storePath)
+	if err != nil {
+		log.Fatalf("Failed to build rollups: %v", err)
+	}
+	if err := store.Save(); err != nil {
+		log.Fatalf("Failed to save rollups: %v", err)
+	}
+
+	log.Printf("Rebuilt rollups in %s", time.Since(started).Round(time.Millisecond))
+	printStatus(storePath)
+}
+
+// printStatus ロールアップの状態を表示
+func printStatus(storePath string) {
+	store, err := service.OpenRollupStore(storePath)
+	if err != nil {
+		log.Fatalf("Failed to open rollups: %v", err)
+	}
+
+	status := store.Status()
+	fmt.Printf("path:       %s\n", status.Path)
+	fmt.Printf("ready:      %t\n", status.Ready)
+	if !status.Ready {
+		fmt.Println("(rebuild required)")
+		return
+	}
+	fmt.Printf("build_id:   %s\n", status.BuildID)
+	fmt.Printf("built_at:   %s\n", status.BuiltAt.Format(time.RFC3339))
+	fmt.Printf("updated_at: %s\n", status.UpdatedAt.Format(time.RFC3339))
+	fmt.Printf("watermark:  %s\n", status.Watermark)
+	fmt.Printf("rows:       %d\n", status.Rows)
+	fmt.Printf("vehicles:   %d\n", status.Vehicles)
+	fmt.Printf("days:       %d\n", status.Days)
+}
@@ -81,4 +81,8 @@ func main() {
 	if err := grpcServer.Serve(listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
+
+	// GracefulStop 後にバックグラウンドのジョブを停止
+	aggregationService.Close()
+	log.Println("Server stopped")
 }
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Column 出力する列の定義
+//
+// CSV・Excelの両方で共通に使用します。Value が返すセルの表示形式に応じて、
+// CSVでは小数桁数を揃えた文字列、Excelでは数値セルとして出力されます。
+type Column[T any] struct {
+	Key   string       // 列の指定に使うキー（リクエストの columns）
+	Title string       // 見出し
+	Width float64      // Excelでの列幅（文字数、0で既定）
+	Value func(T) Cell // 行から値を取り出す
+}
+
+// SelectColumns キーの指定順に列を選択
+//
+// keys が空の場合はすべての列を定義順で返します。未定義のキーはエラーです。
+func SelectColumns[T any](columns []Column[T], keys []string) ([]Column[T], error) {
+	if len(keys) == 0 {
+		return columns, nil
+	}
+
+	byKey := make(map[string]Column[T], len(columns))
+	for _, column := range columns {
+		byKey[column.Key] = column
+	}
+
+	selected := make([]Column[T], 0, len(keys))
+	for _, key := range keys {
+		column, ok := byKey[strings.TrimSpace(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", key, strings.Join(ColumnKeys(columns), ", "))
+		}
+		selected = append(selected, column)
+	}
+	return selected, nil
+}
+
+// ColumnKeys 列のキー一覧
+func ColumnKeys[T any](columns []Column[T]) []string {
+	keys := make([]string, len(columns))
+	for i, column := range columns {
+		keys[i] = column.Key
+	}
+	return keys
+}
+
+// AddTable 見出し行（固定表示）と各行をシートに追加
+func AddTable[T any](sheet *Sheet, columns []Column[T], rows []T) {
+	titles := make([]string, len(columns))
+	widths := make([]float64, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+		widths[i] = column.Width
+		if widths[i] <= 0 {
+			widths[i] = 12
+		}
+	}
+	sheet.AddHeader(titles...)
+	sheet.SetColumnWidths(widths...)
+
+	for _, row := range rows {
+		AddTableRow(sheet, columns, row, false)
+	}
+}
+
+// AddTableRow 1行をシートに追加（bold で合計行などを太字にする）
+func AddTableRow[T any](sheet *Sheet, columns []Column[T], row T, bold bool) {
+	cells := make([]Cell, len(columns))
+	for i, column := range columns {
+		cells[i] = column.Value(row)
+		if bold {
+			cells[i] = cells[i].Bold()
+		}
+	}
+	sheet.AddRow(cells...)
+}
+
+// String CSV出力用の文字列表現
+//
+// 数値は表示形式に応じた小数桁数で出力し、桁区切りや単位は付けません。
+func (c Cell) String() string {
+	if !c.numeric {
+		return c.text
+	}
+	switch c.format {
+	case FormatInteger, FormatYen:
+		return strconv.FormatFloat(c.number, 'f', 0, 64)
+	case FormatKilometer, FormatLiter:
+		return strconv.FormatFloat(c.number, 'f', 1, 64)
+	case FormatDecimal, FormatKmPerLiter:
+		return strconv.FormatFloat(c.number, 'f', 2, 64)
+	case FormatPercent:
+		return strconv.FormatFloat(c.number*100, 'f', 1, 64)
+	}
+	return strconv.FormatFloat(c.number, 'f', -1, 64)
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Encoding CSVの文字コード
+type Encoding string
+
+// 対応する文字コード
+const (
+	EncodingUTF8     Encoding = "utf-8"     // UTF-8（BOMなし）
+	EncodingUTF8BOM  Encoding = "utf-8-bom" // UTF-8（BOM付き、Excelで直接開く場合）
+	EncodingShiftJIS Encoding = "shift_jis" // Shift_JIS（従来の会計ソフト向け）
+)
+
+// ContentTypeCSV CSVのMIMEタイプ
+const ContentTypeCSV = "text/csv"
+
+// utf8BOM UTF-8のバイトオーダーマーク
+const utf8BOM = "\ufeff"
+
+// ParseEncoding 文字コード名を解釈（空文字はUTF-8）
+func ParseEncoding(name string) (Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "utf-8", "utf8":
+		return EncodingUTF8, nil
+	case "utf-8-bom", "utf8-bom", "utf-8bom":
+		return EncodingUTF8BOM, nil
+	case "shift_jis", "shift-jis", "sjis", "cp932":
+		return EncodingShiftJIS, nil
+	}
+	return "", fmt.Errorf("unsupported encoding %q", name)
+}
+
+// IsUTF8 UTF-8系の文字コードか
+func (e Encoding) IsUTF8() bool {
+	return e == EncodingUTF8 || e == EncodingUTF8BOM
+}
+
+// ContentType 文字コードを含むMIMEタイプ
+func (e Encoding) ContentType() string {
+	if e == EncodingShiftJIS {
+		return ContentTypeCSV + "; charset=Shift_JIS"
+	}
+	return ContentTypeCSV + "; charset=UTF-8"
+}
+
+// WriteCSV 見出し行と各行をCSV（RFC 4180）で書き出す
+//
+// カンマ・ダブルクォート・改行を含む値はダブルクォートで囲みます。
+// 行末は従来の出力と同じLFです。
+// Shift_JISで表現できない文字は "?" に置き換えます。
+func WriteCSV[T any](out io.Writer, columns []Column[T], rows []T, enc Encoding) error {
+	var w io.Writer = out
+	var closer io.Closer
+	switch enc {
+	case EncodingUTF8:
+	case EncodingUTF8BOM:
+		if _, err := io.WriteString(out, utf8BOM); err != nil {
+			return err
+		}
+	case EncodingShiftJIS:
+		tw := transform.NewWriter(out, encoding.ReplaceUnsupported(japanese.ShiftJIS.NewEncoder()))
+		w, closer = tw, tw
+	default:
+		return fmt.Errorf("unsupported encoding %q", enc)
+	}
+
+	cw := csv.NewWriter(w)
+
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.Title
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = column.Value(row).String()
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	if closer != nil {
+		// 変換途中のバイトを書き出す
+		return closer.Close()
+	}
+	return nil
+}
+
+// EncodeCSV CSVをバイト列で取得
+func EncodeCSV[T any](columns []Column[T], rows []T, enc Encoding) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, columns, rows, enc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,116 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"time"
+)
+
+// 地理データのMIMEタイプ
+const (
+	ContentTypeGeoJSON = "application/geo+json"
+	ContentTypeGPX     = "application/gpx+xml"
+)
+
+// GeoPoint 経路上の地点（WGS84）
+type GeoPoint struct {
+	Latitude    float64
+	Longitude   float64
+	Time        time.Time // 通過日時（ゼロ値の場合は出力しない）
+	Name        string    // 地名
+	Description string    // 説明（イベント名など）
+}
+
+// EncodeGeoJSON 経路を GeoJSON（RFC 7946）の FeatureCollection で書き出す
+//
+// 経路全体を LineString、各地点を Point の Feature として出力します。
+// 座標は [経度, 緯度] の順です。properties には name と地点の time・description を含めます。
+func EncodeGeoJSON(name string, points []GeoPoint) ([]byte, error) {
+	type geometry struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string         `json:"type"`
+		Geometry   geometry       `json:"geometry"`
+		Properties map[string]any `json:"properties"`
+	}
+
+	features := make([]feature, 0, len(points)+1)
+	line := make([][2]float64, len(points))
+	for i, p := range points {
+		line[i] = [2]float64{p.Longitude, p.Latitude}
+	}
+	features = append(features, feature{
+		Type:       "Feature",
+		Geometry:   geometry{Type: "LineString", Coordinates: line},
+		Properties: map[string]any{"name": name},
+	})
+
+	for _, p := range points {
+		properties := map[string]any{"name": p.Name}
+		if !p.Time.IsZero() {
+			properties["time"] = p.Time.Format(time.RFC3339)
+		}
+		if p.Description != "" {
+			properties["description"] = p.Description
+		}
+		features = append(features, feature{
+			Type:       "Feature",
+			Geometry:   geometry{Type: "Point", Coordinates: [2]float64{p.Longitude, p.Latitude}},
+			Properties: properties,
+		})
+	}
+
+	return json.Marshal(map[string]any{
+		"type":     "FeatureCollection",
+		"features": features,
+	})
+}
+
+// EncodeGPX 経路を GPX 1.1 の1トラックとして書き出す
+//
+// 各地点を trkpt として出力し、通過日時は UTC で time 要素に出力します。
+func EncodeGPX(name string, points []GeoPoint) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<gpx version="1.1" creator="dtako_rows" xmlns="http://www.topografix.com/GPX/1/1">`)
+	b.WriteString(`<trk><name>`)
+	if err := xml.EscapeText(&b, []byte(name)); err != nil {
+		return nil, err
+	}
+	b.WriteString(`</name><trkseg>`)
+
+	for _, p := range points {
+		b.WriteString(`<trkpt lat="`)
+		b.WriteString(strconv.FormatFloat(p.Latitude, 'f', -1, 64))
+		b.WriteString(`" lon="`)
+		b.WriteString(strconv.FormatFloat(p.Longitude, 'f', -1, 64))
+		b.WriteString(`">`)
+		if !p.Time.IsZero() {
+			b.WriteString(`<time>`)
+			b.WriteString(p.Time.UTC().Format(time.RFC3339))
+			b.WriteString(`</time>`)
+		}
+		if p.Name != "" {
+			b.WriteString(`<name>`)
+			if err := xml.EscapeText(&b, []byte(p.Name)); err != nil {
+				return nil, err
+			}
+			b.WriteString(`</name>`)
+		}
+		if p.Description != "" {
+			b.WriteString(`<desc>`)
+			if err := xml.EscapeText(&b, []byte(p.Description)); err != nil {
+				return nil, err
+			}
+			b.WriteString(`</desc>`)
+		}
+		b.WriteString(`</trkpt>`)
+	}
+
+	b.WriteString(`</trkseg></trk></gpx>`)
+	return b.Bytes(), nil
+}
@@ -0,0 +1,398 @@
+// Package export 集計結果のファイル出力（Excel / CSV / GeoJSON / GPX）
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ContentTypeXLSX Excelブック (.xlsx) のMIMEタイプ
+const ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// NumberFormat 数値セルの表示形式
+type NumberFormat int
+
+// 数値の表示形式
+const (
+	FormatGeneral    NumberFormat = iota // 標準
+	FormatInteger                        // #,##0
+	FormatDecimal                        // #,##0.00
+	FormatKilometer                      // #,##0.0 "km"
+	FormatLiter                          // #,##0.0 "L"
+	FormatKmPerLiter                     // #,##0.00 "km/L"
+	FormatYen                            // "¥"#,##0
+	FormatPercent                        // 0.0%
+)
+
+// numberFormatCodes 表示形式ごとの書式コード（FormatGeneral は組み込みの書式を使用）
+var numberFormatCodes = map[NumberFormat]string{
+	FormatInteger:    `#,##0`,
+	FormatDecimal:    `#,##0.00`,
+	FormatKilometer:  `#,##0.0" km"`,
+	FormatLiter:      `#,##0.0" L"`,
+	FormatKmPerLiter: `#,##0.00" km/L"`,
+	FormatYen:        `"¥"#,##0`,
+	FormatPercent:    `0.0%`,
+}
+
+// numberFormats スタイルを割り当てる順序
+var numberFormats = []NumberFormat{
+	FormatGeneral,
+	FormatInteger,
+	FormatDecimal,
+	FormatKilometer,
+	FormatLiter,
+	FormatKmPerLiter,
+	FormatYen,
+	FormatPercent,
+}
+
+// Cell セルの値
+type Cell struct {
+	text    string
+	number  float64
+	numeric bool
+	format  NumberFormat
+	bold    bool
+}
+
+// Text 文字列セル
+func Text(value string) Cell {
+	return Cell{text: value}
+}
+
+// Number 数値セル（NaN・無限大は0として出力）
+func Number(value float64, format NumberFormat) Cell {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		value = 0
+	}
+	return Cell{number: value, numeric: true, format: format}
+}
+
+// Int 整数セル
+func Int(value int64) Cell {
+	return Number(float64(value), FormatInteger)
+}
+
+// Bold 太字にしたセルを返す
+func (c Cell) Bold() Cell {
+	c.bold = true
+	return c
+}
+
+// Sheet ワークシート
+type Sheet struct {
+	name       string
+	rows       [][]Cell
+	widths     []float64
+	freezeRows int
+}
+
+// AddRow 行を追加
+func (s *Sheet) AddRow(cells ...Cell) {
+	s.rows = append(s.rows, cells)
+}
+
+// AddHeader 太字の見出し行を追加し、見出しまでの行を固定する
+func (s *Sheet) AddHeader(titles ...string) {
+	cells := make([]Cell, len(titles))
+	for i, title := range titles {
+		cells[i] = Text(title).Bold()
+	}
+	s.AddRow(cells...)
+	s.freezeRows = len(s.rows)
+}
+
+// SetColumnWidths 列幅（文字数）を設定
+func (s *Sheet) SetColumnWidths(widths ...float64) {
+	s.widths = widths
+}
+
+// Workbook Excelブック
+//
+// 外部ライブラリを使わずに Office Open XML (SpreadsheetML) を直接出力します。
+// 文字列はインライン文字列として書き込みます。
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// NewWorkbook 空のブックを作成
+func NewWorkbook() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet シートを追加
+//
+// シート名はExcelの制約（31文字以内、[]:*?/\ 不可、重複不可）に合わせて調整します。
+func (w *Workbook) AddSheet(name string) *Sheet {
+	sheet := &Sheet{name: w.uniqueSheetName(name)}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// uniqueSheetName Excelで使用できる重複しないシート名
+func (w *Workbook) uniqueSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Sheet"
+	}
+
+	candidate := truncateRunes(name, 31)
+	for i := 2; w.hasSheet(candidate); i++ {
+		suffix := fmt.Sprintf("(%d)", i)
+		candidate = truncateRunes(name, 31-len(suffix)) + suffix
+	}
+	return candidate
+}
+
+// hasSheet 同名のシートがあるか（大文字小文字を区別しない）
+func (w *Workbook) hasSheet(name string) bool {
+	for _, sheet := range w.sheets {
+		if strings.EqualFold(sheet.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateRunes 文字数で切り詰める
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// Bytes ブックを .xlsx 形式のバイト列で取得
+func (w *Workbook) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := w.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Write ブックを .xlsx 形式で書き出す
+func (w *Workbook) Write(out io.Writer) error {
+	if len(w.sheets) == 0 {
+		w.AddSheet("Sheet1")
+	}
+
+	zw := zip.NewWriter(out)
+	parts := []struct {
+		name string
+		data string
+	}{
+		{"[Content_Types].xml", w.contentTypesXML()},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", w.workbookXML()},
+		{"xl/_rels/workbook.xml.rels", w.workbookRelsXML()},
+		{"xl/styles.xml", stylesXML()},
+	}
+	for i, sheet := range w.sheets {
+		parts = append(parts, struct {
+			name string
+			data string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml(i == 0)})
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// xmlHeader 各パート先頭のXML宣言
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// rootRelsXML _rels/.rels（ブック本体への参照）
+const rootRelsXML = xmlHeader +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// contentTypesXML [Content_Types].xml（パッケージ内の各パートの種類）
+func (w *Workbook) contentTypesXML() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+// workbookXML xl/workbook.xml（シート一覧）
+func (w *Workbook) workbookXML() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	b.WriteString(`<bookViews><workbookView/></bookViews><sheets>`)
+	for i, sheet := range w.sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(sheet.name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+// workbookRelsXML xl/_rels/workbook.xml.rels（シート・スタイルへの参照）
+func (w *Workbook) workbookRelsXML() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// styleIndex セルに対応する cellXfs のインデックス
+//
+// cellXfs は numberFormats の順に「標準」「太字」の2件ずつ並べる。
+func styleIndex(c Cell) int {
+	index := 0
+	for i, format := range numberFormats {
+		if format == c.format {
+			index = i * 2
+			break
+		}
+	}
+	if c.bold {
+		index++
+	}
+	return index
+}
+
+// stylesXML xl/styles.xml（表示形式・フォント）
+func stylesXML() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	// ユーザー定義の書式は164番以降
+	numFmtIDs := make(map[NumberFormat]int)
+	fmt.Fprintf(&b, `<numFmts count="%d">`, len(numberFormatCodes))
+	for i, format := range numberFormats[1:] {
+		id := 164 + i
+		numFmtIDs[format] = id
+		fmt.Fprintf(&b, `<numFmt numFmtId="%d" formatCode="%s"/>`, id, escapeXML(numberFormatCodes[format]))
+	}
+	b.WriteString(`</numFmts>`)
+
+	b.WriteString(`<fonts count="2"><font><sz val="11"/><name val="Yu Gothic"/><family val="3"/><charset val="128"/></font>`)
+	b.WriteString(`<font><b/><sz val="11"/><name val="Yu Gothic"/><family val="3"/><charset val="128"/></font></fonts>`)
+	b.WriteString(`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>`)
+	b.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	b.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+
+	fmt.Fprintf(&b, `<cellXfs count="%d">`, len(numberFormats)*2)
+	for _, format := range numberFormats {
+		numFmtID := numFmtIDs[format]
+		for fontID := 0; fontID < 2; fontID++ {
+			fmt.Fprintf(&b, `<xf numFmtId="%d" fontId="%d" fillId="0" borderId="0" xfId="0"`, numFmtID, fontID)
+			if numFmtID != 0 {
+				b.WriteString(` applyNumberFormat="1"`)
+			}
+			if fontID != 0 {
+				b.WriteString(` applyFont="1"`)
+			}
+			b.WriteString(`/>`)
+		}
+	}
+	b.WriteString(`</cellXfs>`)
+
+	b.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	b.WriteString(`</styleSheet>`)
+	return b.String()
+}
+
+// xml xl/worksheets/sheetN.xml（固定行・列幅・セル）
+func (s *Sheet) xml(selected bool) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"`)
+	if selected {
+		b.WriteString(` tabSelected="1"`)
+	}
+	if s.freezeRows > 0 {
+		topLeft := fmt.Sprintf("A%d", s.freezeRows+1)
+		fmt.Fprintf(&b, `><pane ySplit="%d" topLeftCell="%s" activePane="bottomLeft" state="frozen"/>`, s.freezeRows, topLeft)
+		fmt.Fprintf(&b, `<selection pane="bottomLeft" activeCell="%s" sqref="%s"/></sheetView>`, topLeft, topLeft)
+	} else {
+		b.WriteString(`/>`)
+	}
+	b.WriteString(`</sheetViews>`)
+
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, width := range s.widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, strconv.FormatFloat(width, 'f', -1, 64))
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := columnName(c) + strconv.Itoa(r+1)
+			style := styleIndex(cell)
+			if cell.numeric {
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(cell.number, 'f', -1, 64))
+			} else {
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escapeXML(cell.text))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+
+	b.WriteString(`</worksheet>`)
+	return b.String()
+}
+
+// columnName 0始まりの列番号を列名（A, B, ..., Z, AA, ...）に変換
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// escapeXML XMLの特殊文字をエスケープ
+func escapeXML(s string) string {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return s
+	}
+	return b.String()
+}
@@ -2,35 +2,90 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"sort"
 	"time"
 
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"github.com/yhonda-ohishi/dtako_rows/v3/internal/export"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // MonthlyFuelSummary 月次給油量サマリー
+//
+// 集計期間の単位は Bucketing で指定します（既定は月次）。
 type MonthlyFuelSummary struct {
 	CarCC         string  // 車輌CC
-	YearMonth     string  // 年月 (YYYY-MM形式)
+	CarCode       int32   // 車輌CD（運行データの car_code、不明な場合は0）
+	YearMonth     string  // 集計期間のキー（月次の場合は YYYY-MM形式）
+	Bucket        Bucket  // 集計期間（範囲・表示名）
 	TotalDistance float64 // 総走行距離
-	TotalFuel     float64 // 総給油量（計算値: 走行距離 / 燃費）
+	TotalFuel     float64 // 総給油量（FuelBasis に応じて実績値・実績値 + 推定値・推定値）
 	TripCount     int32   // 運行回数
+	FerryDistance float64 // フェリーの見なし距離（TotalDistance・給油量の推定には含まない）
+
+	FuelEfficiency       float64 // 給油量の推定に使用した燃費 (km/L)
+	FuelEfficiencySource string  // 燃費の決定元 (FuelEfficiencySource*)
+
+	FuelBasis     string  // TotalFuel の根拠 (FuelBasis*)
+	MeasuredFuel  float64 // 実給油量の合計 (L)
+	EstimatedFuel float64 // 推定給油量 (L, 走行距離 / 燃費)
+	RefuelCount   int32   // 実給油データの件数
+
+	UncoveredDistance float64 // 実給油データでカバーされない走行距離（期間内の最後の給油日より後の運行）
+
+	distanceByDay map[time.Time]float64 // 運行日（業務タイムゾーンの0時）→ 走行距離
+	lastRefuel    time.Time             // 期間内の最後の給油日（業務タイムゾーンの0時）
+}
+
+// addDistance 運行日の走行距離を加算
+func (s *MonthlyFuelSummary) addDistance(opDate time.Time, distance float64) {
+	s.TotalDistance += distance
+	if s.distanceByDay == nil {
+		s.distanceByDay = make(map[time.Time]float64)
+	}
+	s.distanceByDay[startOfBusinessDay(opDate)] += distance
+}
+
+// addRefuel 実給油データを加算
+func (s *MonthlyFuelSummary) addRefuel(refuel *RefuelRecord) {
+	s.MeasuredFuel += refuel.Liters
+	s.RefuelCount++
+	if day := startOfBusinessDay(refuel.Date); day.After(s.lastRefuel) {
+		s.lastRefuel = day
+	}
+}
+
+// DistanceWithFerry フェリーの見なし距離を含む距離
+func (s *MonthlyFuelSummary) DistanceWithFerry() float64 {
+	return s.TotalDistance + s.FerryDistance
 }
 
 // GetMonthlyFuelConsumption 車両ごとの月次給油量を集計
 //
-// 指定期間の運行データから、車両ごと・月ごとの給油量を集計します。
-// 給油量は走行距離から推定計算します（実際の給油データがない場合）。
-func (s *DtakoRowsService) GetMonthlyFuelConsumption(ctx context.Context, carCC string, startDate, endDate string) ([]*MonthlyFuelSummary, error) {
-	log.Printf("GetMonthlyFuelConsumption: car_cc=%s, start=%s, end=%s", carCC, startDate, endDate)
+// 指定期間の運行データから、車両ごと・集計期間（bucketing、通常は月）ごとの給油量を集計します。
+// 実給油データ（FuelSource）がある期間はその合計を、ない期間は走行距離と
+// 車両ごとの燃費（FuelEfficiencyResolver）からの推定値を給油量とします。
+func (s *DtakoRowsService) GetMonthlyFuelConsumption(ctx context.Context, carCC string, startDate, endDate string, bucketing Bucketing) ([]*MonthlyFuelSummary, error) {
+	log.Printf("GetMonthlyFuelConsumption: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
 
 	// バリデーション
 	if carCC == "" {
 		return nil, status.Error(codes.InvalidArgument, "car_cc is required")
 	}
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	// ロールアップがあればそこから集計
+	if days, ok := s.rollupDays(carCC, startDate, endDate); ok {
+		refuels := s.listRefuels(ctx, carCC, startDate, endDate)
+		data := s.summarizeRollupDays(ctx, days, refuels, periods)
+		return sortedSummaries(data[carCC]), nil
+	}
 
 	// 新しいフィルタリングメソッドを使用
 	allRows, err := s.ListByCarCCAndDateRange(ctx, carCC, startDate, endDate, 0)
@@ -41,131 +96,350 @@ func (s *DtakoRowsService) GetMonthlyFuelConsumption(ctx context.Context, carCC
 
 	log.Printf("Filtered %d rows for car_cc=%s", len(allRows), carCC)
 
-	// 月次集計
-	monthlyData := make(map[string]*MonthlyFuelSummary)
+	refuels := s.listRefuels(ctx, carCC, startDate, endDate)
+	results := s.summarizeMonthly(ctx, carCC, allRows, refuels[carCC], periods)
 
-	for _, row := range allRows {
-		opDate, err := time.Parse(time.RFC3339, row.OperationDate)
-		if err != nil {
+	log.Printf("Aggregated %d periods of data", len(results))
+	return results, nil
+}
+
+// summarizeMonthly 1車両分の運行データを集計期間ごとに集計（期間順）
+func (s *DtakoRowsService) summarizeMonthly(ctx context.Context, carCC string, rows []*dbpb.Db_DTakoRows, refuels []*RefuelRecord, periods BucketRange) []*MonthlyFuelSummary {
+	// 車両マスタから燃費を決定
+	carCode := rowsCarCode(rows)
+	efficiency := s.resolveFuelEfficiency(ctx, carCC, carCode)
+
+	// 期間ごとに集計
+	periodData := make(map[string]*MonthlyFuelSummary)
+	ferries := s.newFerryTally(ctx)
+
+	for _, row := range rows {
+		opDate, ok := parseOperationDate(row)
+		if !ok {
 			continue
 		}
 
-		yearMonth := opDate.Format("2006-01")
+		bucket := periods.Bucket(opDate)
 
-		if _, exists := monthlyData[yearMonth]; !exists {
-			monthlyData[yearMonth] = &MonthlyFuelSummary{
-				CarCC:     row.CarCc,
-				YearMonth: yearMonth,
-			}
+		if _, exists := periodData[bucket.Key]; !exists {
+			periodData[bucket.Key] = newPeriodSummary(row.CarCc, bucket, efficiency)
+			periodData[bucket.Key].CarCode = carCode
 		}
 
-		summary := monthlyData[yearMonth]
-		summary.TotalDistance += row.TotalDistance
+		summary := periodData[bucket.Key]
+		summary.addDistance(opDate, row.TotalDistance)
 		summary.TripCount++
-
-		// 燃費を10km/Lと仮定して給油量を計算（実際の燃費データがあればそれを使用）
-		// TODO: 車両マスタから実際の燃費を取得
-		const averageFuelEfficiency = 10.0 // km/L
-		summary.TotalFuel = summary.TotalDistance / averageFuelEfficiency
+		summary.FerryDistance += ferries.deemedDistance(row.OperationNo)
 	}
 
-	// マップを配列に変換してソート
-	results := make([]*MonthlyFuelSummary, 0, len(monthlyData))
-	for _, summary := range monthlyData {
-		results = append(results, summary)
+	applyRefuels(periodData, refuels, periods, carCC, efficiency)
+
+	for _, summary := range periodData {
+		finalizeFuel(summary)
 	}
+	return sortedSummaries(periodData)
+}
 
-	// 年月でソート
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].YearMonth < results[j].YearMonth
-	})
+// rowsCarCode 1車両分の運行データの車輌CD（0以外の最初の値、ない場合は0）
+func rowsCarCode(rows []*dbpb.Db_DTakoRows) int32 {
+	for _, row := range rows {
+		if row.CarCode != 0 {
+			return row.CarCode
+		}
+	}
+	return 0
+}
 
-	log.Printf("Aggregated %d months of data", len(results))
-	return results, nil
+// newPeriodSummary 集計期間の空のサマリーを作成
+func newPeriodSummary(carCC string, bucket Bucket, efficiency FuelEfficiency) *MonthlyFuelSummary {
+	return &MonthlyFuelSummary{
+		CarCC:                carCC,
+		YearMonth:            bucket.Key,
+		Bucket:               bucket,
+		FuelEfficiency:       efficiency.KmPerLiter,
+		FuelEfficiencySource: efficiency.Source,
+	}
 }
 
 // GetVehicleMonthlySummary 全車両の月次サマリーを取得
 //
-// 指定期間の全車両の月次走行距離・給油量を集計します。
-func (s *DtakoRowsService) GetVehicleMonthlySummary(ctx context.Context, startDate, endDate string) (map[string][]*MonthlyFuelSummary, error) {
-	log.Printf("GetVehicleMonthlySummary: start=%s, end=%s", startDate, endDate)
+// 指定期間の全車両の走行距離・給油量を集計期間（bucketing、通常は月）ごとに集計します。
+func (s *DtakoRowsService) GetVehicleMonthlySummary(ctx context.Context, startDate, endDate string, bucketing Bucketing) (map[string][]*MonthlyFuelSummary, error) {
+	log.Printf("GetVehicleMonthlySummary: start=%s, end=%s, bucket=%s", startDate, endDate, bucketing.Kind)
 
-	// 新しいフィルタリングメソッドを使用（日付範囲のみ）
-	allRows, err := s.ListByDateRange(ctx, startDate, endDate, 0)
+	start, end, err := parseDateRange(startDate, endDate)
 	if err != nil {
-		log.Printf("Failed to list rows with filter: %v", err)
 		return nil, err
 	}
+	periods := bucketing.Range(start, end)
+
+	// ロールアップがあればそこから集計
+	if days, ok := s.rollupDays("", startDate, endDate); ok {
+		refuels := s.listRefuels(ctx, "", startDate, endDate)
+		results := make(map[string][]*MonthlyFuelSummary)
+		for carCC, periodData := range s.summarizeRollupDays(ctx, days, refuels, periods) {
+			results[carCC] = sortedSummaries(periodData)
+		}
+		return results, nil
+	}
 
-	log.Printf("Processing %d rows for vehicle summary", len(allRows))
+	refuels := s.listRefuels(ctx, "", startDate, endDate)
 
-	// 車両ごと・月次集計
-	vehicleMonthlyData := make(map[string]map[string]*MonthlyFuelSummary)
+	// 車両ごと・期間ごとに集計（運行日降順に取得し、行は保持せずに集計値のみを残す）
+	vehicleData := make(map[string]map[string]*MonthlyFuelSummary)
+	efficiencies := make(map[string]FuelEfficiency)
+	ferries := s.newFerryTally(ctx)
+	filter := &FilterOptions{
+		StartDate: &start,
+		EndDate:   &end,
+	}
+	processed := 0
 
-	for _, row := range allRows {
-		opDate, err := time.Parse(time.RFC3339, row.OperationDate)
-		if err != nil {
-			continue
-		}
+	_, err = s.ScanWithFilter(ctx, filter, func(rows []*dbpb.Db_DTakoRows) error {
+		for _, row := range rows {
+			opDate, ok := parseOperationDate(row)
+			if !ok {
+				continue
+			}
 
-		yearMonth := opDate.Format("2006-01")
-		carCC := row.CarCc
+			bucket := periods.Bucket(opDate)
+			carCC := row.CarCc
 
-		if _, exists := vehicleMonthlyData[carCC]; !exists {
-			vehicleMonthlyData[carCC] = make(map[string]*MonthlyFuelSummary)
-		}
+			if _, exists := vehicleData[carCC]; !exists {
+				vehicleData[carCC] = make(map[string]*MonthlyFuelSummary)
+				efficiencies[carCC] = s.resolveFuelEfficiency(ctx, carCC, row.CarCode)
+			}
 
-		if _, exists := vehicleMonthlyData[carCC][yearMonth]; !exists {
-			vehicleMonthlyData[carCC][yearMonth] = &MonthlyFuelSummary{
-				CarCC:     carCC,
-				YearMonth: yearMonth,
+			if _, exists := vehicleData[carCC][bucket.Key]; !exists {
+				vehicleData[carCC][bucket.Key] = newPeriodSummary(carCC, bucket, efficiencies[carCC])
+			}
+			if row.CarCode != 0 {
+				vehicleData[carCC][bucket.Key].CarCode = row.CarCode
 			}
+
+			summary := vehicleData[carCC][bucket.Key]
+			summary.addDistance(opDate, row.TotalDistance)
+			summary.TripCount++
+			summary.FerryDistance += ferries.deemedDistance(row.OperationNo)
+			processed++
 		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to scan rows with filter: %v", err)
+		return nil, err
+	}
 
-		summary := vehicleMonthlyData[carCC][yearMonth]
-		summary.TotalDistance += row.TotalDistance
-		summary.TripCount++
+	log.Printf("Processed %d rows for vehicle summary", processed)
 
-		const averageFuelEfficiency = 10.0
-		summary.TotalFuel = summary.TotalDistance / averageFuelEfficiency
+	// 運行のない車両の実給油データも集計する
+	for carCC, carRefuels := range refuels {
+		if _, exists := vehicleData[carCC]; !exists {
+			vehicleData[carCC] = make(map[string]*MonthlyFuelSummary)
+			efficiencies[carCC] = s.resolveFuelEfficiency(ctx, carCC, 0)
+		}
+		applyRefuels(vehicleData[carCC], carRefuels, periods, carCC, efficiencies[carCC])
 	}
 
-	// マップを整形
+	// マップを整形（期間順）
 	results := make(map[string][]*MonthlyFuelSummary)
-	for carCC, monthlyData := range vehicleMonthlyData {
-		summaries := make([]*MonthlyFuelSummary, 0, len(monthlyData))
-		for _, summary := range monthlyData {
-			summaries = append(summaries, summary)
+	for carCC, periodData := range vehicleData {
+		for _, summary := range periodData {
+			finalizeFuel(summary)
 		}
-		// 年月でソート
-		sort.Slice(summaries, func(i, j int) bool {
-			return summaries[i].YearMonth < summaries[j].YearMonth
-		})
-		results[carCC] = summaries
+		results[carCC] = sortedSummaries(periodData)
 	}
 
 	log.Printf("Aggregated data for %d vehicles", len(results))
 	return results, nil
 }
 
+// StreamVehicleMonthlySummary 全車両の月次サマリーを車両単位で逐次出力
+//
+// 運行データを車輌CC順に取得し、車輌CCが切り替わった時点でその車両の集計を確定して
+// fn に渡します。GetVehicleMonthlySummary と異なり、保持するのは集計中の1車両分の行のみです。
+// 運行のない車両の実給油データは、運行データの車両をすべて出力した後に車輌CC順に出力します。
+func (s *DtakoRowsService) StreamVehicleMonthlySummary(ctx context.Context, startDate, endDate string, bucketing Bucketing, fn func(carCC string, summaries []*MonthlyFuelSummary) error) error {
+	log.Printf("StreamVehicleMonthlySummary: start=%s, end=%s, bucket=%s", startDate, endDate, bucketing.Kind)
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+	filter := &FilterOptions{
+		StartDate: &start,
+		EndDate:   &end,
+	}
+	periods := bucketing.Range(start, end)
+
+	refuels := s.listRefuels(ctx, "", startDate, endDate)
+
+	currentCarCC := ""
+	var currentRows []*dbpb.Db_DTakoRows
+	streamed := make(map[string]bool)
+
+	flush := func() error {
+		if len(currentRows) == 0 {
+			return nil
+		}
+		summaries := s.summarizeMonthly(ctx, currentCarCC, currentRows, refuels[currentCarCC], periods)
+		currentRows = nil
+		streamed[currentCarCC] = true
+		return fn(currentCarCC, summaries)
+	}
+
+	plan := PlanQueryByCarCC(filter)
+	log.Printf("StreamVehicleMonthlySummary: %s", plan)
+
+	_, err = s.scan(ctx, filter, plan, func(rows []*dbpb.Db_DTakoRows) error {
+		for _, row := range rows {
+			if row.CarCc != currentCarCC {
+				if err := flush(); err != nil {
+					return err
+				}
+				currentCarCC = row.CarCc
+			}
+			currentRows = append(currentRows, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// 運行のない車両の実給油データも出力する
+	var refuelOnly []string
+	for carCC := range refuels {
+		if !streamed[carCC] {
+			refuelOnly = append(refuelOnly, carCC)
+		}
+	}
+	sort.Strings(refuelOnly)
+	for _, carCC := range refuelOnly {
+		if err := fn(carCC, s.summarizeMonthly(ctx, carCC, nil, refuels[carCC], periods)); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Streamed data for %d vehicles", len(streamed)+len(refuelOnly))
+	return nil
+}
+
+// resolveFuelEfficiency 車輌CCに適用する燃費を取得
+//
+// リゾルバーが未設定の場合はデフォルト燃費を返します。
+func (s *DtakoRowsService) resolveFuelEfficiency(ctx context.Context, carCC string, carCode int32) FuelEfficiency {
+	if s.fuelResolver == nil {
+		return FuelEfficiency{KmPerLiter: defaultFuelEfficiency, Source: FuelEfficiencySourceDefault}
+	}
+	return s.fuelResolver.Resolve(ctx, carCC, carCode)
+}
+
+// listRefuels 指定期間の実給油データを車輌CCごとに取得
+//
+// 取得元が未設定、または取得に失敗した場合は nil を返します（給油量はすべて推定値になります）。
+func (s *DtakoRowsService) listRefuels(ctx context.Context, carCC, startDate, endDate string) map[string][]*RefuelRecord {
+	if s.fuelSource == nil {
+		return nil
+	}
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil
+	}
+	records, err := s.fuelSource.ListRefuels(ctx, carCC, start, end)
+	if err != nil {
+		log.Printf("Warning: failed to list refuels from %s, using estimates: %v", s.fuelSource.Name(), err)
+		return nil
+	}
+
+	byCar := make(map[string][]*RefuelRecord)
+	for _, record := range records {
+		byCar[record.CarCC] = append(byCar[record.CarCC], record)
+	}
+	return byCar
+}
+
+// applyRefuels 実給油データを期間ごとのサマリーに加算
+//
+// 運行のない期間に給油があった場合は、その期間のサマリーを作成します。
+func applyRefuels(data map[string]*MonthlyFuelSummary, refuels []*RefuelRecord, periods BucketRange, carCC string, efficiency FuelEfficiency) {
+	for _, refuel := range refuels {
+		bucket := periods.Bucket(refuel.Date)
+		if _, exists := data[bucket.Key]; !exists {
+			data[bucket.Key] = newPeriodSummary(carCC, bucket, efficiency)
+		}
+		data[bucket.Key].addRefuel(refuel)
+	}
+}
+
+// finalizeFuel 推定給油量を計算し、総給油量とその根拠を確定
+//
+// 給油はその日までの走行分を補給したものとみなし、期間内の最後の給油日より後の運行の
+// 走行距離は実給油データでカバーされないとします。
+//   - 給油がない期間: 推定値（estimated）
+//   - 最後の給油日より後に運行がない期間: 実給油量（measured）
+//   - それ以外: 実給油量 + カバーされない走行距離の推定値（mixed）
+func finalizeFuel(summary *MonthlyFuelSummary) {
+	summary.EstimatedFuel = summary.TotalDistance / summary.FuelEfficiency
+	if summary.RefuelCount == 0 {
+		summary.TotalFuel = summary.EstimatedFuel
+		summary.FuelBasis = FuelBasisEstimated
+		return
+	}
+
+	summary.UncoveredDistance = 0
+	for day, distance := range summary.distanceByDay {
+		if day.After(summary.lastRefuel) {
+			summary.UncoveredDistance += distance
+		}
+	}
+	if summary.UncoveredDistance > 0 {
+		summary.TotalFuel = summary.MeasuredFuel + summary.UncoveredDistance/summary.FuelEfficiency
+		summary.FuelBasis = FuelBasisMixed
+	} else {
+		summary.TotalFuel = summary.MeasuredFuel
+		summary.FuelBasis = FuelBasisMeasured
+	}
+}
+
 // PrintMonthlySummary 月次サマリーをログ出力（デバッグ用）
 func PrintMonthlySummary(summaries []*MonthlyFuelSummary) {
 	log.Println("=== Monthly Fuel Summary ===")
 	for _, s := range summaries {
-		log.Printf("%s | 車両: %s | 走行距離: %.1fkm | 給油量: %.1fL | 運行回数: %d回",
-			s.YearMonth, s.CarCC, s.TotalDistance, s.TotalFuel, s.TripCount)
+		log.Printf("%s | 車両: %s | 走行距離: %.1fkm | 給油量: %.1fL (%s) | 運行回数: %d回",
+			s.YearMonth, s.CarCC, s.TotalDistance, s.TotalFuel, s.FuelBasis, s.TripCount)
 	}
 }
 
 // GetDailySummary 日次サマリーを取得
 //
-// 指定車両の日次走行距離・給油量を集計します。
-func (s *DtakoRowsService) GetDailySummary(ctx context.Context, carCC string, startDate, endDate string) (map[string]*MonthlyFuelSummary, error) {
-	log.Printf("GetDailySummary: car_cc=%s, start=%s, end=%s", carCC, startDate, endDate)
+// 指定車両の走行距離・給油量を集計期間（bucketing、通常は日）ごとに集計します。
+// 戻り値は集計期間のキー → サマリーです。
+func (s *DtakoRowsService) GetDailySummary(ctx context.Context, carCC string, startDate, endDate string, bucketing Bucketing) (map[string]*MonthlyFuelSummary, error) {
+	log.Printf("GetDailySummary: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
 
 	if carCC == "" {
 		return nil, status.Error(codes.InvalidArgument, "car_cc is required")
 	}
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	// ロールアップがあればそこから集計
+	if days, ok := s.rollupDays(carCC, startDate, endDate); ok {
+		refuels := s.listRefuels(ctx, carCC, startDate, endDate)
+		data := s.summarizeRollupDays(ctx, days, refuels, periods)
+		if data[carCC] == nil {
+			return map[string]*MonthlyFuelSummary{}, nil
+		}
+		return data[carCC], nil
+	}
 
 	// 新しいフィルタリングメソッドを使用
 	allRows, err := s.ListByCarCCAndDateRange(ctx, carCC, startDate, endDate, 0)
@@ -173,29 +447,34 @@ func (s *DtakoRowsService) GetDailySummary(ctx context.Context, carCC string, st
 		return nil, err
 	}
 
+	carCode := rowsCarCode(allRows)
+	efficiency := s.resolveFuelEfficiency(ctx, carCC, carCode)
+	refuels := s.listRefuels(ctx, carCC, startDate, endDate)
 	dailyData := make(map[string]*MonthlyFuelSummary)
+	ferries := s.newFerryTally(ctx)
 
 	for _, row := range allRows {
-		opDate, err := time.Parse(time.RFC3339, row.OperationDate)
-		if err != nil {
+		opDate, ok := parseOperationDate(row)
+		if !ok {
 			continue
 		}
 
-		dateKey := opDate.Format("2006-01-02")
+		bucket := periods.Bucket(opDate)
 
-		if _, exists := dailyData[dateKey]; !exists {
-			dailyData[dateKey] = &MonthlyFuelSummary{
-				CarCC:     row.CarCc,
-				YearMonth: dateKey, // 日付を格納
-			}
+		if _, exists := dailyData[bucket.Key]; !exists {
+			dailyData[bucket.Key] = newPeriodSummary(row.CarCc, bucket, efficiency)
+			dailyData[bucket.Key].CarCode = carCode
 		}
 
-		summary := dailyData[dateKey]
-		summary.TotalDistance += row.TotalDistance
+		summary := dailyData[bucket.Key]
+		summary.addDistance(opDate, row.TotalDistance)
 		summary.TripCount++
+		summary.FerryDistance += ferries.deemedDistance(row.OperationNo)
+	}
 
-		const averageFuelEfficiency = 10.0
-		summary.TotalFuel = summary.TotalDistance / averageFuelEfficiency
+	applyRefuels(dailyData, refuels[carCC], periods, carCC, efficiency)
+	for _, summary := range dailyData {
+		finalizeFuel(summary)
 	}
 
 	log.Printf("Aggregated %d days of data", len(dailyData))
@@ -204,10 +483,25 @@ func (s *DtakoRowsService) GetDailySummary(ctx context.Context, carCC string, st
 
 // FormatSummaryAsCSV 集計結果をCSV形式で出力（エクスポート用）
 func FormatSummaryAsCSV(summaries []*MonthlyFuelSummary) string {
-	csv := "年月,車両CC,走行距離(km),給油量(L),運行回数\n"
-	for _, s := range summaries {
-		csv += fmt.Sprintf("%s,%s,%.1f,%.1f,%d\n",
-			s.YearMonth, s.CarCC, s.TotalDistance, s.TotalFuel, s.TripCount)
+	format := ExportFormat{
+		Encoding: export.EncodingUTF8,
+		Columns:  []string{"year_month", "car_cc", "total_distance", "total_fuel", "trip_count", "fuel_basis"},
+	}
+	data, err := EncodeMonthlyFuelCSV(summaries, format)
+	if err != nil {
+		log.Printf("Failed to format summary as CSV: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+// fuelBasisLabel 給油量の根拠の表示名
+func fuelBasisLabel(basis string) string {
+	switch basis {
+	case FuelBasisMeasured:
+		return "実績"
+	case FuelBasisMixed:
+		return "実績+推定"
 	}
-	return csv
+	return "推定"
 }
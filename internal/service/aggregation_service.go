@@ -4,15 +4,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"time"
 
 	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"github.com/yhonda-ohishi/dtako_rows/v3/internal/export"
 	pb "github.com/yhonda-ohishi/dtako_rows/v3/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // DtakoRowsAggregationService 集計サービス実装
 type DtakoRowsAggregationService struct {
 	pb.UnimplementedDtakoRowsServiceServer
-	dbClient dbpb.Db_DTakoRowsServiceClient
+	dbClient    dbpb.Db_DTakoRowsServiceClient
+	rowsService *DtakoRowsService // 集計ロジック（aggregation.go）
+	cache       *AggregateCache   // 集計結果のキャッシュ（nilの場合はキャッシュなし）
 }
 
 // NewDtakoRowsAggregationService 集計サービスの作成（スタンドアロン用）
@@ -22,28 +29,57 @@ func NewDtakoRowsAggregationService(dbServiceAddr string) (*DtakoRowsAggregation
 	if err != nil {
 		return nil, err
 	}
+	rowsService.EnableRollupsFromEnv(context.Background())
 
 	return &DtakoRowsAggregationService{
-		dbClient: rowsService.dbClient,
+		dbClient:    rowsService.dbClient,
+		rowsService: rowsService,
+		cache:       NewAggregateCacheFromEnv(rowsService),
 	}, nil
 }
 
 // NewDtakoRowsAggregationServiceWithClient 集計サービスの作成（desktop-server統合用）
 func NewDtakoRowsAggregationServiceWithClient(client dbpb.Db_DTakoRowsServiceClient) *DtakoRowsAggregationService {
 	log.Println("Creating dtako_rows aggregation service with existing db_service client")
+	return NewDtakoRowsAggregationServiceWithClients(&DBClients{Rows: client})
+}
+
+// NewDtakoRowsAggregationServiceWithClients 集計サービスの作成（複数のdb_serviceクライアントを使用）
+func NewDtakoRowsAggregationServiceWithClients(clients *DBClients) *DtakoRowsAggregationService {
+	rowsService := NewDtakoRowsServiceWithClients(clients)
+	rowsService.EnableRollupsFromEnv(context.Background())
 	return &DtakoRowsAggregationService{
-		dbClient: client,
+		dbClient:    clients.Rows,
+		rowsService: rowsService,
+		cache:       NewAggregateCacheFromEnv(rowsService),
 	}
 }
 
+// Close バックグラウンドのジョブ（ロールアップの更新）を停止
+//
+// gRPCサーバーの GracefulStop の後に呼び出します。
+func (s *DtakoRowsAggregationService) Close() {
+	s.rowsService.Close()
+}
+
 // GetMonthlyFuelConsumption 月次給油量集計
 func (s *DtakoRowsAggregationService) GetMonthlyFuelConsumption(ctx context.Context, req *pb.GetMonthlyFuelConsumptionRequest) (*pb.MonthlyFuelConsumptionResponse, error) {
 	log.Printf("GetMonthlyFuelConsumption: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
 
+	return cachedResponse(ctx, s.cache, "GetMonthlyFuelConsumption", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.MonthlyFuelConsumptionResponse, error) {
+		return s.monthlyFuelConsumption(ctx, req)
+	})
+}
+
+// monthlyFuelConsumption 月次給油量集計（キャッシュなし）
+func (s *DtakoRowsAggregationService) monthlyFuelConsumption(ctx context.Context, req *pb.GetMonthlyFuelConsumptionRequest) (*pb.MonthlyFuelConsumptionResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
 	// aggregation.goの関数を使って集計
-	// 一時的にDtakoRowsServiceを作成
-	rowsService := &DtakoRowsService{dbClient: s.dbClient}
-	summaries, err := rowsService.GetMonthlyFuelConsumption(ctx, req.CarCc, req.StartDate, req.EndDate)
+	summaries, err := s.rowsService.GetMonthlyFuelConsumption(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
 	if err != nil {
 		return nil, err
 	}
@@ -51,19 +87,7 @@ func (s *DtakoRowsAggregationService) GetMonthlyFuelConsumption(ctx context.Cont
 	// 内部型からproto型に変換
 	pbSummaries := make([]*pb.MonthlyFuelSummary, len(summaries))
 	for i, s := range summaries {
-		avgFuelEfficiency := 0.0
-		if s.TotalFuel > 0 {
-			avgFuelEfficiency = s.TotalDistance / s.TotalFuel
-		}
-
-		pbSummaries[i] = &pb.MonthlyFuelSummary{
-			CarCc:             s.CarCC,
-			YearMonth:         s.YearMonth,
-			TotalDistance:     s.TotalDistance,
-			TotalFuel:         s.TotalFuel,
-			TripCount:         s.TripCount,
-			AvgFuelEfficiency: avgFuelEfficiency,
-		}
+		pbSummaries[i] = convertMonthlySummaryToProto(s)
 	}
 
 	return &pb.MonthlyFuelConsumptionResponse{
@@ -77,42 +101,74 @@ func (s *DtakoRowsAggregationService) GetMonthlyFuelConsumption(ctx context.Cont
 func (s *DtakoRowsAggregationService) GetVehicleMonthlySummary(ctx context.Context, req *pb.GetVehicleMonthlySummaryRequest) (*pb.VehicleMonthlySummaryResponse, error) {
 	log.Printf("GetVehicleMonthlySummary: start=%s, end=%s", req.StartDate, req.EndDate)
 
-	rowsService := &DtakoRowsService{dbClient: s.dbClient}
-	summariesMap, err := rowsService.GetVehicleMonthlySummary(ctx, req.StartDate, req.EndDate)
+	return cachedResponse(ctx, s.cache, "GetVehicleMonthlySummary", "", req.StartDate, req.EndDate, req, func() (*pb.VehicleMonthlySummaryResponse, error) {
+		return s.vehicleMonthlySummary(ctx, req)
+	})
+}
+
+// vehicleMonthlySummary 全車両月次サマリー（キャッシュなし）
+func (s *DtakoRowsAggregationService) vehicleMonthlySummary(ctx context.Context, req *pb.GetVehicleMonthlySummaryRequest) (*pb.VehicleMonthlySummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
 	if err != nil {
 		return nil, err
 	}
 
-	// 内部型からproto型に変換
-	vehicleSummaries := make([]*pb.VehicleMonthlySummaries, 0, len(summariesMap))
-	for carCC, summaries := range summariesMap {
-		pbSummaries := make([]*pb.MonthlyFuelSummary, len(summaries))
-		for i, s := range summaries {
-			avgFuelEfficiency := 0.0
-			if s.TotalFuel > 0 {
-				avgFuelEfficiency = s.TotalDistance / s.TotalFuel
-			}
+	opts := FleetOptions{
+		SortBy:      req.SortBy,
+		Descending:  req.Descending,
+		RankingSize: req.RankingSize,
+	}
+	// 集計前にオプションを検証する
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 
-			pbSummaries[i] = &pb.MonthlyFuelSummary{
-				CarCc:             s.CarCC,
-				YearMonth:         s.YearMonth,
-				TotalDistance:     s.TotalDistance,
-				TotalFuel:         s.TotalFuel,
-				TripCount:         s.TripCount,
-				AvgFuelEfficiency: avgFuelEfficiency,
-			}
+	summariesMap, err := s.rowsService.GetVehicleMonthlySummary(ctx, req.StartDate, req.EndDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	fleet, err := BuildFleetSummary(summariesMap, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	vehicleSummaries := make([]*pb.VehicleMonthlySummaries, len(fleet.Vehicles))
+	for i, v := range fleet.Vehicles {
+		pbSummaries := make([]*pb.MonthlyFuelSummary, len(v.Summaries))
+		for j, s := range v.Summaries {
+			pbSummaries[j] = convertMonthlySummaryToProto(s)
 		}
 
-		vehicleSummaries = append(vehicleSummaries, &pb.VehicleMonthlySummaries{
-			CarCc:     carCC,
+		vehicleSummaries[i] = &pb.VehicleMonthlySummaries{
+			CarCc:     v.CarCC,
 			Summaries: pbSummaries,
-		})
+			Totals:    convertSummaryTotalsToProto(v.Totals),
+		}
+	}
+
+	fleetSummaries := make([]*pb.MonthlyFuelSummary, len(fleet.Periods))
+	for i, s := range fleet.Periods {
+		fleetSummaries[i] = convertMonthlySummaryToProto(s)
+	}
+
+	rankings := make([]*pb.VehicleRanking, len(fleet.Rankings))
+	for i, ranking := range fleet.Rankings {
+		rankings[i] = &pb.VehicleRanking{
+			Metric: ranking.Metric,
+			Top:    convertRankedVehiclesToProto(ranking.Top),
+			Bottom: convertRankedVehiclesToProto(ranking.Bottom),
+		}
 	}
 
 	return &pb.VehicleMonthlySummaryResponse{
 		VehicleSummaries: vehicleSummaries,
 		TotalVehicles:    int32(len(vehicleSummaries)),
 		Period:           fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		FleetSummaries:   fleetSummaries,
+		FleetTotals:      convertSummaryTotalsToProto(fleet.Totals),
+		Rankings:         rankings,
 	}, nil
 }
 
@@ -120,24 +176,48 @@ func (s *DtakoRowsAggregationService) GetVehicleMonthlySummary(ctx context.Conte
 func (s *DtakoRowsAggregationService) GetDailySummary(ctx context.Context, req *pb.GetDailySummaryRequest) (*pb.DailySummaryResponse, error) {
 	log.Printf("GetDailySummary: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
 
-	rowsService := &DtakoRowsService{dbClient: s.dbClient}
-	dailyData, err := rowsService.GetDailySummary(ctx, req.CarCc, req.StartDate, req.EndDate)
+	return cachedResponse(ctx, s.cache, "GetDailySummary", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.DailySummaryResponse, error) {
+		return s.dailySummary(ctx, req)
+	})
+}
+
+// dailySummary 日次サマリー（キャッシュなし）
+func (s *DtakoRowsAggregationService) dailySummary(ctx context.Context, req *pb.GetDailySummaryRequest) (*pb.DailySummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketDay)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyData, err := s.rowsService.GetDailySummary(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
 	if err != nil {
 		return nil, err
 	}
 
 	// 内部型からproto型に変換
 	pbSummaries := make([]*pb.DailySummary, 0, len(dailyData))
-	for date, s := range dailyData {
+	for key, s := range dailyData {
 		pbSummaries = append(pbSummaries, &pb.DailySummary{
-			CarCc:         s.CarCC,
-			Date:          date,
-			TotalDistance: s.TotalDistance,
-			TotalFuel:     s.TotalFuel,
-			TripCount:     s.TripCount,
+			CarCc:                s.CarCC,
+			Date:                 key,
+			TotalDistance:        s.TotalDistance,
+			TotalFuel:            s.TotalFuel,
+			TripCount:            s.TripCount,
+			FuelEfficiency:       s.FuelEfficiency,
+			FuelEfficiencySource: s.FuelEfficiencySource,
+			FuelBasis:            s.FuelBasis,
+			MeasuredFuel:         s.MeasuredFuel,
+			EstimatedFuel:        s.EstimatedFuel,
+			RefuelCount:          s.RefuelCount,
+			Bucket:               convertBucketToProto(s.Bucket),
+			UncoveredDistance:    s.UncoveredDistance,
 		})
 	}
 
+	// 期間順に並べる
+	sort.Slice(pbSummaries, func(i, j int) bool {
+		return pbSummaries[i].Date < pbSummaries[j].Date
+	})
+
 	return &pb.DailySummaryResponse{
 		Summaries: pbSummaries,
 		CarCc:     req.CarCc,
@@ -145,31 +225,130 @@ func (s *DtakoRowsAggregationService) GetDailySummary(ctx context.Context, req *
 	}, nil
 }
 
-// ExportMonthlyFuelCSV CSV形式でエクスポート
+// ExportMonthlyFuelCSV CSV出力
 func (s *DtakoRowsAggregationService) ExportMonthlyFuelCSV(ctx context.Context, req *pb.GetMonthlyFuelConsumptionRequest) (*pb.ExportCSVResponse, error) {
 	log.Printf("ExportMonthlyFuelCSV: car_cc=%s", req.CarCc)
 
+	format, err := exportFormatFromProto(req.ExportOptions)
+	if err != nil {
+		return nil, err
+	}
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
 	// 月次データを取得
-	resp, err := s.GetMonthlyFuelConsumption(ctx, req)
+	summaries, err := s.rowsService.GetMonthlyFuelConsumption(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
 	if err != nil {
 		return nil, err
 	}
 
 	// CSV形式に変換
-	csv := "年月,車両CC,走行距離(km),給油量(L),運行回数,平均燃費(km/L)\n"
-	for _, s := range resp.Summaries {
-		csv += fmt.Sprintf("%s,%s,%.1f,%.1f,%d,%.2f\n",
-			s.YearMonth, s.CarCc, s.TotalDistance, s.TotalFuel, s.TripCount, s.AvgFuelEfficiency)
+	data, err := EncodeMonthlyFuelCSV(summaries, format)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ExportCSVResponse{
+		Filename:    fmt.Sprintf("monthly_fuel_%s_%s_%s.csv", req.CarCc, req.StartDate, req.EndDate),
+		Data:        data,
+		ContentType: format.Encoding.ContentType(),
+	}
+	// csv_data（string）はUTF-8でなければならないため、Shift_JISの場合は data のみ返す
+	if format.Encoding.IsUTF8() {
+		resp.CsvData = string(data)
+	}
+	return resp, nil
+}
+
+// ExportMonthlyFuelXLSX Excel出力（月次給油量）
+func (s *DtakoRowsAggregationService) ExportMonthlyFuelXLSX(ctx context.Context, req *pb.GetMonthlyFuelConsumptionRequest) (*pb.ExportFileResponse, error) {
+	log.Printf("ExportMonthlyFuelXLSX: car_cc=%s", req.CarCc)
+
+	format, err := exportFormatFromProto(req.ExportOptions)
+	if err != nil {
+		return nil, err
+	}
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.rowsService.GetMonthlyFuelConsumption(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeMonthlyFuelWorkbook(map[string][]*MonthlyFuelSummary{req.CarCc: summaries}, format)
+	if err != nil {
+		return nil, err
 	}
 
-	filename := fmt.Sprintf("monthly_fuel_%s_%s_%s.csv", req.CarCc, req.StartDate, req.EndDate)
+	return &pb.ExportFileResponse{
+		Data:        data,
+		Filename:    fmt.Sprintf("monthly_fuel_%s_%s_%s.xlsx", req.CarCc, req.StartDate, req.EndDate),
+		ContentType: export.ContentTypeXLSX,
+	}, nil
+}
+
+// ExportVehicleMonthlySummaryXLSX Excel出力（全車両の月次サマリー）
+func (s *DtakoRowsAggregationService) ExportVehicleMonthlySummaryXLSX(ctx context.Context, req *pb.GetVehicleMonthlySummaryRequest) (*pb.ExportFileResponse, error) {
+	log.Printf("ExportVehicleMonthlySummaryXLSX: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	format, err := exportFormatFromProto(req.ExportOptions)
+	if err != nil {
+		return nil, err
+	}
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summariesMap, err := s.rowsService.GetVehicleMonthlySummary(ctx, req.StartDate, req.EndDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := encodeMonthlyFuelWorkbook(summariesMap, format)
+	if err != nil {
+		return nil, err
+	}
 
-	return &pb.ExportCSVResponse{
-		CsvData:  csv,
-		Filename: filename,
+	return &pb.ExportFileResponse{
+		Data:        data,
+		Filename:    fmt.Sprintf("vehicle_monthly_summary_%s_%s.xlsx", req.StartDate, req.EndDate),
+		ContentType: export.ContentTypeXLSX,
 	}, nil
 }
 
+// encodeMonthlyFuelWorkbook 月次サマリーのExcelブックを作成してバイト列に変換
+func encodeMonthlyFuelWorkbook(vehicles map[string][]*MonthlyFuelSummary, format ExportFormat) ([]byte, error) {
+	workbook, err := BuildMonthlyFuelWorkbook(vehicles, format)
+	if err != nil {
+		return nil, err
+	}
+	data, err := workbook.Bytes()
+	if err != nil {
+		log.Printf("Failed to build workbook: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to build workbook: %v", err)
+	}
+	return data, nil
+}
+
+// bucketingFromProto リクエストの集計期間の区切り方を取得（省略時は defaultKind）
+func bucketingFromProto(b *pb.Bucketing, defaultKind string) (Bucketing, error) {
+	return NewBucketing(b.GetKind(), int(b.GetClosingDay()), int(b.GetFiscalYearStartMonth()), defaultKind)
+}
+
+// exportFormatFromProto 出力オプションのproto型を内部型に変換
+func exportFormatFromProto(opts *pb.ExportOptions) (ExportFormat, error) {
+	if opts == nil {
+		return NewExportFormat("", nil)
+	}
+	return NewExportFormat(opts.Encoding, opts.Columns)
+}
+
 // GetRow 運行データ取得（db_serviceプロキシ）
 func (s *DtakoRowsAggregationService) GetRow(ctx context.Context, req *pb.GetRowRequest) (*pb.RowResponse, error) {
 	log.Printf("GetRow (proxy): id=%s", req.Id)
@@ -190,51 +369,1233 @@ func (s *DtakoRowsAggregationService) GetRow(ctx context.Context, req *pb.GetRow
 	}, nil
 }
 
-// ListRows 運行データ一覧取得（db_serviceプロキシ）
+// ListRows の取得件数
+const (
+	defaultListLimit = 100  // limit 省略時
+	maxListLimit     = 1000 // limit の上限
+)
+
+// ListRows 運行データ一覧取得（フィルタ付き）
+//
+// フィルタ条件は ListWithFilter で評価し、total_count はフィルタ後の総件数を返します。
+// use_cursor または page_token を指定した場合はカーソルページングとなり、続きがあれば
+// next_page_token を返します（2ページ目以降は page_token を指定）。
+// それ以外はオフセットページングで、ページ間で同じ取得経路・並び順になります。
 func (s *DtakoRowsAggregationService) ListRows(ctx context.Context, req *pb.ListRowsRequest) (*pb.ListRowsResponse, error) {
-	log.Printf("ListRows (proxy): limit=%d, offset=%d", req.Limit, req.Offset)
+	log.Printf("ListRows: limit=%d, offset=%d, use_cursor=%t, page_token=%t, car_cc=%s, start=%s, end=%s",
+		req.Limit, req.Offset, req.UseCursor, req.PageToken != "", req.GetCarCc(), req.StartDate, req.EndDate)
 
-	// db_serviceから取得
-	dbResp, err := s.dbClient.List(ctx, &dbpb.Db_ListDTakoRowsRequest{
-		Limit:   req.Limit,
-		Offset:  req.Offset,
-		OrderBy: req.OrderBy,
-	})
-	if err != nil {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	filter := &FilterOptions{
+		CarCC:               req.CarCc,
+		MinDistance:         req.MinDistance,
+		OperationNos:        req.OperationNos,
+		ExcludeZeroDistance: req.ExcludeZeroDistance,
+		DriverCode:          req.DriverCode,
+		OrderBy:             req.GetOrderBy(),
+	}
+	if err := setDateFilter(filter, req.StartDate, req.EndDate); err != nil {
 		return nil, err
 	}
 
+	var dbRows []*dbpb.Db_DTakoRows
+	var totalCount int32
+	var plan *QueryPlan
+	nextPageToken := ""
+
+	if req.PageToken == "" && !req.UseCursor {
+		// オフセットページング
+		var err error
+		dbRows, totalCount, plan, err = s.rowsService.ListWithPlan(ctx, filter, limit, req.Offset)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// カーソルページング
+		if req.Offset > 0 || filter.OrderBy != "" {
+			return nil, status.Error(codes.InvalidArgument, "page_token and use_cursor cannot be combined with offset or order_by")
+		}
+
+		var cursor *RowCursor
+		if req.PageToken != "" {
+			var err error
+			cursor, totalCount, err = DecodePageToken(req.PageToken, filter)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// 総件数は1ページ目でのみ数え、以降はトークンに保持した値を返す
+		var next *RowCursor
+		var count int32
+		var err error
+		dbRows, next, count, plan, err = s.rowsService.ListWithCursor(ctx, filter, limit, cursor, cursor == nil)
+		if err != nil {
+			return nil, err
+		}
+		if cursor == nil {
+			totalCount = count
+		}
+		if next != nil {
+			nextPageToken = EncodePageToken(next, filter, totalCount)
+		}
+	}
+
 	// db_serviceの型からdtako_rowsの型に変換
-	rows := make([]*pb.Row, len(dbResp.Items))
-	for i, dbRow := range dbResp.Items {
+	rows := make([]*pb.Row, len(dbRows))
+	for i, dbRow := range dbRows {
 		rows[i] = convertDbRowToProto(dbRow)
 	}
 
 	return &pb.ListRowsResponse{
-		Rows:       rows,
-		TotalCount: dbResp.TotalCount,
+		Rows:          rows,
+		TotalCount:    totalCount,
+		QueryPath:     plan.Path,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// setDateFilter 開始日・終了日 (YYYY-MM-DD) をフィルタに設定（空の場合は制限なし）
+//
+// 終了日はその日の終わりまでを含みます（業務タイムゾーン）。
+func setDateFilter(filter *FilterOptions, startDate, endDate string) error {
+	if startDate != "" {
+		start, err := parseStartDate(startDate)
+		if err != nil {
+			return err
+		}
+		filter.StartDate = &start
+	}
+	if endDate != "" {
+		end, err := parseEndDate(endDate)
+		if err != nil {
+			return err
+		}
+		filter.EndDate = &end
+	}
+	return nil
+}
+
+// StreamVehicleMonthlySummary 全車両月次サマリー（ストリーミング）
+//
+// 車両ごとの集計が確定した時点で1車両ずつ送信します。
+func (s *DtakoRowsAggregationService) StreamVehicleMonthlySummary(req *pb.GetVehicleMonthlySummaryRequest, stream pb.DtakoRowsService_StreamVehicleMonthlySummaryServer) error {
+	log.Printf("StreamVehicleMonthlySummary: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return err
+	}
+
+	return s.rowsService.StreamVehicleMonthlySummary(stream.Context(), req.StartDate, req.EndDate, bucketing, func(carCC string, summaries []*MonthlyFuelSummary) error {
+		pbSummaries := make([]*pb.MonthlyFuelSummary, len(summaries))
+		var totals SummaryTotals
+		for i, s := range summaries {
+			pbSummaries[i] = convertMonthlySummaryToProto(s)
+			totals.add(s)
+		}
+
+		return stream.Send(&pb.VehicleMonthlySummaries{
+			CarCc:     carCC,
+			Summaries: pbSummaries,
+			Totals:    convertSummaryTotalsToProto(totals),
+		})
+	})
+}
+
+// StreamRows 運行データ一覧（ストリーミング）
+//
+// db_serviceから1ページ取得してフィルタリングするたびに、そのページの行を送信します。
+func (s *DtakoRowsAggregationService) StreamRows(req *pb.StreamRowsRequest, stream pb.DtakoRowsService_StreamRowsServer) error {
+	log.Printf("StreamRows: car_cc=%s, start=%s, end=%s", req.GetCarCc(), req.StartDate, req.EndDate)
+
+	filter := &FilterOptions{
+		CarCC: req.CarCc,
+	}
+	if err := setDateFilter(filter, req.StartDate, req.EndDate); err != nil {
+		return err
+	}
+
+	batchIndex := int32(0)
+	_, err := s.rowsService.ScanWithFilter(stream.Context(), filter, func(dbRows []*dbpb.Db_DTakoRows) error {
+		if len(dbRows) == 0 {
+			return nil
+		}
+
+		rows := make([]*pb.Row, len(dbRows))
+		for i, dbRow := range dbRows {
+			rows[i] = convertDbRowToProto(dbRow)
+		}
+
+		if err := stream.Send(&pb.RowBatch{
+			Rows:       rows,
+			BatchIndex: batchIndex,
+		}); err != nil {
+			return err
+		}
+		batchIndex++
+		return nil
+	})
+	return err
+}
+
+// GetDriverMonthlySummary 乗務員別月次サマリー
+func (s *DtakoRowsAggregationService) GetDriverMonthlySummary(ctx context.Context, req *pb.GetDriverSummaryRequest) (*pb.DriverSummaryResponse, error) {
+	log.Printf("GetDriverMonthlySummary: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetDriverMonthlySummary", "", req.StartDate, req.EndDate, req, func() (*pb.DriverSummaryResponse, error) {
+		return s.driverMonthlySummary(ctx, req)
+	})
+}
+
+// driverMonthlySummary 乗務員別月次サマリー（キャッシュなし）
+func (s *DtakoRowsAggregationService) driverMonthlySummary(ctx context.Context, req *pb.GetDriverSummaryRequest) (*pb.DriverSummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summariesMap, err := s.rowsService.GetDriverMonthlySummary(ctx, req.StartDate, req.EndDate, req.DriverCode, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertDriverSummariesToProto(summariesMap, req), nil
+}
+
+// GetDriverDailySummary 乗務員別日次サマリー
+func (s *DtakoRowsAggregationService) GetDriverDailySummary(ctx context.Context, req *pb.GetDriverSummaryRequest) (*pb.DriverSummaryResponse, error) {
+	log.Printf("GetDriverDailySummary: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetDriverDailySummary", "", req.StartDate, req.EndDate, req, func() (*pb.DriverSummaryResponse, error) {
+		return s.driverDailySummary(ctx, req)
+	})
+}
+
+// driverDailySummary 乗務員別日次サマリー（キャッシュなし）
+func (s *DtakoRowsAggregationService) driverDailySummary(ctx context.Context, req *pb.GetDriverSummaryRequest) (*pb.DriverSummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketDay)
+	if err != nil {
+		return nil, err
+	}
+
+	summariesMap, err := s.rowsService.GetDriverDailySummary(ctx, req.StartDate, req.EndDate, req.DriverCode, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertDriverSummariesToProto(summariesMap, req), nil
+}
+
+// GetLoadedRatioSummary 車両別月次実車率
+func (s *DtakoRowsAggregationService) GetLoadedRatioSummary(ctx context.Context, req *pb.GetLoadedRatioSummaryRequest) (*pb.LoadedRatioSummaryResponse, error) {
+	log.Printf("GetLoadedRatioSummary: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetLoadedRatioSummary", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.LoadedRatioSummaryResponse, error) {
+		return s.loadedRatioSummary(ctx, req)
+	})
+}
+
+// loadedRatioSummary 車両別月次実車率（キャッシュなし）
+func (s *DtakoRowsAggregationService) loadedRatioSummary(ctx context.Context, req *pb.GetLoadedRatioSummaryRequest) (*pb.LoadedRatioSummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.rowsService.GetLoadedRatioSummary(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing, LoadedRatioOptions{
+		PoorRatio:        req.PoorRatioThreshold,
+		PoorMonthsStreak: req.PoorMonthsStreak,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbVehicles := make([]*pb.VehicleLoadedRatio, len(vehicles))
+	flagged := int32(0)
+	for i, v := range vehicles {
+		pbSummaries := make([]*pb.LoadedRatioSummary, len(v.Summaries))
+		for j, s := range v.Summaries {
+			pbSummaries[j] = &pb.LoadedRatioSummary{
+				CarCc:               s.CarCC,
+				YearMonth:           s.YearMonth,
+				TotalDistance:       s.TotalDistance,
+				LoadedDistance:      s.LoadedDistance,
+				EmptyDistance:       s.EmptyDistance,
+				LoadedDriveTime:     s.LoadedDriveTime,
+				EmptyDriveTime:      s.EmptyDriveTime,
+				TripCount:           s.TripCount,
+				LoadedDistanceRatio: s.LoadedDistanceRatio,
+				LoadedTimeRatio:     s.LoadedTimeRatio,
+				Poor:                s.Poor,
+				Bucket:              convertBucketToProto(s.Bucket),
+			}
+		}
+
+		if v.Flagged {
+			flagged++
+		}
+		pbVehicles[i] = &pb.VehicleLoadedRatio{
+			CarCc:               v.CarCC,
+			Summaries:           pbSummaries,
+			LoadedDistanceRatio: v.LoadedDistanceRatio,
+			LoadedTimeRatio:     v.LoadedTimeRatio,
+			PoorMonths:          v.PoorMonths,
+			Flagged:             v.Flagged,
+		}
+	}
+
+	return &pb.LoadedRatioSummaryResponse{
+		Vehicles:        pbVehicles,
+		FlaggedVehicles: flagged,
+		Period:          fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+	}, nil
+}
+
+// CheckDriverCompliance 乗務員の改善基準告示チェック
+func (s *DtakoRowsAggregationService) CheckDriverCompliance(ctx context.Context, req *pb.CheckDriverComplianceRequest) (*pb.DriverComplianceResponse, error) {
+	log.Printf("CheckDriverCompliance: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "CheckDriverCompliance", "", req.StartDate, req.EndDate, req, func() (*pb.DriverComplianceResponse, error) {
+		return s.driverCompliance(ctx, req)
+	})
+}
+
+// driverCompliance 乗務員の改善基準告示チェック（キャッシュなし）
+func (s *DtakoRowsAggregationService) driverCompliance(ctx context.Context, req *pb.CheckDriverComplianceRequest) (*pb.DriverComplianceResponse, error) {
+	thresholds := convertThresholdsFromProto(req.Thresholds).withDefaults()
+	drivers, violations, err := s.rowsService.CheckDriverCompliance(ctx, req.StartDate, req.EndDate, req.DriverCode, thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbDrivers := make([]*pb.DriverCompliance, len(drivers))
+	for i, d := range drivers {
+		monthly := make([]*pb.DriverMonthlyCompliance, len(d.Monthly))
+		for j, m := range d.Monthly {
+			monthly[j] = &pb.DriverMonthlyCompliance{
+				YearMonth:        m.YearMonth,
+				RestraintMinutes: minutes(m.Restraint),
+				DrivingMinutes:   minutes(m.Driving),
+				ShiftCount:       m.ShiftCount,
+			}
+		}
+		pbDrivers[i] = &pb.DriverCompliance{
+			DriverCode:               d.DriverCode,
+			Monthly:                  monthly,
+			MaxDailyRestraintMinutes: minutes(d.MaxDailyRestraint),
+			MinRestIntervalMinutes:   minutes(d.MinRestInterval),
+			ViolationCount:           d.ViolationCount,
+			WarningCount:             d.WarningCount,
+		}
+	}
+
+	pbViolations := make([]*pb.ComplianceViolation, len(violations))
+	for i, v := range violations {
+		pbViolations[i] = &pb.ComplianceViolation{
+			DriverCode:    v.DriverCode,
+			Rule:          v.Rule,
+			Severity:      v.Severity,
+			Date:          v.Date,
+			ActualMinutes: minutes(v.Actual),
+			LimitMinutes:  minutes(v.Limit),
+			RowIds:        v.RowIDs,
+			Message:       v.Message,
+		}
+	}
+
+	return &pb.DriverComplianceResponse{
+		Drivers:           pbDrivers,
+		Violations:        pbViolations,
+		AppliedThresholds: convertThresholdsToProto(thresholds),
+		Period:            fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+	}, nil
+}
+
+// ValidateRows 運行データの品質チェック
+func (s *DtakoRowsAggregationService) ValidateRows(ctx context.Context, req *pb.ValidateRowsRequest) (*pb.ValidationReport, error) {
+	log.Printf("ValidateRows: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
+
+	opts := ValidationOptions{
+		MeterToleranceKm:    req.MeterToleranceKm,
+		DistanceToleranceKm: req.DistanceToleranceKm,
+	}
+	report, err := s.rowsService.ValidateRows(ctx, req.CarCc, req.StartDate, req.EndDate, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbIssues := make([]*pb.ValidationIssue, len(report.Issues))
+	for i, issue := range report.Issues {
+		pbIssues[i] = &pb.ValidationIssue{
+			Code:     issue.Code,
+			Severity: issue.Severity,
+			CarCc:    issue.CarCC,
+			RowIds:   issue.RowIDs,
+			Field:    issue.Field,
+			Message:  issue.Message,
+		}
+	}
+
+	return &pb.ValidationReport{
+		Issues:          pbIssues,
+		RowsChecked:     report.RowsChecked,
+		VehiclesChecked: report.VehiclesChecked,
+		ErrorCount:      report.ErrorCount,
+		WarningCount:    report.WarningCount,
+		Period:          fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
 	}, nil
 }
 
+// CompareVehiclePeriods 車両ごとの2期間比較
+func (s *DtakoRowsAggregationService) CompareVehiclePeriods(ctx context.Context, req *pb.CompareVehiclePeriodsRequest) (*pb.CompareVehiclePeriodsResponse, error) {
+	if req.Current == nil || req.Current.StartDate == "" || req.Current.EndDate == "" {
+		return nil, status.Error(codes.InvalidArgument, "current period is required")
+	}
+	current := DateRange{StartDate: req.Current.StartDate, EndDate: req.Current.EndDate}
+
+	// 基準期間を省略した場合は前年同期と比較
+	var baseline DateRange
+	if req.Baseline == nil || (req.Baseline.StartDate == "" && req.Baseline.EndDate == "") {
+		var err error
+		baseline, err = SameRangeLastYear(current)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		baseline = DateRange{StartDate: req.Baseline.StartDate, EndDate: req.Baseline.EndDate}
+	}
+
+	log.Printf("CompareVehiclePeriods: current=%s~%s, baseline=%s~%s, car_cc=%s",
+		current.StartDate, current.EndDate, baseline.StartDate, baseline.EndDate, req.CarCc)
+
+	// キャッシュの期間は2期間を含む範囲
+	start, end := current.StartDate, current.EndDate
+	if baseline.StartDate < start {
+		start = baseline.StartDate
+	}
+	if baseline.EndDate > end {
+		end = baseline.EndDate
+	}
+
+	return cachedResponse(ctx, s.cache, "CompareVehiclePeriods", req.CarCc, start, end, req, func() (*pb.CompareVehiclePeriodsResponse, error) {
+		vehicles, fleet, err := s.rowsService.CompareVehiclePeriods(ctx, current, baseline, req.CarCc)
+		if err != nil {
+			return nil, err
+		}
+
+		pbVehicles := make([]*pb.VehiclePeriodComparison, len(vehicles))
+		for i, v := range vehicles {
+			pbVehicles[i] = convertVehicleComparisonToProto(v)
+		}
+		return &pb.CompareVehiclePeriodsResponse{
+			Vehicles: pbVehicles,
+			Fleet:    convertVehicleComparisonToProto(fleet),
+			Current:  &pb.DateRange{StartDate: current.StartDate, EndDate: current.EndDate},
+			Baseline: &pb.DateRange{StartDate: baseline.StartDate, EndDate: baseline.EndDate},
+		}, nil
+	})
+}
+
+// GetOfficeMonthlySummary 事業所別月次サマリー
+func (s *DtakoRowsAggregationService) GetOfficeMonthlySummary(ctx context.Context, req *pb.GetOfficeMonthlySummaryRequest) (*pb.OfficeMonthlySummaryResponse, error) {
+	log.Printf("GetOfficeMonthlySummary: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetOfficeMonthlySummary", "", req.StartDate, req.EndDate, req, func() (*pb.OfficeMonthlySummaryResponse, error) {
+		return s.officeMonthlySummary(ctx, req)
+	})
+}
+
+// officeMonthlySummary 事業所別月次サマリー（キャッシュなし）
+func (s *DtakoRowsAggregationService) officeMonthlySummary(ctx context.Context, req *pb.GetOfficeMonthlySummaryRequest) (*pb.OfficeMonthlySummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	offices, err := s.rowsService.GetOfficeMonthlySummary(ctx, req.StartDate, req.EndDate, req.OfficeCode, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbOffices := make([]*pb.OfficeSummaries, len(offices))
+	for i, office := range offices {
+		pbSummaries := make([]*pb.OfficePeriodSummary, len(office.Summaries))
+		for j, summary := range office.Summaries {
+			pbSummaries[j] = convertOfficeSummaryToProto(summary)
+		}
+		pbOffices[i] = &pb.OfficeSummaries{
+			OfficeCode: office.OfficeCode,
+			Summaries:  pbSummaries,
+			Total:      convertOfficeSummaryToProto(office.Total),
+			CarCcs:     office.CarCCs,
+		}
+	}
+
+	return &pb.OfficeMonthlySummaryResponse{
+		Offices:            pbOffices,
+		TotalOffices:       int32(len(pbOffices)),
+		Period:             fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		CarMasterAvailable: s.rowsService.cars.Available(),
+	}, nil
+}
+
+// convertOfficeSummaryToProto 事業所サマリーの内部型をproto型に変換
+func convertOfficeSummaryToProto(s *OfficeSummary) *pb.OfficePeriodSummary {
+	summary := &pb.OfficePeriodSummary{
+		OfficeCode:            s.OfficeCode,
+		Period:                s.Period,
+		TotalDistance:         s.TotalDistance,
+		TotalFuel:             s.TotalFuel,
+		MeasuredFuel:          s.MeasuredFuel,
+		EstimatedFuel:         s.EstimatedFuel,
+		TripCount:             s.TripCount,
+		ActiveVehicles:        s.ActiveVehicles,
+		AvgDistancePerVehicle: s.PerVehicle(s.TotalDistance),
+		AvgFuelPerVehicle:     s.PerVehicle(s.TotalFuel),
+		AvgTripsPerVehicle:    s.PerVehicle(float64(s.TripCount)),
+		AvgFuelEfficiency:     s.AvgFuelEfficiency(),
+	}
+	if s.Period != "" {
+		summary.Bucket = convertBucketToProto(s.Bucket)
+	}
+	return summary
+}
+
+// GetTollSummary 通行料金（ETC明細）の集計
+func (s *DtakoRowsAggregationService) GetTollSummary(ctx context.Context, req *pb.GetTollSummaryRequest) (*pb.TollSummaryResponse, error) {
+	log.Printf("GetTollSummary: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetTollSummary", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.TollSummaryResponse, error) {
+		return s.tollSummary(ctx, req)
+	})
+}
+
+// tollSummary 通行料金（ETC明細）の集計（キャッシュなし）
+func (s *DtakoRowsAggregationService) tollSummary(ctx context.Context, req *pb.GetTollSummaryRequest) (*pb.TollSummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.rowsService.GetTollSummary(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbTrips := make([]*pb.TripToll, len(summary.Trips))
+	for i, t := range summary.Trips {
+		pbTrips[i] = &pb.TripToll{
+			RowId:          t.RowID,
+			OperationNo:    t.OperationNo,
+			CarCc:          t.CarCC,
+			OperationDate:  t.OperationDate.Format("2006-01-02"),
+			TotalDistance:  t.TotalDistance,
+			TollAmount:     t.TollAmount,
+			TollCount:      t.TollCount,
+			EstimatedCount: t.EstimatedCount,
+			Bucket:         convertBucketToProto(t.Bucket),
+		}
+	}
+
+	pbVehicles := make([]*pb.VehicleTolls, len(summary.Vehicles))
+	for i, v := range summary.Vehicles {
+		pbSummaries := make([]*pb.TollPeriodSummary, len(v.Summaries))
+		for j, p := range v.Summaries {
+			pbSummaries[j] = convertTollSummaryToProto(p)
+		}
+		pbVehicles[i] = &pb.VehicleTolls{
+			CarCc:     v.CarCC,
+			Summaries: pbSummaries,
+			Total:     convertTollSummaryToProto(v.Total),
+		}
+	}
+
+	pbPeriods := make([]*pb.TollPeriodSummary, len(summary.Periods))
+	for i, p := range summary.Periods {
+		pbPeriods[i] = convertTollSummaryToProto(p)
+	}
+
+	pbUnmatched := make([]*pb.UnmatchedETCRecord, len(summary.Unmatched))
+	for i, u := range summary.Unmatched {
+		usedAt := u.Record.DateTo
+		if !u.UsedAt.IsZero() {
+			usedAt = u.UsedAt.Format(time.RFC3339)
+		}
+		pbUnmatched[i] = &pb.UnmatchedETCRecord{
+			Hash:       u.Record.Hash,
+			UsedAt:     usedAt,
+			EntryIc:    u.Record.GetIcFr(),
+			ExitIc:     u.Record.IcTo,
+			Price:      u.Record.Price,
+			EtcCardNum: u.Record.EtcNum,
+			CarCc:      u.CarCC,
+			Reason:     u.Reason,
+		}
+	}
+
+	return &pb.TollSummaryResponse{
+		Trips:           pbTrips,
+		Vehicles:        pbVehicles,
+		Periods:         pbPeriods,
+		Total:           convertTollSummaryToProto(summary.Total),
+		Unmatched:       pbUnmatched,
+		UnmatchedAmount: summary.UnmatchedAmount(),
+		Period:          fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		EtcAvailable:    summary.Available,
+	}, nil
+}
+
+// convertTollSummaryToProto 期間ごとの通行料金の内部型をproto型に変換
+func convertTollSummaryToProto(s *TollPeriodSummary) *pb.TollPeriodSummary {
+	summary := &pb.TollPeriodSummary{
+		CarCc:           s.CarCC,
+		Period:          s.Period,
+		TotalDistance:   s.TotalDistance,
+		TollAmount:      s.TollAmount,
+		TollCount:       s.TollCount,
+		TripCount:       s.TripCount,
+		TolledTripCount: s.TolledTripCount,
+		TollPerKm:       s.TollPerKm(),
+	}
+	if s.Period != "" {
+		summary.Bucket = convertBucketToProto(s.Bucket)
+	}
+	return summary
+}
+
+// GetFerrySummary フェリー利用の集計
+func (s *DtakoRowsAggregationService) GetFerrySummary(ctx context.Context, req *pb.GetFerrySummaryRequest) (*pb.FerrySummaryResponse, error) {
+	log.Printf("GetFerrySummary: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetFerrySummary", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.FerrySummaryResponse, error) {
+		return s.ferrySummary(ctx, req)
+	})
+}
+
+// ferrySummary フェリー利用の集計（キャッシュなし）
+func (s *DtakoRowsAggregationService) ferrySummary(ctx context.Context, req *pb.GetFerrySummaryRequest) (*pb.FerrySummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.rowsService.GetFerrySummary(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbVehicles := make([]*pb.VehicleFerries, len(summary.Vehicles))
+	for i, v := range summary.Vehicles {
+		pbSummaries := make([]*pb.FerryPeriodSummary, len(v.Summaries))
+		for j, p := range v.Summaries {
+			pbSummaries[j] = convertFerrySummaryToProto(p)
+		}
+		pbVehicles[i] = &pb.VehicleFerries{
+			CarCc:     v.CarCC,
+			Summaries: pbSummaries,
+			Total:     convertFerrySummaryToProto(v.Total),
+		}
+	}
+
+	pbPeriods := make([]*pb.FerryPeriodSummary, len(summary.Periods))
+	for i, p := range summary.Periods {
+		pbPeriods[i] = convertFerrySummaryToProto(p)
+	}
+
+	return &pb.FerrySummaryResponse{
+		Vehicles:       pbVehicles,
+		Periods:        pbPeriods,
+		Total:          convertFerrySummaryToProto(summary.Total),
+		Period:         fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		FerryAvailable: summary.Available,
+	}, nil
+}
+
+// convertFerrySummaryToProto 期間ごとのフェリー利用の内部型をproto型に変換
+func convertFerrySummaryToProto(s *FerryPeriodSummary) *pb.FerryPeriodSummary {
+	summary := &pb.FerryPeriodSummary{
+		CarCc:             s.CarCC,
+		Period:            s.Period,
+		Crossings:         s.Crossings,
+		FerryCost:         s.FerryCost,
+		StandardFare:      s.StandardFare,
+		DeemedDistance:    s.DeemedDistance,
+		TotalDistance:     s.TotalDistance,
+		DistanceWithFerry: s.DistanceWithFerry(),
+		TripCount:         s.TripCount,
+		FerryTripCount:    s.FerryTripCount,
+	}
+	if s.Period != "" {
+		summary.Bucket = convertBucketToProto(s.Bucket)
+	}
+	return summary
+}
+
+// GetTripProfitability 運行・車両・期間ごとの採算の集計
+func (s *DtakoRowsAggregationService) GetTripProfitability(ctx context.Context, req *pb.GetTripProfitabilityRequest) (*pb.TripProfitabilityResponse, error) {
+	log.Printf("GetTripProfitability: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetTripProfitability", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.TripProfitabilityResponse, error) {
+		return s.tripProfitability(ctx, req)
+	})
+}
+
+// tripProfitability 運行・車両・期間ごとの採算の集計（キャッシュなし）
+func (s *DtakoRowsAggregationService) tripProfitability(ctx context.Context, req *pb.GetTripProfitabilityRequest) (*pb.TripProfitabilityResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := ProfitOptions{
+		FuelPricePerLiter: req.FuelPricePerLiter,
+		RevenueCodes:      req.RevenueKeihiCodes,
+	}
+
+	report, err := s.rowsService.GetTripProfitability(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbTrips := make([]*pb.TripProfit, len(report.Trips))
+	for i, t := range report.Trips {
+		pbLines := make([]*pb.KeihiAmount, len(t.Lines))
+		for j, l := range t.Lines {
+			pbLines[j] = &pb.KeihiAmount{
+				KeihiC:  l.KeihiC,
+				Revenue: l.Revenue,
+				Amount:  l.Amount,
+				Km:      l.Km,
+				Count:   l.Count,
+			}
+		}
+		pbTrips[i] = &pb.TripProfit{
+			RowId:         t.RowID,
+			OperationNo:   t.OperationNo,
+			CarCc:         t.CarCC,
+			OperationDate: t.OperationDate.Format("2006-01-02"),
+			Bucket:        convertBucketToProto(t.Bucket),
+			Totals:        convertProfitTotalsToProto(t.ProfitTotals),
+			Lines:         pbLines,
+		}
+	}
+
+	pbVehicles := make([]*pb.VehicleProfit, len(report.Vehicles))
+	for i, v := range report.Vehicles {
+		pbSummaries := make([]*pb.ProfitPeriodSummary, len(v.Summaries))
+		for j, p := range v.Summaries {
+			pbSummaries[j] = convertProfitSummaryToProto(p)
+		}
+		pbVehicles[i] = &pb.VehicleProfit{
+			CarCc:     v.CarCC,
+			Summaries: pbSummaries,
+			Total:     convertProfitSummaryToProto(v.Total),
+		}
+	}
+
+	pbPeriods := make([]*pb.ProfitPeriodSummary, len(report.Periods))
+	for i, p := range report.Periods {
+		pbPeriods[i] = convertProfitSummaryToProto(p)
+	}
+
+	return &pb.TripProfitabilityResponse{
+		Trips:              pbTrips,
+		Vehicles:           pbVehicles,
+		Periods:            pbPeriods,
+		Total:              convertProfitSummaryToProto(report.Total),
+		Period:             fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		FuelPricePerLiter:  report.Options.fuelPrice(),
+		RevenueKeihiCodes:  report.Options.RevenueCodes,
+		UnlinkedKeihiCount: report.UnlinkedKeihi,
+		KeihiAvailable:     report.KeihiAvailable,
+		EtcAvailable:       report.TollsAvailable,
+		FerryAvailable:     report.FerriesAvailable,
+	}, nil
+}
+
+// convertProfitSummaryToProto 期間ごとの採算の内部型をproto型に変換
+func convertProfitSummaryToProto(s *ProfitPeriodSummary) *pb.ProfitPeriodSummary {
+	summary := &pb.ProfitPeriodSummary{
+		CarCc:  s.CarCC,
+		Period: s.Period,
+		Totals: convertProfitTotalsToProto(s.ProfitTotals),
+	}
+	if s.Period != "" {
+		summary.Bucket = convertBucketToProto(s.Bucket)
+	}
+	return summary
+}
+
+// convertProfitTotalsToProto 売上・費用の合計の内部型をproto型に変換
+func convertProfitTotalsToProto(t ProfitTotals) *pb.ProfitTotals {
+	return &pb.ProfitTotals{
+		TotalDistance: t.TotalDistance,
+		TripCount:     t.TripCount,
+		Revenue:       t.Revenue,
+		Expenses:      t.Expenses,
+		FuelLiters:    t.FuelLiters,
+		FuelCost:      t.FuelCost,
+		TollCost:      t.TollCost,
+		FerryCost:     t.FerryCost,
+		TotalCost:     t.TotalCost(),
+		GrossMargin:   t.GrossMargin(),
+		MarginRatio:   t.MarginRatio(),
+		RevenuePerKm:  t.RevenuePerKm(),
+	}
+}
+
+// GetTripEventSummary 運行のイベント集計
+func (s *DtakoRowsAggregationService) GetTripEventSummary(ctx context.Context, req *pb.GetTripEventSummaryRequest) (*pb.TripEventSummaryResponse, error) {
+	log.Printf("GetTripEventSummary: operation_no=%s", req.OperationNo)
+
+	summary, err := s.rowsService.GetTripEventSummary(ctx, req.OperationNo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	resp := &pb.TripEventSummaryResponse{
+		OperationNo:     summary.OperationNo,
+		CarCc:           summary.CarCC,
+		DriverCodes:     summary.DriverCodes,
+		SpanSeconds:     int64(summary.Span().Seconds()),
+		IdlingRatio:     summary.IdlingRatio(),
+		Breakdown:       convertEventBreakdownToProto(summary.Breakdown),
+		EventsAvailable: summary.Available,
+	}
+	if !summary.FirstStart.IsZero() {
+		resp.FirstStart = summary.FirstStart.Format(time.RFC3339)
+	}
+	if !summary.LastEnd.IsZero() {
+		resp.LastEnd = summary.LastEnd.Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+// GetEventSummary 車両・乗務員・期間ごとのイベント集計
+func (s *DtakoRowsAggregationService) GetEventSummary(ctx context.Context, req *pb.GetEventSummaryRequest) (*pb.EventSummaryResponse, error) {
+	log.Printf("GetEventSummary: car_cc=%s, start=%s, end=%s", req.CarCc, req.StartDate, req.EndDate)
+
+	return cachedResponse(ctx, s.cache, "GetEventSummary", req.CarCc, req.StartDate, req.EndDate, req, func() (*pb.EventSummaryResponse, error) {
+		return s.eventSummary(ctx, req)
+	})
+}
+
+// eventSummary 車両・乗務員・期間ごとのイベント集計（キャッシュなし）
+func (s *DtakoRowsAggregationService) eventSummary(ctx context.Context, req *pb.GetEventSummaryRequest) (*pb.EventSummaryResponse, error) {
+	bucketing, err := bucketingFromProto(req.Bucketing, BucketMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.rowsService.GetEventSummary(ctx, req.CarCc, req.StartDate, req.EndDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbVehicles := make([]*pb.VehicleEvents, len(summary.Vehicles))
+	for i, v := range summary.Vehicles {
+		pbSummaries := make([]*pb.EventPeriodSummary, len(v.Summaries))
+		for j, p := range v.Summaries {
+			pbSummaries[j] = convertEventSummaryToProto(p)
+		}
+		pbVehicles[i] = &pb.VehicleEvents{
+			CarCc:     v.CarCC,
+			Summaries: pbSummaries,
+			Total:     convertEventSummaryToProto(v.Total),
+		}
+	}
+
+	pbDrivers := make([]*pb.DriverEvents, len(summary.Drivers))
+	for i, d := range summary.Drivers {
+		pbDrivers[i] = &pb.DriverEvents{
+			DriverCode: d.DriverCode,
+			Breakdown:  convertEventBreakdownToProto(d.Breakdown),
+		}
+	}
+
+	pbPeriods := make([]*pb.EventPeriodSummary, len(summary.Periods))
+	for i, p := range summary.Periods {
+		pbPeriods[i] = convertEventSummaryToProto(p)
+	}
+
+	return &pb.EventSummaryResponse{
+		Vehicles:        pbVehicles,
+		Drivers:         pbDrivers,
+		Periods:         pbPeriods,
+		Total:           convertEventSummaryToProto(summary.Total),
+		Period:          fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+		EventsAvailable: summary.Available,
+	}, nil
+}
+
+// convertEventSummaryToProto 期間ごとのイベント集計の内部型をproto型に変換
+func convertEventSummaryToProto(s *EventPeriodSummary) *pb.EventPeriodSummary {
+	summary := &pb.EventPeriodSummary{
+		CarCc:     s.CarCC,
+		Period:    s.Period,
+		Breakdown: convertEventBreakdownToProto(s.Breakdown),
+	}
+	if s.Period != "" {
+		summary.Bucket = convertBucketToProto(s.Bucket)
+	}
+	return summary
+}
+
+// convertEventBreakdownToProto イベントCD別の集計の内部型をproto型に変換
+func convertEventBreakdownToProto(b *EventBreakdown) *pb.EventBreakdown {
+	codes := b.Codes()
+	pbCodes := make([]*pb.EventCodeTotals, len(codes))
+	for i, c := range codes {
+		pbCodes[i] = &pb.EventCodeTotals{
+			EventCode:       c.EventCode,
+			EventName:       c.EventName,
+			Category:        string(c.Category),
+			Count:           c.Count,
+			DurationSeconds: int64(c.Duration.Seconds()),
+			SectionTime:     c.SectionTime,
+			Distance:        c.Distance,
+		}
+	}
+
+	categories := b.Categories()
+	pbCategories := make([]*pb.EventCategoryTotals, len(categories))
+	for i, c := range categories {
+		pbCategories[i] = &pb.EventCategoryTotals{
+			Category:        string(c.Category),
+			Count:           c.Count,
+			DurationSeconds: int64(c.Duration.Seconds()),
+			Distance:        c.Distance,
+		}
+	}
+
+	return &pb.EventBreakdown{
+		TripCount:     b.TripCount,
+		EventCount:    b.EventCount(),
+		Codes:         pbCodes,
+		Categories:    pbCategories,
+		IdlingSeconds: int64(b.CategoryDuration(EventCategoryIdling).Seconds()),
+	}
+}
+
+// GetTripRoute 運行の経路
+func (s *DtakoRowsAggregationService) GetTripRoute(ctx context.Context, req *pb.GetTripRouteRequest) (*pb.TripRouteResponse, error) {
+	log.Printf("GetTripRoute: operation_no=%s", req.OperationNo)
+
+	route, err := s.rowsService.GetTripRoute(ctx, req.OperationNo)
+	if err != nil {
+		return nil, err
+	}
+
+	// 内部型からproto型に変換
+	pbPoints := make([]*pb.RoutePoint, len(route.Points))
+	for i, p := range route.Points {
+		pbPoints[i] = &pb.RoutePoint{
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+			PlaceName: p.PlaceName,
+			CityName:  p.CityName,
+			EventCode: p.EventCode,
+			EventName: p.EventName,
+			Edge:      p.Edge,
+			Mileage:   p.Mileage,
+		}
+		if !p.Time.IsZero() {
+			pbPoints[i].Time = p.Time.Format(time.RFC3339)
+		}
+	}
+
+	return &pb.TripRouteResponse{
+		OperationNo:     route.OperationNo,
+		CarCc:           route.CarCC,
+		Points:          pbPoints,
+		EventCount:      route.EventCount,
+		DroppedCount:    route.DroppedCount,
+		Distance:        route.Distance(),
+		EventsAvailable: route.Available,
+	}, nil
+}
+
+// ExportTripRouteGeoJSON 運行の経路をGeoJSONで出力
+func (s *DtakoRowsAggregationService) ExportTripRouteGeoJSON(ctx context.Context, req *pb.GetTripRouteRequest) (*pb.ExportFileResponse, error) {
+	log.Printf("ExportTripRouteGeoJSON: operation_no=%s", req.OperationNo)
+
+	route, err := s.rowsService.GetTripRoute(ctx, req.OperationNo)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := export.EncodeGeoJSON(route.OperationNo, route.GeoPoints())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode GeoJSON: %v", err)
+	}
+
+	return &pb.ExportFileResponse{
+		Data:        data,
+		Filename:    fmt.Sprintf("route_%s.geojson", route.OperationNo),
+		ContentType: export.ContentTypeGeoJSON,
+	}, nil
+}
+
+// ExportTripRouteGPX 運行の経路をGPXで出力
+func (s *DtakoRowsAggregationService) ExportTripRouteGPX(ctx context.Context, req *pb.GetTripRouteRequest) (*pb.ExportFileResponse, error) {
+	log.Printf("ExportTripRouteGPX: operation_no=%s", req.OperationNo)
+
+	route, err := s.rowsService.GetTripRoute(ctx, req.OperationNo)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := export.EncodeGPX(route.OperationNo, route.GeoPoints())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode GPX: %v", err)
+	}
+
+	return &pb.ExportFileResponse{
+		Data:        data,
+		Filename:    fmt.Sprintf("route_%s.gpx", route.OperationNo),
+		ContentType: export.ContentTypeGPX,
+	}, nil
+}
+
+// convertVehicleComparisonToProto 期間比較の内部型をproto型に変換
+func convertVehicleComparisonToProto(v *VehicleComparison) *pb.VehiclePeriodComparison {
+	totals := func(t PeriodTotals) *pb.PeriodTotals {
+		return &pb.PeriodTotals{
+			TotalDistance: t.TotalDistance,
+			TotalFuel:     t.TotalFuel,
+			TripCount:     t.TripCount,
+			Present:       t.Present,
+		}
+	}
+	delta := func(d Delta) *pb.PeriodDelta {
+		return &pb.PeriodDelta{Absolute: d.Absolute, Percent: d.Percent}
+	}
+	return &pb.VehiclePeriodComparison{
+		CarCc:    v.CarCC,
+		Presence: v.Presence,
+		Current:  totals(v.Current),
+		Baseline: totals(v.Baseline),
+		Distance: delta(v.Distance),
+		Fuel:     delta(v.Fuel),
+		Trips:    delta(v.Trips),
+	}
+}
+
+// GetCacheStats 集計キャッシュの統計
+func (s *DtakoRowsAggregationService) GetCacheStats(ctx context.Context, req *pb.GetCacheStatsRequest) (*pb.CacheStatsResponse, error) {
+	if s.cache == nil {
+		return &pb.CacheStatsResponse{Enabled: false}, nil
+	}
+
+	stats := s.cache.Stats()
+	resp := &pb.CacheStatsResponse{
+		Enabled:           stats.Enabled,
+		Hits:              stats.Hits,
+		Misses:            stats.Misses,
+		HitRatio:          stats.HitRatio(),
+		Entries:           int32(stats.Entries),
+		Invalidations:     stats.Invalidations,
+		Expirations:       stats.Expirations,
+		Evictions:         stats.Evictions,
+		ReadDateWatermark: stats.Watermark,
+	}
+	if !stats.LastChecked.IsZero() {
+		resp.LastCheckedAt = stats.LastChecked.Format(time.RFC3339)
+	}
+	for _, rpc := range stats.RPCNames() {
+		counter := stats.ByRPC[rpc]
+		resp.Rpcs = append(resp.Rpcs, &pb.RPCCacheStats{
+			Rpc:     rpc,
+			Hits:    counter.Hits,
+			Misses:  counter.Misses,
+			Entries: int32(stats.EntriesByRPC[rpc]),
+		})
+	}
+	return resp, nil
+}
+
+// convertThresholdsFromProto しきい値のproto型を内部型に変換
+func convertThresholdsFromProto(t *pb.ComplianceThresholds) ComplianceThresholds {
+	if t == nil {
+		return ComplianceThresholds{}
+	}
+	return ComplianceThresholds{
+		MaxDailyRestraint:       time.Duration(t.MaxDailyRestraintMinutes) * time.Minute,
+		ExtendedDailyRestraint:  time.Duration(t.ExtendedDailyRestraintMinutes) * time.Minute,
+		MinRestInterval:         time.Duration(t.MinRestIntervalMinutes) * time.Minute,
+		RecommendedRestInterval: time.Duration(t.RecommendedRestIntervalMinutes) * time.Minute,
+		MaxContinuousDriving:    time.Duration(t.MaxContinuousDrivingMinutes) * time.Minute,
+		MinBreak:                time.Duration(t.MinBreakMinutes) * time.Minute,
+		MaxTwoDayAvgDriving:     time.Duration(t.MaxTwoDayAvgDrivingMinutes) * time.Minute,
+		MaxMonthlyRestraint:     time.Duration(t.MaxMonthlyRestraintMinutes) * time.Minute,
+	}
+}
+
+// convertThresholdsToProto しきい値の内部型をproto型に変換
+func convertThresholdsToProto(t ComplianceThresholds) *pb.ComplianceThresholds {
+	return &pb.ComplianceThresholds{
+		MaxDailyRestraintMinutes:       minutes(t.MaxDailyRestraint),
+		ExtendedDailyRestraintMinutes:  minutes(t.ExtendedDailyRestraint),
+		MinRestIntervalMinutes:         minutes(t.MinRestInterval),
+		RecommendedRestIntervalMinutes: minutes(t.RecommendedRestInterval),
+		MaxContinuousDrivingMinutes:    minutes(t.MaxContinuousDriving),
+		MinBreakMinutes:                minutes(t.MinBreak),
+		MaxTwoDayAvgDrivingMinutes:     minutes(t.MaxTwoDayAvgDriving),
+		MaxMonthlyRestraintMinutes:     minutes(t.MaxMonthlyRestraint),
+	}
+}
+
+// minutes 時間を分単位の整数に変換
+func minutes(d time.Duration) int32 {
+	return int32(d / time.Minute)
+}
+
+// convertDriverSummariesToProto 乗務員別サマリーの内部型をproto型に変換（乗務員CD1順）
+func convertDriverSummariesToProto(summariesMap map[string][]*DriverSummary, req *pb.GetDriverSummaryRequest) *pb.DriverSummaryResponse {
+	codes := make([]string, 0, len(summariesMap))
+	for code := range summariesMap {
+		codes = append(codes, code)
+	}
+	sortDriverCodes(codes)
+
+	driverSummaries := make([]*pb.DriverSummaries, 0, len(codes))
+	for _, code := range codes {
+		summaries := summariesMap[code]
+		pbSummaries := make([]*pb.DriverPeriodSummary, len(summaries))
+		for i, s := range summaries {
+			pbSummaries[i] = &pb.DriverPeriodSummary{
+				DriverCode:           s.DriverCode,
+				Period:               s.Period,
+				TotalDistance:        s.TotalDistance,
+				LoadedDistance:       s.LoadedDistance,
+				TripCount:            s.TripCount,
+				GeneralRoadDriveTime: s.GeneralRoadDriveTime,
+				HighwayDriveTime:     s.HighwayDriveTime,
+				BypassDriveTime:      s.BypassDriveTime,
+				Work1Time:            s.Work1Time,
+				Work2Time:            s.Work2Time,
+				Work3Time:            s.Work3Time,
+				Work4Time:            s.Work4Time,
+				Bucket:               convertBucketToProto(s.Bucket),
+			}
+		}
+
+		driverSummaries = append(driverSummaries, &pb.DriverSummaries{
+			DriverCode: code,
+			Summaries:  pbSummaries,
+		})
+	}
+
+	return &pb.DriverSummaryResponse{
+		DriverSummaries: driverSummaries,
+		TotalDrivers:    int32(len(driverSummaries)),
+		Period:          fmt.Sprintf("%s ~ %s", req.StartDate, req.EndDate),
+	}
+}
+
+// convertMonthlySummaryToProto 月次サマリーの内部型をproto型に変換
+func convertMonthlySummaryToProto(s *MonthlyFuelSummary) *pb.MonthlyFuelSummary {
+	return &pb.MonthlyFuelSummary{
+		CarCc:                s.CarCC,
+		YearMonth:            s.YearMonth,
+		TotalDistance:        s.TotalDistance,
+		TotalFuel:            s.TotalFuel,
+		TripCount:            s.TripCount,
+		AvgFuelEfficiency:    averageFuelEfficiency(s.TotalDistance, s.TotalFuel),
+		FuelEfficiency:       s.FuelEfficiency,
+		FuelEfficiencySource: s.FuelEfficiencySource,
+		FuelBasis:            s.FuelBasis,
+		MeasuredFuel:         s.MeasuredFuel,
+		EstimatedFuel:        s.EstimatedFuel,
+		RefuelCount:          s.RefuelCount,
+		Bucket:               convertBucketToProto(s.Bucket),
+		FerryDistance:        s.FerryDistance,
+		DistanceWithFerry:    s.DistanceWithFerry(),
+		UncoveredDistance:    s.UncoveredDistance,
+	}
+}
+
+// convertSummaryTotalsToProto 期間全体の合計の内部型をproto型に変換
+func convertSummaryTotalsToProto(t SummaryTotals) *pb.SummaryTotals {
+	return &pb.SummaryTotals{
+		TotalDistance:     t.TotalDistance,
+		TotalFuel:         t.TotalFuel,
+		MeasuredFuel:      t.MeasuredFuel,
+		EstimatedFuel:     t.EstimatedFuel,
+		TripCount:         t.TripCount,
+		RefuelCount:       t.RefuelCount,
+		AvgFuelEfficiency: t.AvgFuelEfficiency(),
+		FerryDistance:     t.FerryDistance,
+		DistanceWithFerry: t.TotalDistance + t.FerryDistance,
+		MeasuredPeriods:   t.MeasuredPeriods,
+		EstimatedPeriods:  t.EstimatedPeriods,
+		MixedPeriods:      t.MixedPeriods,
+	}
+}
+
+// convertRankedVehiclesToProto ランキングの内部型をproto型に変換
+func convertRankedVehiclesToProto(vehicles []*RankedVehicle) []*pb.RankedVehicle {
+	results := make([]*pb.RankedVehicle, len(vehicles))
+	for i, v := range vehicles {
+		results[i] = &pb.RankedVehicle{
+			Rank:  v.Rank,
+			CarCc: v.CarCC,
+			Value: v.Value,
+		}
+	}
+	return results
+}
+
+// convertBucketToProto 集計期間の内部型をproto型に変換
+func convertBucketToProto(b Bucket) *pb.PeriodBucket {
+	return &pb.PeriodBucket{
+		Key:       b.Key,
+		Label:     b.Label,
+		StartDate: b.Start.Format("2006-01-02"),
+		EndDate:   b.End.Format("2006-01-02"),
+		Partial:   b.Partial,
+	}
+}
+
 // convertDbRowToProto db_serviceの運行データ型をdtako_rowsの型に変換
 func convertDbRowToProto(dbRow *dbpb.Db_DTakoRows) *pb.Row {
 	return &pb.Row{
-		Id:                    dbRow.Id,
-		OperationNo:           dbRow.OperationNo,
-		ReadDate:              dbRow.ReadDate,
-		OperationDate:         dbRow.OperationDate,
-		CarCode:               dbRow.CarCode,
-		CarCc:                 dbRow.CarCc,
-		StartWorkDatetime:     dbRow.StartWorkDatetime,
-		EndWorkDatetime:       dbRow.EndWorkDatetime,
-		DepartureDatetime:     dbRow.DepartureDatetime,
-		ReturnDatetime:        dbRow.ReturnDatetime,
-		DepartureMeter:        dbRow.DepartureMeter,
-		ReturnMeter:           dbRow.ReturnMeter,
-		TotalDistance:         dbRow.TotalDistance,
-		DriverCode1:           dbRow.DriverCode1,
-		LoadedDistance:        dbRow.LoadedDistance,
-		DestinationCityName:   dbRow.DestinationCityName,
-		DestinationPlaceName:  dbRow.DestinationPlaceName,
+		Id:                   dbRow.Id,
+		OperationNo:          dbRow.OperationNo,
+		ReadDate:             dbRow.ReadDate,
+		OperationDate:        dbRow.OperationDate,
+		CarCode:              dbRow.CarCode,
+		CarCc:                dbRow.CarCc,
+		StartWorkDatetime:    dbRow.StartWorkDatetime,
+		EndWorkDatetime:      dbRow.EndWorkDatetime,
+		DepartureDatetime:    dbRow.DepartureDatetime,
+		ReturnDatetime:       dbRow.ReturnDatetime,
+		DepartureMeter:       dbRow.DepartureMeter,
+		ReturnMeter:          dbRow.ReturnMeter,
+		TotalDistance:        dbRow.TotalDistance,
+		DriverCode1:          dbRow.DriverCode1,
+		LoadedDistance:       dbRow.LoadedDistance,
+		DestinationCityName:  dbRow.DestinationCityName,
+		DestinationPlaceName: dbRow.DestinationPlaceName,
 	}
 }
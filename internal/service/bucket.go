@@ -0,0 +1,236 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 集計期間の単位
+const (
+	BucketDay          = "day"           // 日
+	BucketISOWeek      = "iso_week"      // ISO週（月曜〜日曜）
+	BucketMonth        = "month"         // 暦月
+	BucketClosingMonth = "closing_month" // 締め日で区切った月
+	BucketQuarter      = "quarter"       // 四半期（年度の開始月から3か月ごと）
+	BucketFiscalYear   = "fiscal_year"   // 年度
+)
+
+// defaultFiscalYearStartMonth 年度の開始月のデフォルト（4月始まり）
+const defaultFiscalYearStartMonth = 4
+
+// Bucketing 集計期間の区切り方
+type Bucketing struct {
+	Kind                 string // Bucket*
+	ClosingDay           int    // 締め日（1〜31、0は月末。月の日数を超える場合は月末）
+	FiscalYearStartMonth int    // 年度の開始月（1〜12）
+}
+
+// Bucket 1つの集計期間
+//
+// Start・End はリクエストの期間で切り詰めた、実際に集計した範囲です（両端を含む）。
+type Bucket struct {
+	Key     string    // 並べ替え用のキー（例: 2025-10, 2025-W41, FY2025-Q1）
+	Label   string    // 表示名（範囲を含む、例: 2025-10 20日締 (2025-09-21〜2025-10-20)）
+	Start   time.Time // 開始日
+	End     time.Time // 終了日
+	Partial bool      // リクエストの期間で切り詰められた（期間の一部のみ集計）
+}
+
+// NewBucketing 集計期間の区切り方を作成
+//
+// kind が空の場合は defaultKind を使用します。締め日・年度の開始月が0の場合は
+// 環境変数 CLOSING_DAY・FISCAL_YEAR_START_MONTH（未設定の場合は月末・4月）を使用します。
+func NewBucketing(kind string, closingDay, fiscalYearStartMonth int, defaultKind string) (Bucketing, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = defaultKind
+	}
+	switch kind {
+	case BucketDay, BucketISOWeek, BucketMonth, BucketClosingMonth, BucketQuarter, BucketFiscalYear:
+	default:
+		return Bucketing{}, status.Errorf(codes.InvalidArgument, "unsupported bucket kind %q", kind)
+	}
+
+	if closingDay == 0 {
+		closingDay = envInt("CLOSING_DAY", 0, 0, 31)
+	}
+	if closingDay < 0 || closingDay > 31 {
+		return Bucketing{}, status.Errorf(codes.InvalidArgument, "closing_day must be between 1 and 31: %d", closingDay)
+	}
+
+	if fiscalYearStartMonth == 0 {
+		fiscalYearStartMonth = envInt("FISCAL_YEAR_START_MONTH", defaultFiscalYearStartMonth, 1, 12)
+	}
+	if fiscalYearStartMonth < 1 || fiscalYearStartMonth > 12 {
+		return Bucketing{}, status.Errorf(codes.InvalidArgument, "fiscal_year_start_month must be between 1 and 12: %d", fiscalYearStartMonth)
+	}
+
+	return Bucketing{
+		Kind:                 kind,
+		ClosingDay:           closingDay,
+		FiscalYearStartMonth: fiscalYearStartMonth,
+	}, nil
+}
+
+// MonthlyBucketing 暦月の区切り
+func MonthlyBucketing() Bucketing {
+	return Bucketing{Kind: BucketMonth, FiscalYearStartMonth: defaultFiscalYearStartMonth}
+}
+
+// DailyBucketing 日ごとの区切り
+func DailyBucketing() Bucketing {
+	return Bucketing{Kind: BucketDay, FiscalYearStartMonth: defaultFiscalYearStartMonth}
+}
+
+// envInt 環境変数から min〜max の整数を取得（未設定・不正な場合は def）
+func envInt(name string, def, min, max int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		log.Printf("Warning: invalid %s=%q, using %d", name, value, def)
+		return def
+	}
+	return n
+}
+
+// Of 日付を含む集計期間（切り詰める前の全範囲）
+func (b Bucketing) Of(t time.Time) Bucket {
+	day := dateOnly(t)
+
+	var key string
+	var start, end time.Time
+	switch b.Kind {
+	case BucketDay:
+		key = day.Format("2006-01-02")
+		start, end = day, day
+	case BucketISOWeek:
+		year, week := day.ISOWeek()
+		key = fmt.Sprintf("%04d-W%02d", year, week)
+		start = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		end = start.AddDate(0, 0, 6)
+	case BucketClosingMonth:
+		month := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if day.After(b.closingDate(month)) {
+			month = month.AddDate(0, 1, 0)
+		}
+		key = month.Format("2006-01")
+		start = b.closingDate(month.AddDate(0, -1, 0)).AddDate(0, 0, 1)
+		end = b.closingDate(month)
+	case BucketQuarter:
+		fy, offset := b.fiscalYear(day)
+		quarter := offset/3 + 1
+		key = fmt.Sprintf("%s-Q%d", b.fiscalYearKey(fy), quarter)
+		start = time.Date(fy, time.Month(b.FiscalYearStartMonth+(quarter-1)*3), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 3, -1)
+	case BucketFiscalYear:
+		fy, _ := b.fiscalYear(day)
+		key = b.fiscalYearKey(fy)
+		start = time.Date(fy, time.Month(b.FiscalYearStartMonth), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, -1)
+	default: // BucketMonth
+		start = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, -1)
+		key = start.Format("2006-01")
+	}
+
+	bucket := Bucket{Key: key, Start: start, End: end}
+	bucket.Label = b.label(bucket)
+	return bucket
+}
+
+// Within リクエストの期間で切り詰めた集計期間
+//
+// 期間の一部のみを集計した場合は Partial になり、ラベルの範囲も切り詰めた範囲になります。
+func (b Bucketing) Within(t, rangeStart, rangeEnd time.Time) Bucket {
+	bucket := b.Of(t)
+	rangeStart, rangeEnd = dateOnly(rangeStart), dateOnly(rangeEnd)
+	if bucket.Start.Before(rangeStart) {
+		bucket.Start = rangeStart
+		bucket.Partial = true
+	}
+	if bucket.End.After(rangeEnd) {
+		bucket.End = rangeEnd
+		bucket.Partial = true
+	}
+	if bucket.Partial {
+		bucket.Label = b.label(bucket)
+	}
+	return bucket
+}
+
+// label 表示名（キー + 範囲）
+func (b Bucketing) label(bucket Bucket) string {
+	name := bucket.Key
+	if b.Kind == BucketClosingMonth {
+		if b.ClosingDay == 0 || b.ClosingDay >= 31 {
+			name += " 末日締"
+		} else {
+			name += fmt.Sprintf(" %d日締", b.ClosingDay)
+		}
+	}
+	return fmt.Sprintf("%s (%s〜%s)", name, bucket.Start.Format("2006-01-02"), bucket.End.Format("2006-01-02"))
+}
+
+// closingDate 指定月（1日）の締め日
+func (b Bucketing) closingDate(month time.Time) time.Time {
+	last := month.AddDate(0, 1, -1)
+	if b.ClosingDay == 0 || b.ClosingDay >= last.Day() {
+		return last
+	}
+	return time.Date(month.Year(), month.Month(), b.ClosingDay, 0, 0, 0, 0, time.UTC)
+}
+
+// fiscalYear 日付の年度（開始月の年）と、年度の開始月からの月数
+func (b Bucketing) fiscalYear(day time.Time) (int, int) {
+	startMonth := b.FiscalYearStartMonth
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = defaultFiscalYearStartMonth
+	}
+	offset := (int(day.Month()) - startMonth + 12) % 12
+	fy := day.Year()
+	if int(day.Month()) < startMonth {
+		fy--
+	}
+	return fy, offset
+}
+
+// fiscalYearKey 年度のキー（1月始まりの場合は暦年）
+func (b Bucketing) fiscalYearKey(fy int) string {
+	if b.FiscalYearStartMonth == 1 {
+		return strconv.Itoa(fy)
+	}
+	return fmt.Sprintf("FY%d", fy)
+}
+
+// dateOnly 業務タイムゾーンでの日付部分のみ（暦日として扱うため、UTCの0時で保持）
+func dateOnly(t time.Time) time.Time {
+	t = t.In(BusinessLocation())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// BucketRange リクエストの期間と集計期間の区切り方
+type BucketRange struct {
+	Bucketing
+	Start time.Time // リクエストの開始日
+	End   time.Time // リクエストの終了日
+}
+
+// Range リクエストの期間を指定
+func (b Bucketing) Range(start, end time.Time) BucketRange {
+	return BucketRange{Bucketing: b, Start: start, End: end}
+}
+
+// Bucket 日付を含む集計期間（リクエストの期間で切り詰める）
+func (r BucketRange) Bucket(t time.Time) Bucket {
+	return r.Within(t, r.Start, r.End)
+}
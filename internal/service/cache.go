@@ -0,0 +1,512 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// 集計キャッシュの既定値
+const (
+	defaultCacheClosedTTL     = 24 * time.Hour   // 締め済みの月のみを含む期間
+	defaultCacheCurrentTTL    = 5 * time.Minute  // 当月を含む期間
+	defaultCacheCheckInterval = 30 * time.Second // 読取日の更新確認の間隔
+	defaultCacheMaxEntries    = 1000
+)
+
+// changeScanPageSize 読取日の更新確認で1回に取得する行数
+const changeScanPageSize = 100
+
+// externalSourceRPCs 運行データ以外の取得元を集計に含むRPC
+//
+// 取得元の更新は運行データの読取日では検知できないため、期間によらず当月を含む期間のTTLで保持します。
+var externalSourceRPCs = map[string]bool{
+	"GetTollSummary":       true, // ETC明細・対応付け
+	"GetFerrySummary":      true, // フェリー運行データ
+	"GetTripProfitability": true, // 売上・経費、ETC明細、フェリー運行データ
+	"GetEventSummary":      true, // イベントデータ
+}
+
+// RowChangeSource 読取日の更新を検知するための取得元
+type RowChangeSource interface {
+	// RowsReadOnOrAfter 読取日が readDate 以降の行と、最新の読取日を返す
+	// （readDate が空の場合は最新の読取日の行）
+	RowsReadOnOrAfter(ctx context.Context, readDate string) ([]*dbpb.Db_DTakoRows, string, error)
+}
+
+// CacheKey 集計キャッシュのキー
+//
+// 同じRPC・同じリクエストであれば同じキーになります。
+// CarCC と期間は読取日の更新による無効化の判定に使用します（CarCC が空の場合は全車両）。
+type CacheKey struct {
+	RPC     string
+	CarCC   string
+	Start   time.Time
+	End     time.Time
+	Request string // リクエストのハッシュ
+}
+
+// String ログ出力用の表現
+func (k CacheKey) String() string {
+	carCC := k.CarCC
+	if carCC == "" {
+		carCC = "*"
+	}
+	return k.RPC + " " + carCC + " " + k.Start.Format("2006-01-02") + "~" + k.End.Format("2006-01-02")
+}
+
+// covers 読取日が更新された行（車輌CC・運行日）がこのキーの集計に影響するか
+func (k CacheKey) covers(carCC string, opDate time.Time, ok bool) bool {
+	if k.CarCC != "" && k.CarCC != carCC {
+		return false
+	}
+	if !ok {
+		// 運行日が不正な行は期間を判定できないため、影響ありとみなす
+		return true
+	}
+	return !opDate.Before(k.Start) && !opDate.After(k.End)
+}
+
+// cacheEntry キャッシュされた集計結果
+type cacheEntry struct {
+	key       CacheKey
+	value     proto.Message
+	expiresAt time.Time
+}
+
+// CacheCounter RPCごとのヒット・ミス数
+type CacheCounter struct {
+	Hits   int64
+	Misses int64
+}
+
+// CacheStats 集計キャッシュの統計
+type CacheStats struct {
+	Enabled       bool
+	Hits          int64
+	Misses        int64
+	Entries       int
+	Invalidations int64 // 読取日の更新で無効化された件数
+	Expirations   int64 // TTL切れで破棄された件数
+	Evictions     int64 // 上限超過で破棄された件数
+	Watermark     string
+	LastChecked   time.Time
+	ByRPC         map[string]CacheCounter
+	EntriesByRPC  map[string]int
+}
+
+// HitRatio ヒット率（0〜1、問い合わせがない場合は0）
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// AggregateCache 集計RPCのレスポンスキャッシュ（プロセス内）
+//
+// 締め済みの月のみを含む期間は長いTTL、当月を含む期間は短いTTLで保持します。
+// 一定間隔で db_service の最新の読取日を確認し、新しく読み取られた行があれば
+// その車輌CC・運行日を含むエントリを無効化します。
+// 実給油データ（FUEL_CARD_CSV）や燃費設定の変更は検知しないため、TTLで反映されます。
+// ETC明細など運行データ以外の取得元を含むRPCは、期間によらず短いTTLで保持します。
+type AggregateCache struct {
+	source        RowChangeSource
+	closedTTL     time.Duration
+	currentTTL    time.Duration
+	checkInterval time.Duration
+	maxEntries    int
+	now           func() time.Time
+
+	mu            sync.Mutex
+	entries       map[string]*cacheEntry
+	counters      map[string]*CacheCounter
+	invalidations int64
+	expirations   int64
+	evictions     int64
+	generation    uint64 // 無効化のたびに増加
+
+	checkMu     sync.Mutex      // 読取日の確認を同時に1つに制限
+	watermark   string          // 確認済みの最新の読取日
+	seenIDs     map[string]bool // 読取日が watermark の確認済みの行のid
+	lastChecked time.Time
+}
+
+// NewAggregateCache 集計キャッシュの作成
+//
+// 0以下の値を渡した項目は既定値を使用します。
+func NewAggregateCache(source RowChangeSource, closedTTL, currentTTL, checkInterval time.Duration, maxEntries int) *AggregateCache {
+	if closedTTL <= 0 {
+		closedTTL = defaultCacheClosedTTL
+	}
+	if currentTTL <= 0 {
+		currentTTL = defaultCacheCurrentTTL
+	}
+	if checkInterval <= 0 {
+		checkInterval = defaultCacheCheckInterval
+	}
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheMaxEntries
+	}
+	return &AggregateCache{
+		source:        source,
+		closedTTL:     closedTTL,
+		currentTTL:    currentTTL,
+		checkInterval: checkInterval,
+		maxEntries:    maxEntries,
+		now:           time.Now,
+		entries:       make(map[string]*cacheEntry),
+		counters:      make(map[string]*CacheCounter),
+	}
+}
+
+// NewAggregateCacheFromEnv 環境変数から集計キャッシュを作成
+//
+// AGGREGATE_CACHE=off の場合は nil（キャッシュなし）を返します。
+func NewAggregateCacheFromEnv(source RowChangeSource) *AggregateCache {
+	if v := strings.ToLower(os.Getenv("AGGREGATE_CACHE")); v == "off" || v == "false" || v == "0" {
+		log.Println("Aggregate cache disabled (AGGREGATE_CACHE=off)")
+		return nil
+	}
+
+	cache := NewAggregateCache(source,
+		durationFromEnv("AGGREGATE_CACHE_CLOSED_TTL", defaultCacheClosedTTL),
+		durationFromEnv("AGGREGATE_CACHE_CURRENT_TTL", defaultCacheCurrentTTL),
+		durationFromEnv("AGGREGATE_CACHE_CHECK_INTERVAL", defaultCacheCheckInterval),
+		intFromEnv("AGGREGATE_CACHE_MAX_ENTRIES", defaultCacheMaxEntries),
+	)
+	log.Printf("Aggregate cache enabled: closed_ttl=%s, current_ttl=%s, check_interval=%s, max_entries=%d",
+		cache.closedTTL, cache.currentTTL, cache.checkInterval, cache.maxEntries)
+	return cache
+}
+
+// durationFromEnv 環境変数から期間を取得（"10m" 形式、不正な場合は既定値）
+func durationFromEnv(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Warning: invalid %s=%q, using %s", name, value, def)
+		return def
+	}
+	return d
+}
+
+// intFromEnv 環境変数から正の整数を取得（不正な場合は既定値）
+func intFromEnv(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		log.Printf("Warning: invalid %s=%q, using %d", name, value, def)
+		return def
+	}
+	return n
+}
+
+// NewCacheKey RPC名・車輌CC・期間 (YYYY-MM-DD) とリクエストからキーを作成
+//
+// 期間がパースできない場合は false を返します（キャッシュせずに処理し、
+// 通常どおり InvalidArgument を返すため）。
+func NewCacheKey(rpc, carCC, startDate, endDate string, req proto.Message) (CacheKey, bool) {
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return CacheKey{}, false
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return CacheKey{}, false
+	}
+	sum := sha256.Sum256(data)
+	return CacheKey{
+		RPC:     rpc,
+		CarCC:   carCC,
+		Start:   start,
+		End:     end,
+		Request: hex.EncodeToString(sum[:12]),
+	}, true
+}
+
+// id マップのキー
+func (k CacheKey) id() string {
+	return k.RPC + "\x00" + k.Request
+}
+
+// ttl 期間に応じたTTL（当月以降を含む場合、運行データ以外の取得元を含むRPCの場合は短いTTL）
+func (c *AggregateCache) ttl(key CacheKey) time.Duration {
+	if externalSourceRPCs[key.RPC] {
+		return c.currentTTL
+	}
+	now := c.now()
+	now = now.In(BusinessLocation())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if key.End.Before(monthStart) {
+		return c.closedTTL
+	}
+	return c.currentTTL
+}
+
+// Get キャッシュから取得（ヒットした場合は複製を返す）
+func (c *AggregateCache) Get(ctx context.Context, key CacheKey) (proto.Message, bool) {
+	c.refresh(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counter := c.counter(key.RPC)
+	entry, ok := c.entries[key.id()]
+	if ok && !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key.id())
+		c.expirations++
+		ok = false
+	}
+	if !ok {
+		counter.Misses++
+		return nil, false
+	}
+	counter.Hits++
+	return proto.Clone(entry.value), true
+}
+
+// Generation 無効化の世代（集計を始める前に取得して Put に渡す）
+func (c *AggregateCache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Put 集計結果を保存
+//
+// 集計中に無効化が行われた場合（generation が変わった場合）は、
+// 古いデータで集計した可能性があるため保存しません。
+// エントリ数が上限に達している場合は、最も早く期限切れになるエントリを破棄します。
+func (c *AggregateCache) Put(key CacheKey, value proto.Message, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	id := key.id()
+	if _, exists := c.entries[id]; !exists && len(c.entries) >= c.maxEntries {
+		c.evictOldest()
+	}
+	c.entries[id] = &cacheEntry{
+		key:       key,
+		value:     proto.Clone(value),
+		expiresAt: c.now().Add(c.ttl(key)),
+	}
+}
+
+// evictOldest 最も早く期限切れになるエントリを破棄（c.mu を保持して呼び出す）
+func (c *AggregateCache) evictOldest() {
+	var oldestID string
+	var oldest time.Time
+	for id, entry := range c.entries {
+		if oldestID == "" || entry.expiresAt.Before(oldest) {
+			oldestID, oldest = id, entry.expiresAt
+		}
+	}
+	if oldestID != "" {
+		delete(c.entries, oldestID)
+		c.evictions++
+	}
+}
+
+// counter RPCごとのカウンター（c.mu を保持して呼び出す）
+func (c *AggregateCache) counter(rpc string) *CacheCounter {
+	counter, ok := c.counters[rpc]
+	if !ok {
+		counter = &CacheCounter{}
+		c.counters[rpc] = counter
+	}
+	return counter
+}
+
+// refresh 前回の確認から checkInterval 以上経過していれば読取日の更新を確認
+//
+// 新しく読み取られた行があれば、その車輌CC・運行日を含むエントリを無効化します。
+// 確認に失敗した場合はログを残してキャッシュをそのまま使用します（TTLで更新されます）。
+func (c *AggregateCache) refresh(ctx context.Context) {
+	if c.source == nil {
+		return
+	}
+
+	c.checkMu.Lock()
+	defer c.checkMu.Unlock()
+
+	now := c.now()
+	if !c.lastChecked.IsZero() && now.Sub(c.lastChecked) < c.checkInterval {
+		return
+	}
+
+	rows, latest, err := c.source.RowsReadOnOrAfter(ctx, c.watermark)
+	if err != nil {
+		log.Printf("Aggregate cache: failed to check read_date: %v", err)
+		return
+	}
+	c.lastChecked = now
+
+	// 読取日は日付のみのため、watermark と同じ読取日の行は確認済みのidを除いて新しい行とみなす
+	var changed []*dbpb.Db_DTakoRows
+	for _, row := range rows {
+		if compareReadDates(row.ReadDate, c.watermark) == 0 && c.seenIDs[row.Id] {
+			continue
+		}
+		changed = append(changed, row)
+	}
+
+	initial := c.watermark == ""
+	if latest != "" && latest != c.watermark {
+		c.watermark = latest
+		c.seenIDs = make(map[string]bool)
+	}
+	for _, row := range rows {
+		if compareReadDates(row.ReadDate, c.watermark) == 0 {
+			c.seenIDs[row.Id] = true
+		}
+	}
+
+	if !initial && len(changed) > 0 {
+		c.invalidateRows(changed)
+	}
+}
+
+// invalidateRows 新しく読み取られた行の影響を受けるエントリを無効化
+func (c *AggregateCache) invalidateRows(rows []*dbpb.Db_DTakoRows) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for id, entry := range c.entries {
+		for _, row := range rows {
+			opDate, err := parseOperationDateValue(row.OperationDate)
+			if entry.key.covers(row.CarCc, opDate, err == nil) {
+				log.Printf("Aggregate cache: invalidating %s (row %s read at %s)", entry.key, row.Id, row.ReadDate)
+				delete(c.entries, id)
+				removed++
+				break
+			}
+		}
+	}
+	c.invalidations += int64(removed)
+	c.generation++
+	log.Printf("Aggregate cache: %d new rows since %s, invalidated %d entries", len(rows), c.watermark, removed)
+}
+
+// Stats 統計を取得
+func (c *AggregateCache) Stats() CacheStats {
+	c.checkMu.Lock()
+	watermark, lastChecked := c.watermark, c.lastChecked
+	c.checkMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{
+		Enabled:       true,
+		Entries:       len(c.entries),
+		Invalidations: c.invalidations,
+		Expirations:   c.expirations,
+		Evictions:     c.evictions,
+		Watermark:     watermark,
+		LastChecked:   lastChecked,
+		ByRPC:         make(map[string]CacheCounter, len(c.counters)),
+		EntriesByRPC:  make(map[string]int),
+	}
+	for rpc, counter := range c.counters {
+		stats.Hits += counter.Hits
+		stats.Misses += counter.Misses
+		stats.ByRPC[rpc] = *counter
+	}
+	for _, entry := range c.entries {
+		stats.EntriesByRPC[entry.key.RPC]++
+	}
+	return stats
+}
+
+// RPCNames 統計のあるRPC名（名前順）
+func (s CacheStats) RPCNames() []string {
+	names := make([]string, 0, len(s.ByRPC))
+	for rpc := range s.ByRPC {
+		names = append(names, rpc)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cachedResponse キャッシュがあれば返し、なければ load の結果を保存して返す
+//
+// cache が nil、または期間がパースできない場合は常に load を呼び出します。
+func cachedResponse[T proto.Message](ctx context.Context, cache *AggregateCache, rpc, carCC, startDate, endDate string, req proto.Message, load func() (T, error)) (T, error) {
+	if cache == nil {
+		return load()
+	}
+	key, ok := NewCacheKey(rpc, carCC, startDate, endDate, req)
+	if !ok {
+		return load()
+	}
+
+	if value, hit := cache.Get(ctx, key); hit {
+		if resp, ok := value.(T); ok {
+			log.Printf("Aggregate cache hit: %s", key)
+			return resp, nil
+		}
+	}
+
+	generation := cache.Generation()
+	resp, err := load()
+	if err != nil {
+		return resp, err
+	}
+	cache.Put(key, resp, generation)
+	return resp, nil
+}
+
+// RowsReadOnOrAfter 読取日が readDate 以降の行と、最新の読取日を取得
+//
+// 読取日は日付のみのため、同じ読取日の行が後から追加される場合に備えて readDate と同じ行も含めます
+// （確認済みの行は呼び出し側でidにより除きます）。読取日降順に取得し、readDate より前の行が
+// 現れた時点で打ち切ります。readDate が空の場合は最新の読取日の行を返します。
+func (s *DtakoRowsService) RowsReadOnOrAfter(ctx context.Context, readDate string) ([]*dbpb.Db_DTakoRows, string, error) {
+	orderBy := PlanQueryByReadDate(nil).OrderBy
+
+	var rows []*dbpb.Db_DTakoRows
+	latest := ""
+	fetcher := NewPageFetcher(s.dbClient, changeScanPageSize, 1)
+	_, err := fetcher.Fetch(ctx, orderBy, func(items []*dbpb.Db_DTakoRows) error {
+		for _, item := range items {
+			if latest == "" {
+				latest = item.ReadDate
+				if readDate == "" {
+					readDate = latest
+				}
+			}
+			if compareReadDates(item.ReadDate, readDate) < 0 {
+				return errStopScan
+			}
+			rows = append(rows, item)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return nil, "", err
+	}
+	return rows, latest, nil
+}
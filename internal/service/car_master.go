@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// 車両マスタキャッシュの有効期間
+const (
+	carMasterTTL           = 1 * time.Hour
+	carMasterRetryInterval = 1 * time.Minute // 取得失敗後、再取得を試みるまでの間隔
+)
+
+// CarMaster 車両マスタ（DTakoCars）のキャッシュ
+//
+// 燃費の決定と事業所別集計で共有します。client が nil の場合は常に見つからない扱いです。
+type CarMaster struct {
+	client dbpb.Db_DTakoCarsServiceClient
+
+	mu       sync.Mutex
+	byCC     map[string]*dbpb.Db_DTakoCars // 車輌CC → 車両マスタ
+	byCode   map[int32]*dbpb.Db_DTakoCars  // 車輌C（数値に正規化）→ 車両マスタ
+	loadedAt time.Time
+	failedAt time.Time // 最後に取得に失敗した日時
+}
+
+// NewCarMaster 車両マスタのキャッシュを作成
+func NewCarMaster(client dbpb.Db_DTakoCarsServiceClient) *CarMaster {
+	return &CarMaster{client: client}
+}
+
+// Available 車両マスタを参照できるか
+func (m *CarMaster) Available() bool {
+	return m != nil && m.client != nil
+}
+
+// Lookup 運行データの車輌CC・車輌CDに対応する車両を取得（キャッシュ付き）
+//
+// 車両マスタの car_cc が carCC と一致する車両がない場合は、car_code が carCode と一致する車両を返します
+// （carCode が0の場合は car_cc のみで対応付けます）。車両マスタの car_code は文字列のため、
+// 数値として比較します（"0123" は車輌CD 123 と一致します）。
+func (m *CarMaster) Lookup(ctx context.Context, carCC string, carCode int32) *dbpb.Db_DTakoCars {
+	if !m.Available() {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 取得失敗後は carMasterRetryInterval の間、再取得せずに前回のキャッシュを使い続ける
+	if (m.byCC == nil || time.Since(m.loadedAt) > carMasterTTL) && time.Since(m.failedAt) > carMasterRetryInterval {
+		byCC, byCode, err := m.load(ctx)
+		if err != nil {
+			log.Printf("Warning: failed to load car master: %v", err)
+			m.failedAt = time.Now()
+		} else {
+			m.byCC = byCC
+			m.byCode = byCode
+			m.loadedAt = time.Now()
+		}
+	}
+	if m.byCC == nil {
+		return nil
+	}
+
+	if car, ok := m.byCC[carCC]; ok && carCC != "" {
+		return car
+	}
+	if carCode == 0 {
+		return nil
+	}
+	return m.byCode[carCode]
+}
+
+// load 車両マスタを全件取得して車輌CC・車輌Cでインデックス化
+func (m *CarMaster) load(ctx context.Context) (map[string]*dbpb.Db_DTakoCars, map[int32]*dbpb.Db_DTakoCars, error) {
+	req := &dbpb.Db_ListDTakoCarsRequest{
+		Limit:  1000,
+		Offset: 0,
+	}
+
+	byCC := make(map[string]*dbpb.Db_DTakoCars)
+	byCode := make(map[int32]*dbpb.Db_DTakoCars)
+	for {
+		resp, err := m.client.List(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, car := range resp.Items {
+			if car.CarCc != "" {
+				byCC[car.CarCc] = car
+			}
+			if code, ok := parseCarCode(car.CarCode); ok {
+				byCode[code] = car
+			}
+		}
+
+		if len(resp.Items) < int(req.Limit) {
+			break
+		}
+		req.Offset += req.Limit
+	}
+
+	log.Printf("Loaded %d cars from car master", len(byCC))
+	return byCC, byCode, nil
+}
+
+// parseCarCode 車両マスタの car_code（ゼロ埋め・前後の空白を含む文字列）を車輌CDに変換
+//
+// 空・数値でない・0の場合は false を返します。
+func parseCarCode(value string) (int32, bool) {
+	code, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || code == 0 {
+		return 0, false
+	}
+	return int32(code), true
+}
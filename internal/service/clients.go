@@ -0,0 +1,35 @@
+package service
+
+import (
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc"
+)
+
+// DBClients db_serviceの各サービスクライアント
+//
+// Rows は必須です。その他のクライアントはオプショナルで、
+// nil の場合は該当する機能が縮退動作（デフォルト値を使用）します。
+type DBClients struct {
+	Rows        dbpb.Db_DTakoRowsServiceClient          // 運行データ（必須）
+	Cars        dbpb.Db_DTakoCarsServiceClient          // 車両マスタ
+	ETCMeisai   dbpb.Db_ETCMeisaiServiceClient          // ETC明細
+	ETCMapping  dbpb.Db_ETCMeisaiMappingServiceClient   // ETC明細と運行データの対応付け
+	ETCNum      dbpb.Db_ETCNumServiceClient             // ETCカード番号マスタ
+	Ferries     dbpb.Db_DTakoFerryRowsProdServiceClient // フェリー運行データ
+	UriageKeihi dbpb.Db_DTakoUriageKeihiServiceClient   // 売上・経費
+	Events      dbpb.Db_DTakoEventsServiceClient        // イベントデータ
+}
+
+// NewDBClientsFromConn 単一のgRPC接続から全クライアントを作成
+func NewDBClientsFromConn(conn grpc.ClientConnInterface) *DBClients {
+	return &DBClients{
+		Rows:        dbpb.NewDb_DTakoRowsServiceClient(conn),
+		Cars:        dbpb.NewDb_DTakoCarsServiceClient(conn),
+		ETCMeisai:   dbpb.NewDb_ETCMeisaiServiceClient(conn),
+		ETCMapping:  dbpb.NewDb_ETCMeisaiMappingServiceClient(conn),
+		ETCNum:      dbpb.NewDb_ETCNumServiceClient(conn),
+		Ferries:     dbpb.NewDb_DTakoFerryRowsProdServiceClient(conn),
+		UriageKeihi: dbpb.NewDb_DTakoUriageKeihiServiceClient(conn),
+		Events:      dbpb.NewDb_DTakoEventsServiceClient(conn),
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 比較対象の期間に車両が含まれるか
+const (
+	PresenceBoth         = "both"          // 両方の期間に運行（または給油）がある
+	PresenceCurrentOnly  = "current_only"  // 今期のみ（新規の車両など）
+	PresenceBaselineOnly = "baseline_only" // 基準期間のみ（廃車・休車など）
+)
+
+// DateRange 期間 (YYYY-MM-DD)
+type DateRange struct {
+	StartDate string
+	EndDate   string
+}
+
+// PeriodTotals 1期間分の車両の合計
+type PeriodTotals struct {
+	TotalDistance float64
+	TotalFuel     float64
+	TripCount     int32
+	Present       bool // 期間内に運行（または給油）があるか
+}
+
+// add 月次サマリーを加算
+func (t *PeriodTotals) add(s *MonthlyFuelSummary) {
+	t.TotalDistance += s.TotalDistance
+	t.TotalFuel += s.TotalFuel
+	t.TripCount += s.TripCount
+	t.Present = true
+}
+
+// merge 他の合計を加算
+func (t *PeriodTotals) merge(o PeriodTotals) {
+	t.TotalDistance += o.TotalDistance
+	t.TotalFuel += o.TotalFuel
+	t.TripCount += o.TripCount
+	t.Present = t.Present || o.Present
+}
+
+// Delta 差分（今期 - 基準期間）と増減率（%）
+//
+// 基準期間の値が0の場合は増減率を計算できないため Percent は nil です。
+type Delta struct {
+	Absolute float64
+	Percent  *float64
+}
+
+// newDelta 今期と基準期間の値から差分を作成
+func newDelta(current, baseline float64) Delta {
+	d := Delta{Absolute: current - baseline}
+	if baseline != 0 {
+		pct := (current - baseline) / baseline * 100
+		d.Percent = &pct
+	}
+	return d
+}
+
+// VehicleComparison 車両ごとの期間比較
+type VehicleComparison struct {
+	CarCC    string // 全体合計の場合は空
+	Presence string // Presence*
+	Current  PeriodTotals
+	Baseline PeriodTotals
+	Distance Delta
+	Fuel     Delta
+	Trips    Delta
+}
+
+// newVehicleComparison 2期間の合計から比較結果を作成
+func newVehicleComparison(carCC string, current, baseline PeriodTotals) *VehicleComparison {
+	presence := PresenceBoth
+	switch {
+	case current.Present && !baseline.Present:
+		presence = PresenceCurrentOnly
+	case !current.Present && baseline.Present:
+		presence = PresenceBaselineOnly
+	}
+	return &VehicleComparison{
+		CarCC:    carCC,
+		Presence: presence,
+		Current:  current,
+		Baseline: baseline,
+		Distance: newDelta(current.TotalDistance, baseline.TotalDistance),
+		Fuel:     newDelta(current.TotalFuel, baseline.TotalFuel),
+		Trips:    newDelta(float64(current.TripCount), float64(baseline.TripCount)),
+	}
+}
+
+// SameRangeLastYear 1年前の同じ期間（前年同期）
+func SameRangeLastYear(r DateRange) (DateRange, error) {
+	start, end, err := parseDateRange(r.StartDate, r.EndDate)
+	if err != nil {
+		return DateRange{}, err
+	}
+	return DateRange{
+		StartDate: lastYear(start).Format("2006-01-02"),
+		EndDate:   lastYear(end).Format("2006-01-02"),
+	}, nil
+}
+
+// lastYear 1年前の同じ日（2/29 は 2/28）
+func lastYear(t time.Time) time.Time {
+	prev := time.Date(t.Year()-1, t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if prev.Month() != t.Month() {
+		// 閏日は前年の月末に丸める
+		prev = time.Date(t.Year()-1, t.Month()+1, 0, 0, 0, 0, 0, t.Location())
+	}
+	return prev
+}
+
+// CompareVehiclePeriods 車両ごとに2つの期間の走行距離・運行回数・給油量を比較
+//
+// 今期（current）と基準期間（baseline）をそれぞれ GetVehicleMonthlySummary で集計し、
+// 車輌CCごとに合計して差分・増減率を計算します。片方の期間にしか現れない車両も含めます。
+// carCC を指定した場合はその車両のみを GetMonthlyFuelConsumption で集計して返します。戻り値は車輌CC順の比較結果と全体合計です。
+func (s *DtakoRowsService) CompareVehiclePeriods(ctx context.Context, current, baseline DateRange, carCC string) ([]*VehicleComparison, *VehicleComparison, error) {
+	log.Printf("CompareVehiclePeriods: current=%s~%s, baseline=%s~%s, car_cc=%s",
+		current.StartDate, current.EndDate, baseline.StartDate, baseline.EndDate, carCC)
+
+	if current.StartDate == "" || current.EndDate == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "current period is required")
+	}
+
+	currentTotals, err := s.periodTotalsByVehicle(ctx, current, carCC)
+	if err != nil {
+		return nil, nil, err
+	}
+	baselineTotals, err := s.periodTotalsByVehicle(ctx, baseline, carCC)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	carCCs := make([]string, 0, len(currentTotals)+len(baselineTotals))
+	for car := range currentTotals {
+		carCCs = append(carCCs, car)
+	}
+	for car := range baselineTotals {
+		if _, exists := currentTotals[car]; !exists {
+			carCCs = append(carCCs, car)
+		}
+	}
+	sort.Strings(carCCs)
+
+	var fleetCurrent, fleetBaseline PeriodTotals
+	results := make([]*VehicleComparison, len(carCCs))
+	for i, car := range carCCs {
+		results[i] = newVehicleComparison(car, currentTotals[car], baselineTotals[car])
+		fleetCurrent.merge(currentTotals[car])
+		fleetBaseline.merge(baselineTotals[car])
+	}
+
+	log.Printf("Compared %d vehicles", len(results))
+	return results, newVehicleComparison("", fleetCurrent, fleetBaseline), nil
+}
+
+// periodTotalsByVehicle 期間内の車両ごとの合計
+//
+// carCC を指定した場合は GetMonthlyFuelConsumption（その車両の行・ロールアップのみ）で集計し、
+// 全車両を集計しません。
+func (s *DtakoRowsService) periodTotalsByVehicle(ctx context.Context, period DateRange, carCC string) (map[string]PeriodTotals, error) {
+	var summariesMap map[string][]*MonthlyFuelSummary
+	if carCC != "" {
+		summaries, err := s.GetMonthlyFuelConsumption(ctx, carCC, period.StartDate, period.EndDate, MonthlyBucketing())
+		if err != nil {
+			return nil, err
+		}
+		summariesMap = make(map[string][]*MonthlyFuelSummary, 1)
+		if len(summaries) > 0 {
+			summariesMap[carCC] = summaries
+		}
+	} else {
+		var err error
+		summariesMap, err = s.GetVehicleMonthlySummary(ctx, period.StartDate, period.EndDate, MonthlyBucketing())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	totals := make(map[string]PeriodTotals, len(summariesMap))
+	for car, summaries := range summariesMap {
+		var t PeriodTotals
+		for _, summary := range summaries {
+			t.add(summary)
+		}
+		totals[car] = t
+	}
+	return totals, nil
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// 改善基準告示のチェック項目
+const (
+	ComplianceRuleDailyRestraint    = "daily_restraint"    // 1日の拘束時間
+	ComplianceRuleRestInterval      = "rest_interval"      // 勤務間の休息期間
+	ComplianceRuleContinuousDriving = "continuous_driving" // 連続運転時間
+	ComplianceRuleTwoDayDriving     = "two_day_driving"    // 2日平均の運転時間
+	ComplianceRuleMonthlyRestraint  = "monthly_restraint"  // 1か月の拘束時間
+)
+
+// 違反の重大度
+const (
+	ComplianceSeverityViolation = "violation" // 上限超過
+	ComplianceSeverityWarning   = "warning"   // 原則値超過（上限内）
+)
+
+// ComplianceThresholds 改善基準告示のしきい値
+//
+// デフォルト値はトラック運転者の改善基準告示（2024年4月適用）に基づきます。
+type ComplianceThresholds struct {
+	MaxDailyRestraint       time.Duration // 1日の拘束時間（原則）
+	ExtendedDailyRestraint  time.Duration // 1日の拘束時間（最大）
+	MinRestInterval         time.Duration // 休息期間（下限）
+	RecommendedRestInterval time.Duration // 休息期間（努力義務）
+	MaxContinuousDriving    time.Duration // 連続運転時間
+	MinBreak                time.Duration // 連続運転の中断に必要な休憩
+	MaxTwoDayAvgDriving     time.Duration // 2日平均の1日あたり運転時間
+	MaxMonthlyRestraint     time.Duration // 1か月の拘束時間
+}
+
+// DefaultComplianceThresholds デフォルトのしきい値
+func DefaultComplianceThresholds() ComplianceThresholds {
+	return ComplianceThresholds{
+		MaxDailyRestraint:       13 * time.Hour,
+		ExtendedDailyRestraint:  15 * time.Hour,
+		MinRestInterval:         9 * time.Hour,
+		RecommendedRestInterval: 11 * time.Hour,
+		MaxContinuousDriving:    4 * time.Hour,
+		MinBreak:                30 * time.Minute,
+		MaxTwoDayAvgDriving:     9 * time.Hour,
+		MaxMonthlyRestraint:     284 * time.Hour,
+	}
+}
+
+// withDefaults 未設定（0以下）の項目をデフォルト値で補完
+func (t ComplianceThresholds) withDefaults() ComplianceThresholds {
+	d := DefaultComplianceThresholds()
+	fill := func(v *time.Duration, def time.Duration) {
+		if *v <= 0 {
+			*v = def
+		}
+	}
+	fill(&t.MaxDailyRestraint, d.MaxDailyRestraint)
+	fill(&t.ExtendedDailyRestraint, d.ExtendedDailyRestraint)
+	fill(&t.MinRestInterval, d.MinRestInterval)
+	fill(&t.RecommendedRestInterval, d.RecommendedRestInterval)
+	fill(&t.MaxContinuousDriving, d.MaxContinuousDriving)
+	fill(&t.MinBreak, d.MinBreak)
+	fill(&t.MaxTwoDayAvgDriving, d.MaxTwoDayAvgDriving)
+	fill(&t.MaxMonthlyRestraint, d.MaxMonthlyRestraint)
+	return t
+}
+
+// ComplianceViolation 改善基準告示の違反・警告
+type ComplianceViolation struct {
+	DriverCode string        // 乗務員CD1
+	Rule       string        // チェック項目 (ComplianceRule*)
+	Severity   string        // 重大度 (ComplianceSeverity*)
+	Date       string        // 対象日 (YYYY-MM-DD) または対象月 (YYYY-MM)
+	Actual     time.Duration // 実績値
+	Limit      time.Duration // しきい値
+	RowIDs     []string      // 対象の運行データID
+	Message    string        // 説明
+}
+
+// DriverMonthlyCompliance 乗務員の月次実績
+type DriverMonthlyCompliance struct {
+	YearMonth  string        // 年月 (YYYY-MM形式)
+	Restraint  time.Duration // 拘束時間合計
+	Driving    time.Duration // 運転時間合計
+	ShiftCount int32         // 勤務回数
+}
+
+// DriverCompliance 乗務員別のチェック結果
+type DriverCompliance struct {
+	DriverCode        string
+	Monthly           []*DriverMonthlyCompliance // 年月順
+	MaxDailyRestraint time.Duration              // 最大拘束時間（1勤務）
+	MinRestInterval   time.Duration              // 最短休息期間（勤務が2回以上の場合）
+	ViolationCount    int32
+	WarningCount      int32
+}
+
+// complianceShift 1勤務（出社〜退社）
+//
+// 同一乗務員で出社〜退社が重なる運行データは1勤務にまとめる。
+type complianceShift struct {
+	start   time.Time
+	end     time.Time
+	driving time.Duration
+	rowIDs  []string
+}
+
+// CheckDriverCompliance 乗務員ごとに改善基準告示への適合をチェック
+//
+// 出社日時〜退社日時を拘束時間、一般道・高速道・バイパス運転時間（分）の合計を運転時間として扱います。
+// 連続運転時間は運行データ単位の値しかないため、「運転時間を4時間ごとに区切るのに必要な休憩が
+// 拘束時間内に取れない」場合のみ違反とします（確実な違反のみを検出）。
+// 乗務員CD1が未設定の運行はチェック対象外です。
+func (s *DtakoRowsService) CheckDriverCompliance(ctx context.Context, startDate, endDate string, driverCode *int32, thresholds ComplianceThresholds) ([]*DriverCompliance, []*ComplianceViolation, error) {
+	log.Printf("CheckDriverCompliance: start=%s, end=%s", startDate, endDate)
+
+	thresholds = thresholds.withDefaults()
+
+	allRows, err := s.ListByDateRange(ctx, startDate, endDate, 0)
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, nil, err
+	}
+
+	// 乗務員ごとに分類
+	driverRows := make(map[string][]*dbpb.Db_DTakoRows)
+	for _, row := range allRows {
+		if driverCode != nil && (row.DriverCode1 == nil || *row.DriverCode1 != *driverCode) {
+			continue
+		}
+		key := driverKey(row)
+		if key == UnassignedDriverCode {
+			continue
+		}
+		driverRows[key] = append(driverRows[key], row)
+	}
+
+	codes := make([]string, 0, len(driverRows))
+	for code := range driverRows {
+		codes = append(codes, code)
+	}
+	sortDriverCodes(codes)
+
+	drivers := make([]*DriverCompliance, 0, len(codes))
+	violations := make([]*ComplianceViolation, 0)
+	for _, code := range codes {
+		result, driverViolations := checkDriverShifts(code, buildShifts(driverRows[code]), thresholds)
+		drivers = append(drivers, result)
+		violations = append(violations, driverViolations...)
+	}
+
+	log.Printf("Checked compliance for %d drivers: %d issues", len(drivers), len(violations))
+	return drivers, violations, nil
+}
+
+// buildShifts 運行データを出社日時順に並べ、重なる運行を1勤務にまとめる
+func buildShifts(rows []*dbpb.Db_DTakoRows) []*complianceShift {
+	shifts := make([]*complianceShift, 0, len(rows))
+	for _, row := range rows {
+		start, err := time.Parse(time.RFC3339, row.StartWorkDatetime)
+		if err != nil {
+			log.Printf("Skipping row %s: invalid start_work_datetime %q", row.Id, row.StartWorkDatetime)
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, row.EndWorkDatetime)
+		if err != nil || !end.After(start) {
+			log.Printf("Skipping row %s: invalid end_work_datetime %q", row.Id, row.EndWorkDatetime)
+			continue
+		}
+
+		driving := time.Duration(row.GeneralRoadDriveTime+row.HighwayDriveTime+row.BypassDriveTime) * time.Minute
+		// 勤務日・月は業務タイムゾーンで判定する
+		shifts = append(shifts, &complianceShift{
+			start:   start.In(BusinessLocation()),
+			end:     end.In(BusinessLocation()),
+			driving: driving,
+			rowIDs:  []string{row.Id},
+		})
+	}
+
+	sort.Slice(shifts, func(i, j int) bool {
+		return shifts[i].start.Before(shifts[j].start)
+	})
+
+	merged := make([]*complianceShift, 0, len(shifts))
+	for _, shift := range shifts {
+		if len(merged) > 0 {
+			last := merged[len(merged)-1]
+			if !shift.start.After(last.end) {
+				if shift.end.After(last.end) {
+					last.end = shift.end
+				}
+				last.driving += shift.driving
+				last.rowIDs = append(last.rowIDs, shift.rowIDs...)
+				continue
+			}
+		}
+		merged = append(merged, shift)
+	}
+	return merged
+}
+
+// checkDriverShifts 1乗務員の勤務一覧をチェック
+func checkDriverShifts(driverCode string, shifts []*complianceShift, t ComplianceThresholds) (*DriverCompliance, []*ComplianceViolation) {
+	result := &DriverCompliance{DriverCode: driverCode}
+	violations := make([]*ComplianceViolation, 0)
+
+	add := func(v *ComplianceViolation) {
+		v.DriverCode = driverCode
+		if v.Severity == ComplianceSeverityViolation {
+			result.ViolationCount++
+		} else {
+			result.WarningCount++
+		}
+		violations = append(violations, v)
+	}
+
+	monthly := make(map[string]*DriverMonthlyCompliance)
+	dailyDriving := make(map[string]time.Duration)
+	dailyRowIDs := make(map[string][]string)
+
+	for i, shift := range shifts {
+		date := shift.start.Format("2006-01-02")
+		restraint := shift.end.Sub(shift.start)
+
+		// 1日の拘束時間
+		if restraint > result.MaxDailyRestraint {
+			result.MaxDailyRestraint = restraint
+		}
+		switch {
+		case restraint > t.ExtendedDailyRestraint:
+			add(&ComplianceViolation{
+				Rule: ComplianceRuleDailyRestraint, Severity: ComplianceSeverityViolation,
+				Date: date, Actual: restraint, Limit: t.ExtendedDailyRestraint, RowIDs: shift.rowIDs,
+				Message: fmt.Sprintf("拘束時間 %s が上限 %s を超過", formatDuration(restraint), formatDuration(t.ExtendedDailyRestraint)),
+			})
+		case restraint > t.MaxDailyRestraint:
+			add(&ComplianceViolation{
+				Rule: ComplianceRuleDailyRestraint, Severity: ComplianceSeverityWarning,
+				Date: date, Actual: restraint, Limit: t.MaxDailyRestraint, RowIDs: shift.rowIDs,
+				Message: fmt.Sprintf("拘束時間 %s が原則 %s を超過", formatDuration(restraint), formatDuration(t.MaxDailyRestraint)),
+			})
+		}
+
+		// 連続運転時間（運転時間を区切るのに必要な休憩が拘束時間内に収まるか）
+		if shift.driving > t.MaxContinuousDriving {
+			requiredBreaks := int64((shift.driving+t.MaxContinuousDriving-1)/t.MaxContinuousDriving) - 1
+			required := time.Duration(requiredBreaks) * t.MinBreak
+			if available := restraint - shift.driving; available < required {
+				add(&ComplianceViolation{
+					Rule: ComplianceRuleContinuousDriving, Severity: ComplianceSeverityViolation,
+					Date: date, Actual: shift.driving, Limit: t.MaxContinuousDriving, RowIDs: shift.rowIDs,
+					Message: fmt.Sprintf("運転時間 %s に必要な休憩 %s に対し、運転以外の時間が %s しかない",
+						formatDuration(shift.driving), formatDuration(required), formatDuration(available)),
+				})
+			}
+		}
+
+		// 勤務間の休息期間
+		if i+1 < len(shifts) {
+			next := shifts[i+1]
+			rest := next.start.Sub(shift.end)
+			if result.MinRestInterval == 0 || rest < result.MinRestInterval {
+				result.MinRestInterval = rest
+			}
+			rowIDs := append(append([]string{}, shift.rowIDs...), next.rowIDs...)
+			switch {
+			case rest < t.MinRestInterval:
+				add(&ComplianceViolation{
+					Rule: ComplianceRuleRestInterval, Severity: ComplianceSeverityViolation,
+					Date: date, Actual: rest, Limit: t.MinRestInterval, RowIDs: rowIDs,
+					Message: fmt.Sprintf("休息期間 %s が下限 %s を下回る", formatDuration(rest), formatDuration(t.MinRestInterval)),
+				})
+			case rest < t.RecommendedRestInterval:
+				add(&ComplianceViolation{
+					Rule: ComplianceRuleRestInterval, Severity: ComplianceSeverityWarning,
+					Date: date, Actual: rest, Limit: t.RecommendedRestInterval, RowIDs: rowIDs,
+					Message: fmt.Sprintf("休息期間 %s が %s 未満", formatDuration(rest), formatDuration(t.RecommendedRestInterval)),
+				})
+			}
+		}
+
+		dailyDriving[date] += shift.driving
+		dailyRowIDs[date] = append(dailyRowIDs[date], shift.rowIDs...)
+
+		yearMonth := shift.start.Format("2006-01")
+		if _, exists := monthly[yearMonth]; !exists {
+			monthly[yearMonth] = &DriverMonthlyCompliance{YearMonth: yearMonth}
+		}
+		monthly[yearMonth].Restraint += restraint
+		monthly[yearMonth].Driving += shift.driving
+		monthly[yearMonth].ShiftCount++
+	}
+
+	// 2日平均の運転時間（前日との平均・翌日との平均がともに超過した場合に違反）
+	dates := make([]string, 0, len(dailyDriving))
+	for date := range dailyDriving {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		day, _ := time.Parse("2006-01-02", date)
+		prev := dailyDriving[day.AddDate(0, 0, -1).Format("2006-01-02")]
+		next := dailyDriving[day.AddDate(0, 0, 1).Format("2006-01-02")]
+		withPrev := (prev + dailyDriving[date]) / 2
+		withNext := (dailyDriving[date] + next) / 2
+		if withPrev > t.MaxTwoDayAvgDriving && withNext > t.MaxTwoDayAvgDriving {
+			actual := withPrev
+			if withNext < actual {
+				actual = withNext
+			}
+			add(&ComplianceViolation{
+				Rule: ComplianceRuleTwoDayDriving, Severity: ComplianceSeverityViolation,
+				Date: date, Actual: actual, Limit: t.MaxTwoDayAvgDriving, RowIDs: dailyRowIDs[date],
+				Message: fmt.Sprintf("前日・翌日との2日平均運転時間（%s / %s）がともに %s を超過",
+					formatDuration(withPrev), formatDuration(withNext), formatDuration(t.MaxTwoDayAvgDriving)),
+			})
+		}
+	}
+
+	// 1か月の拘束時間
+	for _, m := range monthly {
+		result.Monthly = append(result.Monthly, m)
+	}
+	sort.Slice(result.Monthly, func(i, j int) bool {
+		return result.Monthly[i].YearMonth < result.Monthly[j].YearMonth
+	})
+	for _, m := range result.Monthly {
+		if m.Restraint > t.MaxMonthlyRestraint {
+			add(&ComplianceViolation{
+				Rule: ComplianceRuleMonthlyRestraint, Severity: ComplianceSeverityViolation,
+				Date: m.YearMonth, Actual: m.Restraint, Limit: t.MaxMonthlyRestraint,
+				Message: fmt.Sprintf("月間拘束時間 %s が上限 %s を超過", formatDuration(m.Restraint), formatDuration(t.MaxMonthlyRestraint)),
+			})
+		}
+	}
+
+	return result, violations
+}
+
+// formatDuration 時間を「H時間MM分」形式で表示
+func formatDuration(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	sign := ""
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	return fmt.Sprintf("%s%d時間%02d分", sign, minutes/60, minutes%60)
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pageTokenVersion ページトークンの形式バージョン
+const pageTokenVersion = 1
+
+// RowCursor カーソルページングの位置（最後に返した行）
+//
+// 行は (読取日 DESC, id DESC) の順に返すため、次のページはこの行より後の行から始まります。
+// 読取日順に並べるので、ページ間に新しく読み取られた行が追加されてもページがずれません。
+type RowCursor struct {
+	ReadDate string // 最後に返した行の読取日 (RFC3339)
+	ID       string // 最後に返した行のID
+	Offset   int32  // 最後に返した行の db_service 上のオフセット（次のページの取得開始位置の目安、不明な場合は0）
+}
+
+// pageToken ページトークンの内容（base64url エンコードしたJSON）
+type pageToken struct {
+	Version  int    `json:"v"`
+	ReadDate string `json:"rd"`
+	ID       string `json:"id"`
+	Offset   int32  `json:"o,omitempty"` // 取得開始位置の目安
+	Filter   string `json:"f"`           // フィルタ条件のフィンガープリント
+	Total    int32  `json:"t"`           // 1ページ目取得時点の総件数
+}
+
+// EncodePageToken カーソルとフィルタ条件からページトークンを作成
+func EncodePageToken(cursor *RowCursor, filter *FilterOptions, totalCount int32) string {
+	data, err := json.Marshal(pageToken{
+		Version:  pageTokenVersion,
+		ReadDate: cursor.ReadDate,
+		ID:       cursor.ID,
+		Offset:   cursor.Offset,
+		Filter:   FilterFingerprint(filter),
+		Total:    totalCount,
+	})
+	if err != nil {
+		// 文字列と数値のみのため発生しない
+		log.Printf("Failed to encode page token: %v", err)
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken ページトークンを検証してカーソルと総件数を取得
+//
+// 形式が不正な場合、またはトークン作成時とフィルタ条件が異なる場合は InvalidArgument を返します。
+func DecodePageToken(token string, filter *FilterOptions) (*RowCursor, int32, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil || t.Version != pageTokenVersion || t.ID == "" {
+		return nil, 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if t.Filter != FilterFingerprint(filter) {
+		return nil, 0, status.Error(codes.InvalidArgument, "page_token does not match the request filters")
+	}
+
+	offset := t.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	return &RowCursor{ReadDate: t.ReadDate, ID: t.ID, Offset: offset}, t.Total, nil
+}
+
+// FilterFingerprint フィルタ条件のフィンガープリント
+//
+// 同じ条件であれば運行NOの指定順に関わらず同じ値になります。
+func FilterFingerprint(filter *FilterOptions) string {
+	if filter == nil {
+		filter = &FilterOptions{}
+	}
+
+	parts := []string{
+		"car_cc=" + optionalString(filter.CarCC),
+		"start=" + optionalTime(filter.StartDate),
+		"end=" + optionalTime(filter.EndDate),
+		"min_distance=" + optionalFloat(filter.MinDistance),
+		"exclude_zero=" + strconv.FormatBool(filter.ExcludeZeroDistance),
+		"driver=" + optionalInt32(filter.DriverCode),
+		"invalid_dates=" + strconv.FormatBool(filter.IncludeInvalidDates),
+		"order_by=" + filter.OrderBy,
+	}
+	opNos := append([]string(nil), filter.OperationNos...)
+	sort.Strings(opNos)
+	parts = append(parts, "operation_nos="+strings.Join(opNos, ","))
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// optionalString フィンガープリント用の表現（nil は "-"）
+func optionalString(v *string) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Quote(*v)
+}
+
+// optionalTime フィンガープリント用の表現（nil は "-"）
+func optionalTime(v *time.Time) string {
+	if v == nil {
+		return "-"
+	}
+	return v.Format(time.RFC3339)
+}
+
+// optionalFloat フィンガープリント用の表現（nil は "-"）
+func optionalFloat(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*v, 'g', -1, 64)
+}
+
+// optionalInt32 フィンガープリント用の表現（nil は "-"）
+func optionalInt32(v *int32) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(int(*v))
+}
+
+// ListWithCursor カーソルページングでのデータ取得
+//
+// (読取日 DESC, id DESC) の順で cursor より後の行を最大 limit 件返します。
+// 続きがある場合は次のページのカーソルを返します（ない場合は nil）。
+// countAll が true の場合は条件に一致する行を最後まで走査して総件数を返し、
+// false の場合は limit 件集まった時点で走査を打ち切ります（総件数は0）。
+//
+// countAll が false の場合は cursor.Offset のページから取得を始める（seekPages）ため、
+// 2ページ目以降も先頭から走査し直しません。countAll が true の場合は並列に全件を
+// 走査するため、返すカーソルのオフセットは0（次のページは先頭から走査）です。
+func (s *DtakoRowsService) ListWithCursor(ctx context.Context, filter *FilterOptions, limit int32, cursor *RowCursor, countAll bool) ([]*dbpb.Db_DTakoRows, *RowCursor, int32, *QueryPlan, error) {
+	plan := PlanQueryByReadDate(filter)
+	log.Printf("ListWithCursor: limit=%d, cursor=%v, %s", limit, cursor, plan)
+
+	var pageRows []*dbpb.Db_DTakoRows
+	var offsets []int32
+	totalCount := int32(0)
+
+	collect := func(row *dbpb.Db_DTakoRows, offset int32) {
+		totalCount++
+		if cursor != nil && !cursor.before(row) {
+			return
+		}
+		// 続きの有無を判定するため limit+1 件まで保持
+		if int32(len(pageRows)) <= limit {
+			pageRows = append(pageRows, row)
+			offsets = append(offsets, offset)
+		}
+	}
+
+	var err error
+	switch {
+	case plan.Path == QueryPathOperationNoLookup:
+		// 運行NOごとの取得は並び順が保証されないため、全件取得して並べ替える
+		var matched []*dbpb.Db_DTakoRows
+		_, err = s.scan(ctx, filter, plan, func(rows []*dbpb.Db_DTakoRows) error {
+			matched = append(matched, rows...)
+			return nil
+		})
+		sort.SliceStable(matched, func(i, j int) bool {
+			return compareRowKeys(matched[i].ReadDate, matched[i].Id, matched[j].ReadDate, matched[j].Id) > 0
+		})
+		for _, row := range matched {
+			collect(row, 0)
+		}
+	case countAll:
+		_, err = s.scan(ctx, filter, plan, func(rows []*dbpb.Db_DTakoRows) error {
+			for _, row := range rows {
+				collect(row, 0)
+			}
+			return nil
+		})
+	default:
+		err = s.seekPages(ctx, filter, plan, cursor, func(row *dbpb.Db_DTakoRows, offset int32) error {
+			collect(row, offset)
+			if int32(len(pageRows)) > limit {
+				return errStopScan
+			}
+			return nil
+		})
+		if errors.Is(err, errStopScan) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, nil, 0, plan, err
+	}
+
+	var next *RowCursor
+	if int32(len(pageRows)) > limit {
+		pageRows = pageRows[:limit]
+		last := pageRows[len(pageRows)-1]
+		next = &RowCursor{ReadDate: last.ReadDate, ID: last.Id, Offset: offsets[limit-1]}
+	}
+	if !countAll {
+		totalCount = 0
+	}
+
+	return pageRows, next, totalCount, plan, nil
+}
+
+// seekPages カーソルの位置から (読取日 DESC, id DESC) の順に1ページずつ取得してフィルタリング
+//
+// db_service の List は条件で絞り込めないため、cursor.Offset を含むページから取得を始めます。
+// 最初のページの先頭行がすでにカーソルより後の場合（カーソルより前の行が削除された場合）は、
+// 行を読み飛ばさないよう先頭のページから取得し直します。
+// fn には条件に一致した行と、その行の db_service 上のオフセットを渡します。
+func (s *DtakoRowsService) seekPages(ctx context.Context, filter *FilterOptions, plan *QueryPlan, cursor *RowCursor, fn func(row *dbpb.Db_DTakoRows, offset int32) error) error {
+	const pageSize = int32(1000)
+
+	start := int32(0)
+	if cursor != nil {
+		start = cursor.Offset / pageSize * pageSize
+	}
+
+	for offset := start; ; offset += pageSize {
+		resp, err := s.dbClient.List(ctx, &dbpb.Db_ListDTakoRowsRequest{
+			Limit:   pageSize,
+			Offset:  offset,
+			OrderBy: &plan.OrderBy,
+		})
+		if err != nil {
+			log.Printf("Failed to list rows: %v", err)
+			return err
+		}
+
+		if offset == start && start > 0 && (len(resp.Items) == 0 || cursor.before(resp.Items[0])) {
+			log.Printf("Rows before the cursor were removed, rescanning from the first page")
+			return s.seekPages(ctx, filter, plan, nil, fn)
+		}
+
+		for i, row := range resp.Items {
+			if !s.matchesFilter(row, filter) {
+				continue
+			}
+			if err := fn(row, offset+int32(i)); err != nil {
+				return err
+			}
+		}
+
+		if len(resp.Items) < int(pageSize) {
+			return nil
+		}
+	}
+}
+
+// before カーソルの行が row より前（row がカーソルより後のページ）か
+func (c *RowCursor) before(row *dbpb.Db_DTakoRows) bool {
+	return compareRowKeys(c.ReadDate, c.ID, row.ReadDate, row.Id) > 0
+}
+
+// String ログ出力用の表現
+func (c *RowCursor) String() string {
+	if c == nil {
+		return "<first>"
+	}
+	return fmt.Sprintf("(%s, %s)", c.ReadDate, c.ID)
+}
+
+// compareRowKeys (読取日, id) の大小比較（a > b で正、a < b で負）
+//
+// 読取日はRFC3339として比較します（パースできない場合は文字列比較）。
+func compareRowKeys(readDateA, idA, readDateB, idB string) int {
+	if c := compareReadDates(readDateA, readDateB); c != 0 {
+		return c
+	}
+	return compareIDs(idA, idB)
+}
+
+// compareReadDates 読取日の比較
+func compareReadDates(a, b string) int {
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return ta.Compare(tb)
+}
+
+// compareIDs idの比較
+//
+// db_service の id は varchar のため、`id DESC` と同じ文字列の順で比較します。
+func compareIDs(a, b string) int {
+	return strings.Compare(a, b)
+}
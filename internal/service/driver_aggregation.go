@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// UnassignedDriverCode 乗務員CD1が未設定の運行をまとめる集計キー
+const UnassignedDriverCode = "unassigned"
+
+// DriverSummary 乗務員別の期間サマリー
+type DriverSummary struct {
+	DriverCode           string  // 乗務員CD1（未設定の場合は UnassignedDriverCode）
+	Period               string  // 集計期間のキー (YYYY-MM, YYYY-MM-DD など)
+	Bucket               Bucket  // 集計期間（範囲・表示名）
+	TotalDistance        float64 // 総走行距離
+	LoadedDistance       float64 // 実車走行距離
+	TripCount            int32   // 運行回数
+	GeneralRoadDriveTime int32   // 一般道運転時間
+	HighwayDriveTime     int32   // 高速道運転時間
+	BypassDriveTime      int32   // バイパス運転時間
+	Work1Time            int32   // 作業１時間
+	Work2Time            int32   // 作業２時間
+	Work3Time            int32   // 作業３時間
+	Work4Time            int32   // 作業４時間
+}
+
+// GetDriverMonthlySummary 乗務員ごとの月次サマリーを取得
+//
+// driverCode を指定した場合はその乗務員のみ集計します。集計期間は bucketing（通常は月）です。
+// 戻り値は乗務員CD1 → 期間順のサマリー一覧です。
+func (s *DtakoRowsService) GetDriverMonthlySummary(ctx context.Context, startDate, endDate string, driverCode *int32, bucketing Bucketing) (map[string][]*DriverSummary, error) {
+	log.Printf("GetDriverMonthlySummary: start=%s, end=%s, bucket=%s", startDate, endDate, bucketing.Kind)
+	return s.aggregateByDriver(ctx, startDate, endDate, driverCode, bucketing)
+}
+
+// GetDriverDailySummary 乗務員ごとの日次サマリーを取得
+//
+// driverCode を指定した場合はその乗務員のみ集計します。集計期間は bucketing（通常は日）です。
+// 戻り値は乗務員CD1 → 期間順のサマリー一覧です。
+func (s *DtakoRowsService) GetDriverDailySummary(ctx context.Context, startDate, endDate string, driverCode *int32, bucketing Bucketing) (map[string][]*DriverSummary, error) {
+	log.Printf("GetDriverDailySummary: start=%s, end=%s, bucket=%s", startDate, endDate, bucketing.Kind)
+	return s.aggregateByDriver(ctx, startDate, endDate, driverCode, bucketing)
+}
+
+// aggregateByDriver 乗務員CD1・集計期間ごとに集計
+func (s *DtakoRowsService) aggregateByDriver(ctx context.Context, startDate, endDate string, driverCode *int32, bucketing Bucketing) (map[string][]*DriverSummary, error) {
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	allRows, err := s.ListByDateRange(ctx, startDate, endDate, 0)
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, err
+	}
+
+	driverData := make(map[string]map[string]*DriverSummary)
+
+	for _, row := range allRows {
+		if driverCode != nil && (row.DriverCode1 == nil || *row.DriverCode1 != *driverCode) {
+			continue
+		}
+
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			continue
+		}
+
+		key := driverKey(row)
+		bucket := periods.Bucket(opDate)
+
+		if _, exists := driverData[key]; !exists {
+			driverData[key] = make(map[string]*DriverSummary)
+		}
+		if _, exists := driverData[key][bucket.Key]; !exists {
+			driverData[key][bucket.Key] = &DriverSummary{
+				DriverCode: key,
+				Period:     bucket.Key,
+				Bucket:     bucket,
+			}
+		}
+
+		summary := driverData[key][bucket.Key]
+		summary.TotalDistance += row.TotalDistance
+		if row.LoadedDistance != nil {
+			summary.LoadedDistance += *row.LoadedDistance
+		}
+		summary.TripCount++
+		summary.GeneralRoadDriveTime += row.GeneralRoadDriveTime
+		summary.HighwayDriveTime += row.HighwayDriveTime
+		summary.BypassDriveTime += row.BypassDriveTime
+		summary.Work1Time += row.Work1Time
+		summary.Work2Time += row.Work2Time
+		summary.Work3Time += row.Work3Time
+		summary.Work4Time += row.Work4Time
+	}
+
+	// マップを整形
+	results := make(map[string][]*DriverSummary)
+	for key, periodData := range driverData {
+		summaries := make([]*DriverSummary, 0, len(periodData))
+		for _, summary := range periodData {
+			summaries = append(summaries, summary)
+		}
+		// 期間でソート
+		sort.Slice(summaries, func(i, j int) bool {
+			return summaries[i].Period < summaries[j].Period
+		})
+		results[key] = summaries
+	}
+
+	log.Printf("Aggregated data for %d drivers", len(results))
+	return results, nil
+}
+
+// driverKey 運行データの乗務員集計キー
+//
+// 乗務員CD1が未設定（NULL）または0の運行は UnassignedDriverCode にまとめる。
+func driverKey(row *dbpb.Db_DTakoRows) string {
+	if row.DriverCode1 == nil || *row.DriverCode1 == 0 {
+		return UnassignedDriverCode
+	}
+	return strconv.Itoa(int(*row.DriverCode1))
+}
+
+// sortDriverCodes 乗務員CD1を数値順に並べる（未割当は末尾）
+func sortDriverCodes(codes []string) {
+	sortCodes(codes, UnassignedDriverCode)
+}
+
+// sortCodes 数値のコードを数値順に並べる（unassigned は末尾）
+func sortCodes(codes []string, unassigned string) {
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i] == unassigned || codes[j] == unassigned {
+			return codes[j] == unassigned && codes[i] != unassigned
+		}
+		a, _ := strconv.Atoi(codes[i])
+		b, _ := strconv.Atoi(codes[j])
+		return a < b
+	})
+}
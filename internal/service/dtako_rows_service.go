@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 
@@ -14,19 +15,32 @@ import (
 
 // FilterOptions サービス層でのフィルタリングオプション
 type FilterOptions struct {
-	CarCC              *string    // 車輌CC（完全一致）
-	StartDate          *time.Time // 運行開始日（以降）
-	EndDate            *time.Time // 運行終了日（以前）
-	MinDistance        *float64   // 最小走行距離
-	OperationNos       []string   // 運行NO（複数指定可）
-	ExcludeZeroDistance bool      // 走行距離0のデータを除外
+	CarCC               *string    // 車輌CC（完全一致）
+	StartDate           *time.Time // 運行開始日（以降）
+	EndDate             *time.Time // 運行終了日（以前）
+	MinDistance         *float64   // 最小走行距離
+	OperationNos        []string   // 運行NO（複数指定可）
+	ExcludeZeroDistance bool       // 走行距離0のデータを除外
+	DriverCode          *int32     // 乗務員CD1（完全一致）
+	IncludeInvalidDates bool       // 運行日がパースできないデータも含める（データ検証用）
+	OrderBy             string     // 並び順（db_serviceのORDER BY句、省略時は実行計画の既定）
 }
 
 // DtakoRowsService gRPCサービス実装（読み取り専用）
 // データアクセスはdb_service経由で行う
 type DtakoRowsService struct {
 	dbpb.UnimplementedDb_DTakoRowsServiceServer
-	dbClient dbpb.Db_DTakoRowsServiceClient
+	dbClient     dbpb.Db_DTakoRowsServiceClient
+	cars         *CarMaster // 車両マスタ（燃費の決定・事業所別集計）
+	fuelResolver *FuelEfficiencyResolver
+	fuelSource   FuelSource                            // 実給油データ（nilの場合は推定値のみ）
+	fetchWorkers int                                   // db_serviceからの並列取得数
+	rollups      *RollupStore                          // 日次集計のロールアップ（nilの場合は運行データから直接集計）
+	stopRollups  func()                                // ロールアップの更新ジョブの停止（nilの場合は未開始）
+	etc          *ETCSource                            // ETC明細（nilの場合は通行料金なし）
+	ferries      *FerrySource                          // フェリー運行データ（見なし距離・フェリー料金）
+	uriageKeihi  dbpb.Db_DTakoUriageKeihiServiceClient // 売上・経費（nilの場合は採算の売上・経費なし）
+	events       *EventSource                          // イベントデータ（nilの場合はイベント集計なし）
 }
 
 // NewDtakoRowsService サービスの作成（スタンドアロン用）
@@ -38,23 +52,44 @@ func NewDtakoRowsService(dbServiceAddr string) (*DtakoRowsService, error) {
 		return nil, err
 	}
 
-	client := dbpb.NewDb_DTakoRowsServiceClient(conn)
+	clients := NewDBClientsFromConn(conn)
 	log.Printf("Connected to db_service at %s", dbServiceAddr)
 
-	return &DtakoRowsService{
-		dbClient: client,
-	}, nil
+	return NewDtakoRowsServiceWithClients(clients), nil
 }
 
 // NewDtakoRowsServiceWithClient サービスの作成（desktop-server統合用）
 // 既存のdb_serviceクライアントを受け取る
 func NewDtakoRowsServiceWithClient(client dbpb.Db_DTakoRowsServiceClient) *DtakoRowsService {
 	log.Println("Creating dtako_rows service with existing db_service client")
+	return NewDtakoRowsServiceWithClients(&DBClients{Rows: client})
+}
+
+// NewDtakoRowsServiceWithClients サービスの作成（複数のdb_serviceクライアントを使用）
+// 車両マスタなどのオプショナルなクライアントも受け取る
+func NewDtakoRowsServiceWithClients(clients *DBClients) *DtakoRowsService {
+	cars := NewCarMaster(clients.Cars)
+	workers := fetchWorkersFromEnv()
 	return &DtakoRowsService{
-		dbClient: client,
+		dbClient:     clients.Rows,
+		cars:         cars,
+		fuelResolver: NewFuelEfficiencyResolverFromEnv(cars),
+		fuelSource:   NewFuelSourceFromEnv(),
+		fetchWorkers: workers,
+		etc:          NewETCSource(clients, workers),
+		ferries:      NewFerrySource(clients.Ferries),
+		uriageKeihi:  clients.UriageKeihi,
+		events:       NewEventSource(clients.Events, workers),
 	}
 }
 
+// SetFuelSource 実給油データの取得元を設定
+//
+// 環境変数（FUEL_CARD_CSV）以外の取得元を使用する場合に呼び出します。
+func (s *DtakoRowsService) SetFuelSource(source FuelSource) {
+	s.fuelSource = source
+}
+
 // Get 運行データ取得
 func (s *DtakoRowsService) Get(ctx context.Context, req *dbpb.Db_GetDTakoRowsRequest) (*dbpb.Db_DTakoRowsResponse, error) {
 	// ビジネスロジック: バリデーション
@@ -146,63 +181,151 @@ func (s *DtakoRowsService) GetByOperationNo(ctx context.Context, req *dbpb.Db_Ge
 // サービス層でフィルタリングを行います。
 // db_serviceにフィルタ機能がない場合でも、このメソッドで柔軟なフィルタリングが可能です。
 func (s *DtakoRowsService) ListWithFilter(ctx context.Context, filter *FilterOptions, limit int32, offset int32) ([]*dbpb.Db_DTakoRows, int32, error) {
-	log.Printf("ListWithFilter: limit=%d, offset=%d", limit, offset)
+	rows, totalCount, _, err := s.ListWithPlan(ctx, filter, limit, offset)
+	return rows, totalCount, err
+}
+
+// ListWithPlan フィルタリングオプション付きデータ取得（実行計画付き）
+//
+// PlanQuery で db_service 側に任せられる条件を判定し、
+// 残りの条件のみメモリ上で評価します。使用した実行計画も返します。
+// 戻り値の totalCount はフィルタ後の総件数です（正確な件数を返すため、
+// 取得範囲を超えても走査は続けますが、保持するのは offset〜offset+limit の行のみです）。
+func (s *DtakoRowsService) ListWithPlan(ctx context.Context, filter *FilterOptions, limit int32, offset int32) ([]*dbpb.Db_DTakoRows, int32, *QueryPlan, error) {
+	plan := PlanQuery(filter)
+	log.Printf("ListWithFilter: limit=%d, offset=%d, %s", limit, offset, plan)
+
+	pageRows := make([]*dbpb.Db_DTakoRows, 0)
+	totalCount := int32(0)
+	totalFetched, err := s.scan(ctx, filter, plan, func(rows []*dbpb.Db_DTakoRows) error {
+		for _, row := range rows {
+			// ページネーション処理: 取得範囲の行のみ保持し、件数は全件数える
+			if totalCount >= offset && (limit == 0 || totalCount < offset+limit) {
+				pageRows = append(pageRows, row)
+			}
+			totalCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, plan, err
+	}
+
+	log.Printf("Filtered %d rows from %d fetched rows (path=%s)", totalCount, totalFetched, plan.Path)
+
+	return pageRows, totalCount, plan, nil
+}
+
+// errStopScan scan のコールバックから走査を正常終了させるためのエラー
+var errStopScan = errors.New("stop scan")
+
+// ScanWithFilter フィルタ条件に一致する行をページ単位でコールバックに渡す
+//
+// ListWithFilter と異なり全件をメモリに保持しないため、
+// ストリーミングRPCなど逐次処理したい場合に使用します。
+func (s *DtakoRowsService) ScanWithFilter(ctx context.Context, filter *FilterOptions, fn func(rows []*dbpb.Db_DTakoRows) error) (*QueryPlan, error) {
+	plan := PlanQuery(filter)
+	log.Printf("ScanWithFilter: %s", plan)
+
+	totalFetched, err := s.scan(ctx, filter, plan, fn)
+	if err != nil {
+		return plan, err
+	}
 
-	// ページネーションで全データを取得
-	req := &dbpb.Db_ListDTakoRowsRequest{
-		Limit:  1000, // 大きめのバッチサイズ
-		Offset: 0,
+	log.Printf("Scanned %d rows (path=%s)", totalFetched, plan.Path)
+	return plan, nil
+}
+
+// scan 実行計画に従ってdb_serviceから取得し、フィルタ後の行をページ単位でコールバックに渡す
+//
+// コールバックが errStopScan を返した場合は正常終了として走査を打ち切ります。
+// 戻り値はdb_serviceから取得した（フィルタ前の）行数です。
+func (s *DtakoRowsService) scan(ctx context.Context, filter *FilterOptions, plan *QueryPlan, fn func(rows []*dbpb.Db_DTakoRows) error) (int32, error) {
+	var totalFetched int32
+	var err error
+
+	switch plan.Path {
+	case QueryPathOperationNoLookup:
+		totalFetched, err = s.scanByOperationNos(ctx, filter, fn)
+	default:
+		totalFetched, err = s.scanPages(ctx, filter, plan, fn)
 	}
+	if errors.Is(err, errStopScan) {
+		err = nil
+	}
+	return totalFetched, err
+}
 
-	allRows := make([]*dbpb.Db_DTakoRows, 0)
+// scanByOperationNos 運行NOごとにdb_serviceから直接取得してフィルタリング
+func (s *DtakoRowsService) scanByOperationNos(ctx context.Context, filter *FilterOptions, fn func(rows []*dbpb.Db_DTakoRows) error) (int32, error) {
 	totalFetched := int32(0)
+	seen := make(map[string]bool)
 
-	for {
-		resp, err := s.dbClient.List(ctx, req)
-		if err != nil {
-			log.Printf("Failed to list rows: %v", err)
-			return nil, 0, err
+	for _, opNo := range filter.OperationNos {
+		if seen[opNo] {
+			continue
 		}
+		seen[opNo] = true
 
-		// フィルタリング処理
-		for _, row := range resp.Items {
-			if s.matchesFilter(row, filter) {
-				allRows = append(allRows, row)
-			}
+		resp, err := s.dbClient.GetByOperationNo(ctx, &dbpb.Db_GetDTakoRowsByOperationNoRequest{
+			OperationNo: opNo,
+		})
+		if err != nil {
+			log.Printf("Failed to get rows by operation_no: %v", err)
+			return totalFetched, err
 		}
-
 		totalFetched += int32(len(resp.Items))
 
-		// ページネーション終了判定
-		if len(resp.Items) < int(req.Limit) {
-			break
-		}
-		req.Offset += req.Limit
-
-		// 最適化: 必要な件数が既に集まったら早期終了
-		if limit > 0 && int32(len(allRows)) >= offset+limit {
-			break
+		if err := fn(s.filterRows(resp.Items, filter)); err != nil {
+			return totalFetched, err
 		}
 	}
 
-	log.Printf("Filtered %d rows from %d total rows", len(allRows), totalFetched)
+	return totalFetched, nil
+}
+
+// scanPages db_serviceからページ単位で取得してフィルタリング
+//
+// ページは PageFetcher で並列に取得し、オフセット順にコールバックへ渡します。
+// 実行計画が date_range_seek の場合、運行日降順に並んでいるため
+// 開始日より古い行が現れたページで走査を打ち切ります。
+func (s *DtakoRowsService) scanPages(ctx context.Context, filter *FilterOptions, plan *QueryPlan, fn func(rows []*dbpb.Db_DTakoRows) error) (int32, error) {
+	fetcher := NewPageFetcher(s.dbClient, 1000, s.fetchWorkers)
+
+	return fetcher.Fetch(ctx, plan.OrderBy, func(items []*dbpb.Db_DTakoRows) error {
+		// フィルタリング処理
+		if err := fn(s.filterRows(items, filter)); err != nil {
+			return err
+		}
 
-	// ページネーション処理
-	totalCount := int32(len(allRows))
-	startIdx := offset
-	endIdx := offset + limit
+		if plan.Path == QueryPathDateRangeSeek && len(items) > 0 && isBeforeStartDate(items[len(items)-1], filter) {
+			return errStopScan
+		}
+		return nil
+	})
+}
 
-	if startIdx > totalCount {
-		return []*dbpb.Db_DTakoRows{}, totalCount, nil
+// filterRows フィルタ条件に一致する行のみを抽出
+func (s *DtakoRowsService) filterRows(rows []*dbpb.Db_DTakoRows, filter *FilterOptions) []*dbpb.Db_DTakoRows {
+	filtered := make([]*dbpb.Db_DTakoRows, 0, len(rows))
+	for _, row := range rows {
+		if s.matchesFilter(row, filter) {
+			filtered = append(filtered, row)
+		}
 	}
-	if endIdx > totalCount {
-		endIdx = totalCount
+	return filtered
+}
+
+// isBeforeStartDate 運行日がフィルタの開始日より前かどうか
+func isBeforeStartDate(row *dbpb.Db_DTakoRows, filter *FilterOptions) bool {
+	if filter == nil || filter.StartDate == nil {
+		return false
 	}
-	if limit == 0 {
-		endIdx = totalCount
+	opDate, err := parseOperationDateValue(row.OperationDate)
+	if err != nil {
+		return false
 	}
-
-	return allRows[startIdx:endIdx], totalCount, nil
+	return opDate.Before(*filter.StartDate)
 }
 
 // matchesFilter 単一行がフィルタ条件に一致するかチェック
@@ -232,17 +355,19 @@ func (s *DtakoRowsService) matchesFilter(row *dbpb.Db_DTakoRows, filter *FilterO
 
 	// 運行日フィルタ
 	if filter.StartDate != nil || filter.EndDate != nil {
-		opDate, err := time.Parse(time.RFC3339, row.OperationDate)
-		if err != nil {
-			log.Printf("Failed to parse operation date: %s", row.OperationDate)
-			return false
-		}
-
-		if filter.StartDate != nil && opDate.Before(*filter.StartDate) {
-			return false
-		}
-		if filter.EndDate != nil && opDate.After(*filter.EndDate) {
-			return false
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			// データ検証用: 運行日が不正な行も返す
+			if !filter.IncludeInvalidDates {
+				return false
+			}
+		} else {
+			if filter.StartDate != nil && opDate.Before(*filter.StartDate) {
+				return false
+			}
+			if filter.EndDate != nil && opDate.After(*filter.EndDate) {
+				return false
+			}
 		}
 	}
 
@@ -256,6 +381,11 @@ func (s *DtakoRowsService) matchesFilter(row *dbpb.Db_DTakoRows, filter *FilterO
 		return false
 	}
 
+	// 乗務員CD1フィルタ
+	if filter.DriverCode != nil && (row.DriverCode1 == nil || *row.DriverCode1 != *filter.DriverCode) {
+		return false
+	}
+
 	return true
 }
 
@@ -270,13 +400,9 @@ func (s *DtakoRowsService) ListByCarCC(ctx context.Context, carCC string, limit
 
 // ListByDateRange 日付範囲で絞り込んだデータ取得（ヘルパーメソッド）
 func (s *DtakoRowsService) ListByDateRange(ctx context.Context, startDate, endDate string, limit int32) ([]*dbpb.Db_DTakoRows, error) {
-	start, err := time.Parse("2006-01-02", startDate)
+	start, end, err := parseDateRange(startDate, endDate)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid start_date format: %v", err)
-	}
-	end, err := time.Parse("2006-01-02", endDate)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid end_date format: %v", err)
+		return nil, err
 	}
 
 	filter := &FilterOptions{
@@ -289,13 +415,9 @@ func (s *DtakoRowsService) ListByDateRange(ctx context.Context, startDate, endDa
 
 // ListByCarCCAndDateRange 車輌CCと日付範囲で絞り込んだデータ取得（ヘルパーメソッド）
 func (s *DtakoRowsService) ListByCarCCAndDateRange(ctx context.Context, carCC, startDate, endDate string, limit int32) ([]*dbpb.Db_DTakoRows, error) {
-	start, err := time.Parse("2006-01-02", startDate)
+	start, end, err := parseDateRange(startDate, endDate)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid start_date format: %v", err)
-	}
-	end, err := time.Parse("2006-01-02", endDate)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid end_date format: %v", err)
+		return nil, err
 	}
 
 	filter := &FilterOptions{
@@ -306,3 +428,33 @@ func (s *DtakoRowsService) ListByCarCCAndDateRange(ctx context.Context, carCC, s
 	rows, _, err := s.ListWithFilter(ctx, filter, limit, 0)
 	return rows, err
 }
+
+// parseDateRange 開始日・終了日 (YYYY-MM-DD) をパース
+//
+// 業務タイムゾーン（BusinessLocation）で、開始日の0時から終了日の終わりまで（両端を含む）を返します。
+func parseDateRange(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := parseStartDate(startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseEndDate(endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// parseOperationDate 運行日 (RFC3339) をパースし、業務タイムゾーンの時刻にする
+//
+// 日付・月の判定（Format や集計期間）は変換後の時刻で行うため、文字列のオフセットに
+// かかわらず業務上の日付で集計される（変換方法は parseOperationDateValue を参照）。
+// パースできない行は集計から除外されるため、行IDをログに残す。
+// 除外された行は ValidateRows で invalid_date として確認できる。
+func parseOperationDate(row *dbpb.Db_DTakoRows) (time.Time, bool) {
+	opDate, err := parseOperationDateValue(row.OperationDate)
+	if err != nil {
+		log.Printf("Skipping row %s: invalid operation_date %q", row.Id, row.OperationDate)
+		return time.Time{}, false
+	}
+	return opDate, true
+}
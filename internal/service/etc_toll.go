@@ -0,0 +1,564 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// 運行に対応付けられなかったETC明細の理由
+const (
+	TollUnmatchedInvalidDate = "invalid_date" // 利用日時がパースできない
+	TollUnmatchedUnknownCard = "unknown_card" // ETCカード番号マスタに該当する車両がない
+	TollUnmatchedNoTrip      = "no_trip"      // 利用時刻に該当する運行がない
+)
+
+// defaultETCMatchWindow 運行の出庫前・帰庫後に許容する利用時刻のずれのデフォルト
+const defaultETCMatchWindow = 30 * time.Minute
+
+// etcTripSpanDays 運行の最大日数（期間の前後の運行・ETC明細の取得範囲）
+const etcTripSpanDays = 3
+
+// etcTimeLayouts ETC明細の利用日時として受け付ける形式（タイムゾーンなしは業務タイムゾーン）
+var etcTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+}
+
+// ETCSource ETC明細の取得元（db_serviceのETC明細・マッピング・ETCカード番号マスタ）
+//
+// ETC明細のクライアントがない場合は nil です。マッピング・ETCカード番号マスタの
+// クライアントがない場合は、それぞれの対応付けを行いません。
+type ETCSource struct {
+	meisai  dbpb.Db_ETCMeisaiServiceClient
+	mapping dbpb.Db_ETCMeisaiMappingServiceClient
+	cards   dbpb.Db_ETCNumServiceClient
+	window  time.Duration // 運行の出庫前・帰庫後に許容する利用時刻のずれ
+	workers int           // マッピングの並列取得数
+}
+
+// NewETCSource ETC明細の取得元を作成
+//
+// 利用時刻のずれの許容幅は環境変数 ETC_MATCH_WINDOW（既定30分）で変更できます。
+func NewETCSource(clients *DBClients, workers int) *ETCSource {
+	if clients.ETCMeisai == nil {
+		return nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return &ETCSource{
+		meisai:  clients.ETCMeisai,
+		mapping: clients.ETCMapping,
+		cards:   clients.ETCNum,
+		window:  durationFromEnv("ETC_MATCH_WINDOW", defaultETCMatchWindow),
+		workers: workers,
+	}
+}
+
+// Available ETC明細を参照できるか
+func (e *ETCSource) Available() bool {
+	return e != nil && e.meisai != nil
+}
+
+// TripToll 運行ごとの通行料金
+type TripToll struct {
+	RowID          string
+	OperationNo    string
+	CarCC          string
+	CarCode        int32 // 車輌CD（運行データの car_code）
+	OperationDate  time.Time
+	Bucket         Bucket  // 運行日の集計期間
+	TotalDistance  float64 // 走行距離 (km)
+	TollAmount     int64   // 通行料金の合計（円、割引後）
+	TollCount      int32   // ETC明細の件数
+	EstimatedCount int32   // うち利用時刻から推定して対応付けた件数
+}
+
+// TollPeriodSummary 車両の集計期間ごとの通行料金
+type TollPeriodSummary struct {
+	CarCC           string // 車輌CC（全車両の合計の場合は空）
+	Period          string // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket          Bucket // 集計期間（期間全体の合計の場合はゼロ値）
+	TotalDistance   float64
+	TollAmount      int64
+	TollCount       int32
+	TripCount       int32 // 運行回数（通行料金のない運行を含む）
+	TolledTripCount int32 // 通行料金のある運行の回数
+}
+
+// TollPerKm 走行距離1kmあたりの通行料金（走行距離が0の場合は0）
+func (t *TollPeriodSummary) TollPerKm() float64 {
+	return ratio(float64(t.TollAmount), t.TotalDistance)
+}
+
+// addTrip 運行を加算
+func (t *TollPeriodSummary) addTrip(trip *TripToll) {
+	t.TotalDistance += trip.TotalDistance
+	t.TollAmount += trip.TollAmount
+	t.TollCount += trip.TollCount
+	t.TripCount++
+	if trip.TollCount > 0 {
+		t.TolledTripCount++
+	}
+}
+
+// VehicleTolls 1車両分の期間ごとの通行料金と期間全体の合計
+type VehicleTolls struct {
+	CarCC     string
+	Summaries []*TollPeriodSummary // 期間順
+	Total     *TollPeriodSummary
+}
+
+// UnmatchedETCRecord 運行に対応付けられなかったETC明細
+type UnmatchedETCRecord struct {
+	Record *dbpb.Db_ETCMeisai
+	UsedAt time.Time // 利用日時（出口、パースできない場合はゼロ値）
+	CarCC  string    // ETCカードから推定した車輌CC（不明な場合は空）
+	Reason string    // TollUnmatched*
+}
+
+// TollSummary 通行料金の集計結果
+type TollSummary struct {
+	Trips     []*TripToll           // 通行料金のある運行（運行日・運行NO順）
+	Vehicles  []*VehicleTolls       // 車輌CC順
+	Periods   []*TollPeriodSummary  // 全車両の期間ごとの合計（期間順）
+	Total     *TollPeriodSummary    // 全車両の期間全体の合計
+	Unmatched []*UnmatchedETCRecord // 利用日時順
+	Available bool                  // ETC明細を参照できたか
+}
+
+// UnmatchedAmount 運行に対応付けられなかったETC明細の料金の合計
+func (t *TollSummary) UnmatchedAmount() int64 {
+	var amount int64
+	for _, u := range t.Unmatched {
+		amount += int64(u.Record.Price)
+	}
+	return amount
+}
+
+// etcUsage ETC明細の利用時間帯（入口〜出口）
+type etcUsage struct {
+	record   *dbpb.Db_ETCMeisai
+	from, to time.Time
+}
+
+// tripWindow 運行の出庫〜帰庫
+type tripWindow struct {
+	row      *dbpb.Db_DTakoRows
+	from, to time.Time
+}
+
+// GetTollSummary 運行・車両・集計期間ごとの通行料金を集計
+//
+// ETC明細はマッピング（GetDTakoRowIDByHash）で運行に対応付け、マッピングのない明細は
+// ETCカード番号マスタ（ETCNum）で車両を求め、利用時刻が出庫〜帰庫（前後 ETC_MATCH_WINDOW）に
+// 含まれる運行に対応付けます。通行料金は対応付けた運行の運行日の期間に計上します。
+// carCC が空の場合は全車両を集計します。期間内に利用した明細のうち、運行に対応付けられ
+// なかったものは Unmatched に理由とともに返します。
+func (s *DtakoRowsService) GetTollSummary(ctx context.Context, carCC, startDate, endDate string, bucketing Bucketing) (*TollSummary, error) {
+	log.Printf("GetTollSummary: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	trips, unmatched, err := s.tripTolls(ctx, carCC, start, end, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TollSummary{Total: &TollPeriodSummary{}, Unmatched: unmatched, Available: s.etc.Available()}
+	s.summarizeTolls(result, trips)
+
+	log.Printf("Attributed tolls to %d trips, %d unmatched ETC records", len(result.Trips), len(result.Unmatched))
+	return result, nil
+}
+
+// tripTolls 期間内の運行（運行データID → 運行）と運行ごとの通行料金
+//
+// 通行料金のない運行も含みます。2つ目の戻り値は期間内に利用した明細のうち、
+// 運行に対応付けられなかったものです。
+func (s *DtakoRowsService) tripTolls(ctx context.Context, carCC string, start, end time.Time, periods BucketRange) (map[string]*TripToll, []*UnmatchedETCRecord, error) {
+	if !s.etc.Available() {
+		log.Printf("Warning: ETC meisai client is not configured, toll amounts are not available")
+	}
+
+	// 期間をまたぐ運行のETC明細も対応付けられるよう、前の運行も取得する
+	rowStart := start.AddDate(0, 0, -etcTripSpanDays)
+	filter := &FilterOptions{StartDate: &rowStart, EndDate: &end}
+	if carCC != "" {
+		filter.CarCC = &carCC
+	}
+	rows, _, err := s.ListWithFilter(ctx, filter, 0, 0)
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, nil, err
+	}
+
+	trips := make(map[string]*TripToll)
+	tripsByCar := make(map[string][]*tripWindow)
+	for _, row := range rows {
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			continue
+		}
+		if !opDate.Before(start) {
+			trips[row.Id] = &TripToll{
+				RowID:         row.Id,
+				OperationNo:   row.OperationNo,
+				CarCC:         row.CarCc,
+				CarCode:       row.CarCode,
+				OperationDate: opDate,
+				Bucket:        periods.Bucket(opDate),
+				TotalDistance: row.TotalDistance,
+			}
+		}
+		if w, ok := newTripWindow(row); ok {
+			tripsByCar[row.CarCc] = append(tripsByCar[row.CarCc], w)
+		}
+	}
+
+	if !s.etc.Available() {
+		return trips, nil, nil
+	}
+	unmatched, err := s.attributeTolls(ctx, trips, tripsByCar, carCC, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trips, unmatched, nil
+}
+
+// attributeTolls 期間内のETC明細を運行に対応付け、対応付けられなかった明細を返す
+func (s *DtakoRowsService) attributeTolls(ctx context.Context, trips map[string]*TripToll, tripsByCar map[string][]*tripWindow, carCC string, start, end time.Time) ([]*UnmatchedETCRecord, error) {
+	// 期間をまたぐ運行のETC明細も取得する（前日の深夜出庫分を含む）
+	records, err := s.etc.listRecords(ctx, start.AddDate(0, 0, -1), end.AddDate(0, 0, etcTripSpanDays))
+	if err != nil {
+		log.Printf("Failed to list ETC meisai: %v", err)
+		return nil, err
+	}
+
+	mapped, err := s.etc.mappedRowIDs(ctx, records)
+	if err != nil {
+		log.Printf("Failed to get ETC meisai mappings: %v", err)
+		return nil, err
+	}
+
+	var unmatched []*UnmatchedETCRecord
+	var cards map[string][]*dbpb.Db_ETCNum
+	for _, record := range records {
+		usage, ok := newETCUsage(record)
+
+		// マッピング済みの明細はマッピング先の運行に計上（期間外・他車両の運行は対象外）
+		if rowIDs := mapped[record.Hash]; len(rowIDs) > 0 {
+			for _, id := range rowIDs {
+				if trip, exists := trips[id]; exists {
+					trip.TollAmount += int64(record.Price)
+					trip.TollCount++
+					break
+				}
+			}
+			continue
+		}
+
+		if !ok {
+			// 車両が不明な明細は全車両の集計でのみ返す
+			if carCC == "" {
+				unmatched = append(unmatched, &UnmatchedETCRecord{Record: record, Reason: TollUnmatchedInvalidDate})
+			}
+			continue
+		}
+
+		if cards == nil {
+			if cards, err = s.etc.loadCards(ctx); err != nil {
+				log.Printf("Failed to load ETC card master: %v", err)
+				return nil, err
+			}
+		}
+
+		recordCarCC := s.etcCardCarCC(ctx, cards, record.EtcNum, usage.to)
+		if carCC != "" && recordCarCC != "" && recordCarCC != carCC {
+			continue
+		}
+
+		var row *dbpb.Db_DTakoRows
+		if recordCarCC != "" {
+			row = matchTrip(tripsByCar[recordCarCC], usage, s.etc.window)
+		}
+		if row == nil {
+			if carCC != "" && recordCarCC == "" {
+				continue
+			}
+			if !usage.to.Before(start) && !usage.to.After(end) {
+				reason := TollUnmatchedNoTrip
+				if recordCarCC == "" {
+					reason = TollUnmatchedUnknownCard
+				}
+				unmatched = append(unmatched, &UnmatchedETCRecord{Record: record, UsedAt: usage.to, CarCC: recordCarCC, Reason: reason})
+			}
+			continue
+		}
+
+		if trip, exists := trips[row.Id]; exists {
+			trip.TollAmount += int64(record.Price)
+			trip.TollCount++
+			trip.EstimatedCount++
+		}
+	}
+
+	sort.SliceStable(unmatched, func(i, j int) bool {
+		return unmatched[i].UsedAt.Before(unmatched[j].UsedAt)
+	})
+	return unmatched, nil
+}
+
+// etcCardCarCC ETCカード番号から利用時点の車輌CCを求める（不明な場合は空）
+//
+// ETCカード番号マスタの車輌IDは車両マスタの car_cc・car_code と照合し、
+// 車両マスタにない場合は車輌IDをそのまま車輌CCとします。
+func (s *DtakoRowsService) etcCardCarCC(ctx context.Context, cards map[string][]*dbpb.Db_ETCNum, cardNum string, usedAt time.Time) string {
+	for _, card := range cards[cardNum] {
+		if !etcCardValidAt(card, usedAt) || card.CarId == "" {
+			continue
+		}
+		carCode, _ := strconv.Atoi(card.CarId)
+		if car := s.cars.Lookup(ctx, card.CarId, int32(carCode)); car != nil && car.CarCc != "" {
+			return car.CarCc
+		}
+		return card.CarId
+	}
+	return ""
+}
+
+// summarizeTolls 運行ごとの通行料金を車両・期間ごとに集計
+func (s *DtakoRowsService) summarizeTolls(result *TollSummary, trips map[string]*TripToll) {
+	rollup := newPeriodRollup(result.Total, func(carCC string, bucket Bucket) *TollPeriodSummary {
+		return &TollPeriodSummary{CarCC: carCC, Period: bucket.Key, Bucket: bucket}
+	})
+
+	for _, trip := range trips {
+		rollup.add(trip.CarCC, trip.Bucket, func(summary *TollPeriodSummary) {
+			summary.addTrip(trip)
+		})
+
+		if trip.TollCount > 0 {
+			result.Trips = append(result.Trips, trip)
+		}
+	}
+
+	sort.Slice(result.Trips, func(i, j int) bool {
+		a, b := result.Trips[i], result.Trips[j]
+		if !a.OperationDate.Equal(b.OperationDate) {
+			return a.OperationDate.Before(b.OperationDate)
+		}
+		if a.OperationNo != b.OperationNo {
+			return a.OperationNo < b.OperationNo
+		}
+		return a.RowID < b.RowID
+	})
+
+	for _, carCC := range rollup.carCCs() {
+		result.Vehicles = append(result.Vehicles, &VehicleTolls{
+			CarCC:     carCC,
+			Summaries: rollup.vehicleSummaries(carCC),
+			Total:     rollup.vehicleTotal(carCC),
+		})
+	}
+	result.Periods = rollup.periods()
+}
+
+// listRecords 利用日（出口）が start〜end の日付（両端を含む）のETC明細を全件取得
+func (e *ETCSource) listRecords(ctx context.Context, start, end time.Time) ([]*dbpb.Db_ETCMeisai, error) {
+	startDate, endDate := datetimeFilterRange(start, end)
+	req := &dbpb.Db_ListETCMeisaiRequest{
+		StartDate: &startDate,
+		EndDate:   &endDate,
+		Limit:     1000,
+		Offset:    0,
+	}
+
+	var records []*dbpb.Db_ETCMeisai
+	for {
+		resp, err := e.meisai.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, resp.Items...)
+
+		if len(resp.Items) < int(req.Limit) {
+			break
+		}
+		req.Offset += req.Limit
+	}
+
+	log.Printf("Loaded %d ETC meisai records (%s ~ %s)", len(records), startDate, endDate)
+	return records, nil
+}
+
+// mappedRowIDs ETC明細のハッシュ → マッピングされた運行データID
+//
+// マッピングのクライアントがない場合は空です。最大 workers 件を同時に取得します。
+func (e *ETCSource) mappedRowIDs(ctx context.Context, records []*dbpb.Db_ETCMeisai) (map[string][]string, error) {
+	mapped := make(map[string][]string)
+	if e.mapping == nil {
+		return mapped, nil
+	}
+
+	hashes := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.Hash != "" {
+			hashes = append(hashes, record.Hash)
+		}
+	}
+	return fanOut(ctx, hashes, e.workers, func(ctx context.Context, hash string) ([]string, error) {
+		resp, err := e.mapping.GetDTakoRowIDByHash(ctx, &dbpb.Db_GetDTakoRowIDByHashRequest{EtcMeisaiHash: hash})
+		if err != nil {
+			return nil, err
+		}
+		return resp.DtakoRowIds, nil
+	})
+}
+
+// loadCards ETCカード番号マスタを全件取得してカード番号でインデックス化
+//
+// ETCカード番号マスタのクライアントがない場合は空です。
+func (e *ETCSource) loadCards(ctx context.Context) (map[string][]*dbpb.Db_ETCNum, error) {
+	cards := make(map[string][]*dbpb.Db_ETCNum)
+	if e.cards == nil {
+		return cards, nil
+	}
+
+	req := &dbpb.Db_ListETCNumRequest{
+		Limit:  1000,
+		Offset: 0,
+	}
+	for {
+		resp, err := e.cards.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, card := range resp.Items {
+			cards[card.EtcCardNum] = append(cards[card.EtcCardNum], card)
+		}
+
+		if len(resp.Items) < int(req.Limit) {
+			break
+		}
+		req.Offset += req.Limit
+	}
+
+	log.Printf("Loaded %d ETC cards", len(cards))
+	return cards, nil
+}
+
+// etcCardValidAt ETCカードの割り当てが利用時点で有効か（開始・終了日時が未設定の場合は無期限）
+func etcCardValidAt(card *dbpb.Db_ETCNum, t time.Time) bool {
+	if card.StartDateTime != nil && *card.StartDateTime != "" {
+		if from, err := time.Parse(time.RFC3339, *card.StartDateTime); err == nil && t.Before(from) {
+			return false
+		}
+	}
+	if card.DueDateTime != nil && *card.DueDateTime != "" {
+		if due, err := time.Parse(time.RFC3339, *card.DueDateTime); err == nil && t.After(due) {
+			return false
+		}
+	}
+	return true
+}
+
+// newETCUsage ETC明細の利用時間帯
+//
+// 出口の日時（date_to）がない場合は利用日（date_to_date）の終日とします。
+// 入口の日時（date_fr）がある場合は入口〜出口、ない場合は出口の時刻のみです。
+func newETCUsage(record *dbpb.Db_ETCMeisai) (etcUsage, bool) {
+	usage := etcUsage{record: record}
+
+	to, ok := parseETCTime(record.DateTo)
+	if ok {
+		usage.from, usage.to = to, to
+	} else {
+		day, err := parseBusinessDate(record.DateToDate)
+		if err != nil {
+			return usage, false
+		}
+		usage.from, usage.to = day, endOfBusinessDay(day)
+	}
+
+	if record.DateFr != nil {
+		if from, ok := parseETCTime(*record.DateFr); ok && from.Before(usage.from) {
+			usage.from = from
+		}
+	}
+	return usage, true
+}
+
+// parseETCTime ETC明細の日時をパース（RFC3339、またはタイムゾーンなしの日時）
+func parseETCTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(BusinessLocation()), true
+	}
+	for _, layout := range etcTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, BusinessLocation()); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// newTripWindow 運行の出庫〜帰庫（日時がパースできない場合は false）
+func newTripWindow(row *dbpb.Db_DTakoRows) (*tripWindow, bool) {
+	from, err := time.Parse(time.RFC3339, row.DepartureDatetime)
+	if err != nil {
+		return nil, false
+	}
+	to, err := time.Parse(time.RFC3339, row.ReturnDatetime)
+	if err != nil || to.Before(from) {
+		to = from
+	}
+	return &tripWindow{row: row, from: from, to: to}, true
+}
+
+// matchTrip 利用時間帯に最も近い運行（出庫前・帰庫後 window 以内、該当なしは nil）
+//
+// 利用時間帯と重なる運行を優先し、同じ近さの場合は出庫の早い運行を選びます。
+func matchTrip(trips []*tripWindow, usage etcUsage, window time.Duration) *dbpb.Db_DTakoRows {
+	var best *tripWindow
+	var bestGap time.Duration
+	for _, trip := range trips {
+		gap := intervalGap(trip.from, trip.to, usage.from, usage.to)
+		if gap > window {
+			continue
+		}
+		if best == nil || gap < bestGap || (gap == bestGap && trip.from.Before(best.from)) {
+			best, bestGap = trip, gap
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.row
+}
+
+// intervalGap 2つの時間帯の間隔（重なる場合は0）
+func intervalGap(aFrom, aTo, bFrom, bTo time.Time) time.Duration {
+	switch {
+	case bTo.Before(aFrom):
+		return aFrom.Sub(bTo)
+	case bFrom.After(aTo):
+		return bFrom.Sub(aTo)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeETCMeisaiClient リクエストを記録し、total 件のETC明細をページングして返す
+type fakeETCMeisaiClient struct {
+	dbpb.Db_ETCMeisaiServiceClient
+	total    int
+	requests []*dbpb.Db_ListETCMeisaiRequest
+}
+
+// List リクエストを記録して offset〜limit のETC明細を返す
+func (c *fakeETCMeisaiClient) List(ctx context.Context, req *dbpb.Db_ListETCMeisaiRequest, opts ...grpc.CallOption) (*dbpb.Db_ListETCMeisaiResponse, error) {
+	c.requests = append(c.requests, proto.Clone(req).(*dbpb.Db_ListETCMeisaiRequest))
+
+	resp := &dbpb.Db_ListETCMeisaiResponse{}
+	for i := int(req.Offset); i < c.total && i < int(req.Offset+req.Limit); i++ {
+		resp.Items = append(resp.Items, &dbpb.Db_ETCMeisai{})
+	}
+	return resp, nil
+}
+
+// TestETCListRecordsSendsRFC3339Range ETC明細の日付フィルタは db_service がパースできる RFC3339 で送る
+func TestETCListRecordsSendsRFC3339Range(t *testing.T) {
+	loc := withBusinessTimezone(t, "Asia/Tokyo")
+
+	client := &fakeETCMeisaiClient{total: 1005}
+	source := NewETCSource(&DBClients{ETCMeisai: client}, 1)
+
+	start, end, err := parseDateRange("2024-01-01", "2024-01-31")
+	if err != nil {
+		t.Fatalf("parseDateRange: %v", err)
+	}
+	records, err := source.listRecords(context.Background(), start.AddDate(0, 0, -1), end.AddDate(0, 0, etcTripSpanDays))
+	if err != nil {
+		t.Fatalf("listRecords: %v", err)
+	}
+	if len(records) != client.total {
+		t.Errorf("records = %d, want %d", len(records), client.total)
+	}
+	if len(client.requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(client.requests))
+	}
+
+	wantStart := time.Date(2023, 12, 31, 0, 0, 0, 0, loc)
+	wantEnd := time.Date(2024, 2, 3, 23, 59, 59, 0, loc)
+	for i, req := range client.requests {
+		if req.StartDate == nil || req.EndDate == nil {
+			t.Fatalf("request %d: StartDate/EndDate not set", i)
+		}
+		gotStart, err := time.Parse(time.RFC3339, *req.StartDate)
+		if err != nil {
+			t.Errorf("request %d: StartDate %q is not RFC3339: %v", i, *req.StartDate, err)
+		} else if !gotStart.Equal(wantStart) {
+			t.Errorf("request %d: StartDate = %s, want %s", i, gotStart, wantStart)
+		}
+		gotEnd, err := time.Parse(time.RFC3339, *req.EndDate)
+		if err != nil {
+			t.Errorf("request %d: EndDate %q is not RFC3339: %v", i, *req.EndDate, err)
+		} else if !gotEnd.Equal(wantEnd) {
+			t.Errorf("request %d: EndDate = %s, want %s", i, gotEnd, wantEnd)
+		}
+		if want := int32(i) * req.Limit; req.Offset != want {
+			t.Errorf("request %d: Offset = %d, want %d", i, req.Offset, want)
+		}
+	}
+}
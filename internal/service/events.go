@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EventCategory イベントの分類
+type EventCategory string
+
+const (
+	EventCategoryIdling   EventCategory = "idling"   // アイドリング
+	EventCategoryRest     EventCategory = "rest"     // 休憩・休息
+	EventCategoryLoading  EventCategory = "loading"  // 積込・荷卸
+	EventCategorySpeeding EventCategory = "speeding" // 速度超過
+	EventCategoryOther    EventCategory = "other"    // その他
+)
+
+// eventCategoryOrder 分類の並び順
+var eventCategoryOrder = []EventCategory{
+	EventCategoryIdling,
+	EventCategoryRest,
+	EventCategoryLoading,
+	EventCategorySpeeding,
+	EventCategoryOther,
+}
+
+// eventCategoryKeywords イベント名に含まれる語による分類（先に一致したものを採用）
+var eventCategoryKeywords = []struct {
+	category EventCategory
+	keywords []string
+}{
+	{EventCategoryIdling, []string{"アイドリング"}},
+	{EventCategorySpeeding, []string{"速度オーバー", "速度超過", "オーバースピード"}},
+	{EventCategoryRest, []string{"休憩", "休息", "仮眠"}},
+	{EventCategoryLoading, []string{"積込", "積み", "荷卸", "荷降", "荷下", "降し", "卸し", "荷役"}},
+}
+
+// classifyEvent イベント名から分類を決定
+func classifyEvent(eventName string) EventCategory {
+	for _, entry := range eventCategoryKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(eventName, keyword) {
+				return entry.category
+			}
+		}
+	}
+	return EventCategoryOther
+}
+
+// EventSource イベントデータ（DTakoEvents）の取得元
+//
+// db_service のイベントデータは期間で絞り込めないため、運行NOごとに取得します。
+type EventSource struct {
+	client  dbpb.Db_DTakoEventsServiceClient
+	workers int // 運行NOごとの並列取得数
+}
+
+// NewEventSource イベントデータの取得元を作成（client が nil の場合は nil）
+func NewEventSource(client dbpb.Db_DTakoEventsServiceClient, workers int) *EventSource {
+	if client == nil {
+		return nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return &EventSource{client: client, workers: workers}
+}
+
+// Available イベントデータを参照できるか
+func (e *EventSource) Available() bool {
+	return e != nil && e.client != nil
+}
+
+// ByOperation 運行NOのイベントを開始日時順に取得
+func (e *EventSource) ByOperation(ctx context.Context, operationNo string) ([]*dbpb.Db_DTakoEvents, error) {
+	resp, err := e.client.GetByOperationNo(ctx, &dbpb.Db_GetDTakoEventsByOperationNoRequest{OperationNo: operationNo})
+	if err != nil {
+		return nil, err
+	}
+
+	events := resp.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].StartDatetime != events[j].StartDatetime {
+			return events[i].StartDatetime < events[j].StartDatetime
+		}
+		return events[i].Id < events[j].Id
+	})
+	return events, nil
+}
+
+// ByOperations 複数の運行NOのイベントを並列に取得（イベントのない運行NOは含まない）
+func (e *EventSource) ByOperations(ctx context.Context, operationNos []string) (map[string][]*dbpb.Db_DTakoEvents, error) {
+	return fanOut(ctx, operationNos, e.workers, e.ByOperation)
+}
+
+// EventCodeTotals イベントCDごとの回数・時間の合計
+type EventCodeTotals struct {
+	EventCode   int32 // イベントCD（未設定の場合は-1）
+	EventName   string
+	Category    EventCategory
+	Count       int32
+	Duration    time.Duration // 開始日時〜終了日時の合計
+	SectionTime int64         // 区間時間の合計（記録値のまま）
+	Distance    float64       // 区間距離の合計 (km)
+}
+
+// add イベントを加算
+func (t *EventCodeTotals) add(o *EventCodeTotals) {
+	t.Count += o.Count
+	t.Duration += o.Duration
+	t.SectionTime += o.SectionTime
+	t.Distance += o.Distance
+}
+
+// EventCategoryTotals 分類ごとの回数・時間の合計
+type EventCategoryTotals struct {
+	Category EventCategory
+	Count    int32
+	Duration time.Duration
+	Distance float64
+}
+
+// EventBreakdown イベントCD別の集計
+type EventBreakdown struct {
+	TripCount int32 // 運行回数（イベントのない運行を含む）
+	codes     map[int32]*EventCodeTotals
+}
+
+// newEventBreakdown 空のイベント集計を作成
+func newEventBreakdown() *EventBreakdown {
+	return &EventBreakdown{codes: make(map[int32]*EventCodeTotals)}
+}
+
+// addEvent イベントを加算
+func (b *EventBreakdown) addEvent(event *dbpb.Db_DTakoEvents) {
+	code := int32(-1)
+	if event.EventCode != nil {
+		code = *event.EventCode
+	}
+	b.addCode(&EventCodeTotals{
+		EventCode:   code,
+		EventName:   event.EventName,
+		Category:    classifyEvent(event.EventName),
+		Count:       1,
+		Duration:    eventDuration(event),
+		SectionTime: int64(event.SectionTime),
+		Distance:    event.SectionDistance,
+	})
+}
+
+// addCode イベントCDの合計を加算（名称・分類は最初に出現したものを採用）
+func (b *EventBreakdown) addCode(totals *EventCodeTotals) {
+	existing, exists := b.codes[totals.EventCode]
+	if !exists {
+		existing = &EventCodeTotals{EventCode: totals.EventCode, EventName: totals.EventName, Category: totals.Category}
+		b.codes[totals.EventCode] = existing
+	}
+	existing.add(totals)
+}
+
+// merge 他の集計を加算
+func (b *EventBreakdown) merge(o *EventBreakdown) {
+	b.TripCount += o.TripCount
+	for _, totals := range o.codes {
+		b.addCode(totals)
+	}
+}
+
+// EventCount イベントの件数
+func (b *EventBreakdown) EventCount() int32 {
+	var count int32
+	for _, totals := range b.codes {
+		count += totals.Count
+	}
+	return count
+}
+
+// Codes イベントCD順の合計
+func (b *EventBreakdown) Codes() []*EventCodeTotals {
+	codes := make([]*EventCodeTotals, 0, len(b.codes))
+	for _, totals := range b.codes {
+		codes = append(codes, totals)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].EventCode < codes[j].EventCode
+	})
+	return codes
+}
+
+// Categories 分類ごとの合計（イベントのある分類のみ、分類の並び順）
+func (b *EventBreakdown) Categories() []*EventCategoryTotals {
+	byCategory := make(map[EventCategory]*EventCategoryTotals)
+	for _, totals := range b.codes {
+		category, exists := byCategory[totals.Category]
+		if !exists {
+			category = &EventCategoryTotals{Category: totals.Category}
+			byCategory[totals.Category] = category
+		}
+		category.Count += totals.Count
+		category.Duration += totals.Duration
+		category.Distance += totals.Distance
+	}
+
+	categories := make([]*EventCategoryTotals, 0, len(byCategory))
+	for _, category := range eventCategoryOrder {
+		if totals, exists := byCategory[category]; exists {
+			categories = append(categories, totals)
+		}
+	}
+	return categories
+}
+
+// CategoryDuration 分類の時間の合計
+func (b *EventBreakdown) CategoryDuration(category EventCategory) time.Duration {
+	var duration time.Duration
+	for _, totals := range b.codes {
+		if totals.Category == category {
+			duration += totals.Duration
+		}
+	}
+	return duration
+}
+
+// eventDuration イベントの開始日時〜終了日時（パースできない・逆転している場合は0）
+func eventDuration(event *dbpb.Db_DTakoEvents) time.Duration {
+	start, err := time.Parse(time.RFC3339, event.StartDatetime)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse(time.RFC3339, event.EndDatetime)
+	if err != nil || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// eventDriverCode イベントの乗務員CD（対象乗務員CD、未設定の場合は乗務員CD1）
+func eventDriverCode(event *dbpb.Db_DTakoEvents) int32 {
+	if event.TargetDriverCode != 0 {
+		return event.TargetDriverCode
+	}
+	return event.DriverCode1
+}
+
+// TripEventSummary 運行ごとのイベント集計
+type TripEventSummary struct {
+	OperationNo string
+	CarCC       string
+	DriverCodes []int32   // イベントの乗務員CD（昇順）
+	FirstStart  time.Time // 最初のイベントの開始日時（イベントがない場合はゼロ値）
+	LastEnd     time.Time // 最後のイベントの終了日時（イベントがない場合はゼロ値）
+	Breakdown   *EventBreakdown
+	Available   bool // イベントデータを参照できたか
+}
+
+// Span 最初のイベントの開始から最後のイベントの終了までの時間
+func (t *TripEventSummary) Span() time.Duration {
+	if t.FirstStart.IsZero() || t.LastEnd.Before(t.FirstStart) {
+		return 0
+	}
+	return t.LastEnd.Sub(t.FirstStart)
+}
+
+// IdlingRatio 運行時間（Span）に占めるアイドリング時間の割合
+func (t *TripEventSummary) IdlingRatio() float64 {
+	return ratio(t.Breakdown.CategoryDuration(EventCategoryIdling).Seconds(), t.Span().Seconds())
+}
+
+// GetTripEventSummary 運行NOのイベントをイベントCD・分類ごとに集計
+func (s *DtakoRowsService) GetTripEventSummary(ctx context.Context, operationNo string) (*TripEventSummary, error) {
+	log.Printf("GetTripEventSummary: operation_no=%s", operationNo)
+
+	if operationNo == "" {
+		return nil, status.Error(codes.InvalidArgument, "operation_no is required")
+	}
+
+	summary := &TripEventSummary{OperationNo: operationNo, Breakdown: newEventBreakdown(), Available: s.events.Available()}
+	if !summary.Available {
+		log.Printf("Warning: events client is not configured, event summary is not available")
+		return summary, nil
+	}
+
+	events, err := s.events.ByOperation(ctx, operationNo)
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Printf("Failed to get events for operation %s: %v", operationNo, err)
+		return nil, err
+	}
+
+	summary.Breakdown.TripCount = 1
+	drivers := make(map[int32]bool)
+	for _, event := range events {
+		summary.Breakdown.addEvent(event)
+		if summary.CarCC == "" {
+			summary.CarCC = event.CarCc
+		}
+		if code := eventDriverCode(event); code != 0 && !drivers[code] {
+			drivers[code] = true
+			summary.DriverCodes = append(summary.DriverCodes, code)
+		}
+		if start, err := time.Parse(time.RFC3339, event.StartDatetime); err == nil && (summary.FirstStart.IsZero() || start.Before(summary.FirstStart)) {
+			summary.FirstStart = start
+		}
+		if end, err := time.Parse(time.RFC3339, event.EndDatetime); err == nil && end.After(summary.LastEnd) {
+			summary.LastEnd = end
+		}
+	}
+	sort.Slice(summary.DriverCodes, func(i, j int) bool {
+		return summary.DriverCodes[i] < summary.DriverCodes[j]
+	})
+
+	log.Printf("Aggregated %d events for operation %s", len(events), operationNo)
+	return summary, nil
+}
+
+// EventPeriodSummary 車両の集計期間ごとのイベント集計
+type EventPeriodSummary struct {
+	CarCC     string // 車輌CC（全車両の合計の場合は空）
+	Period    string // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket    Bucket // 集計期間（期間全体の合計の場合はゼロ値）
+	Breakdown *EventBreakdown
+}
+
+// VehicleEvents 1車両分の期間ごとのイベント集計と期間全体の合計
+type VehicleEvents struct {
+	CarCC     string
+	Summaries []*EventPeriodSummary // 期間順
+	Total     *EventPeriodSummary
+}
+
+// DriverEvents 乗務員ごとのイベント集計（期間全体）
+type DriverEvents struct {
+	DriverCode int32
+	Breakdown  *EventBreakdown
+}
+
+// EventSummary イベントの期間集計結果
+type EventSummary struct {
+	Vehicles  []*VehicleEvents      // 車輌CC順
+	Drivers   []*DriverEvents       // 乗務員CD順
+	Periods   []*EventPeriodSummary // 全車両の期間ごとの合計（期間順）
+	Total     *EventPeriodSummary   // 全車両の期間全体の合計
+	Available bool                  // イベントデータを参照できたか
+}
+
+// GetEventSummary 車両・乗務員・集計期間ごとのイベント（アイドリング・休憩・荷役・速度超過など）を集計
+//
+// 期間内の運行データの運行NOごとにイベントを取得し、運行日の期間に計上します。
+// 同じ運行NOの行が複数ある場合もイベントは1回だけ計上します。carCC が空の場合は全車両を集計します。
+func (s *DtakoRowsService) GetEventSummary(ctx context.Context, carCC, startDate, endDate string, bucketing Bucketing) (*EventSummary, error) {
+	log.Printf("GetEventSummary: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	result := &EventSummary{Total: &EventPeriodSummary{Breakdown: newEventBreakdown()}, Available: s.events.Available()}
+	if !result.Available {
+		log.Printf("Warning: events client is not configured, event summary is not available")
+		return result, nil
+	}
+
+	var allRows []*dbpb.Db_DTakoRows
+	if carCC != "" {
+		allRows, err = s.ListByCarCCAndDateRange(ctx, carCC, startDate, endDate, 0)
+	} else {
+		allRows, err = s.ListByDateRange(ctx, startDate, endDate, 0)
+	}
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, err
+	}
+
+	// 運行NOごとに最初の行の車両・運行日に計上する
+	type trip struct {
+		carCC  string
+		bucket Bucket
+	}
+	trips := make(map[string]trip)
+	var operationNos []string
+	for _, row := range allRows {
+		if row.OperationNo == "" {
+			continue
+		}
+		if _, exists := trips[row.OperationNo]; exists {
+			continue
+		}
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			continue
+		}
+		trips[row.OperationNo] = trip{carCC: row.CarCc, bucket: periods.Bucket(opDate)}
+		operationNos = append(operationNos, row.OperationNo)
+	}
+	sort.Strings(operationNos)
+
+	byOperation, err := s.events.ByOperations(ctx, operationNos)
+	if err != nil {
+		log.Printf("Failed to get events: %v", err)
+		return nil, err
+	}
+
+	rollup := newPeriodRollup(result.Total, func(carCC string, bucket Bucket) *EventPeriodSummary {
+		return &EventPeriodSummary{CarCC: carCC, Period: bucket.Key, Bucket: bucket, Breakdown: newEventBreakdown()}
+	})
+	drivers := make(map[int32]*DriverEvents)
+
+	for _, operationNo := range operationNos {
+		t := trips[operationNo]
+
+		tripBreakdown := newEventBreakdown()
+		tripBreakdown.TripCount = 1
+		driverBreakdowns := make(map[int32]*EventBreakdown)
+		for _, event := range byOperation[operationNo] {
+			tripBreakdown.addEvent(event)
+
+			code := eventDriverCode(event)
+			if code == 0 {
+				continue
+			}
+			if driverBreakdowns[code] == nil {
+				driverBreakdowns[code] = newEventBreakdown()
+				driverBreakdowns[code].TripCount = 1
+			}
+			driverBreakdowns[code].addEvent(event)
+		}
+
+		rollup.add(t.carCC, t.bucket, func(summary *EventPeriodSummary) {
+			summary.Breakdown.merge(tripBreakdown)
+		})
+
+		for code, breakdown := range driverBreakdowns {
+			driver, exists := drivers[code]
+			if !exists {
+				driver = &DriverEvents{DriverCode: code, Breakdown: newEventBreakdown()}
+				drivers[code] = driver
+			}
+			driver.Breakdown.merge(breakdown)
+		}
+	}
+
+	for _, carCC := range rollup.carCCs() {
+		result.Vehicles = append(result.Vehicles, &VehicleEvents{
+			CarCC:     carCC,
+			Summaries: rollup.vehicleSummaries(carCC),
+			Total:     rollup.vehicleTotal(carCC),
+		})
+	}
+	for _, driver := range drivers {
+		result.Drivers = append(result.Drivers, driver)
+	}
+	sort.Slice(result.Drivers, func(i, j int) bool {
+		return result.Drivers[i].DriverCode < result.Drivers[j].DriverCode
+	})
+	result.Periods = rollup.periods()
+
+	log.Printf("Aggregated %d events for %d operations, %d vehicles", result.Total.Breakdown.EventCount(), len(operationNos), len(result.Vehicles))
+	return result, nil
+}
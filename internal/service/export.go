@@ -0,0 +1,196 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/yhonda-ohishi/dtako_rows/v3/internal/export"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// monthlyFuelColumns 月次給油量サマリーの出力列（CSV・Excel共通）
+var monthlyFuelColumns = []export.Column[*MonthlyFuelSummary]{
+	{Key: "year_month", Title: "年月", Width: 10, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Text(s.YearMonth)
+	}},
+	{Key: "period_label", Title: "集計期間", Width: 36, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Text(s.Bucket.Label)
+	}},
+	{Key: "car_cc", Title: "車両CC", Width: 10, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Text(s.CarCC)
+	}},
+	{Key: "total_distance", Title: "走行距離(km)", Width: 14, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(s.TotalDistance, export.FormatKilometer)
+	}},
+	{Key: "ferry_distance", Title: "フェリー見なし距離(km)", Width: 16, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(s.FerryDistance, export.FormatKilometer)
+	}},
+	{Key: "distance_with_ferry", Title: "フェリー込み距離(km)", Width: 16, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(s.DistanceWithFerry(), export.FormatKilometer)
+	}},
+	{Key: "total_fuel", Title: "給油量(L)", Width: 14, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(s.TotalFuel, export.FormatLiter)
+	}},
+	{Key: "fuel_basis", Title: "給油量区分", Width: 10, Value: func(s *MonthlyFuelSummary) export.Cell {
+		if s.FuelBasis == "" {
+			return export.Text("")
+		}
+		return export.Text(fuelBasisLabel(s.FuelBasis))
+	}},
+	{Key: "measured_fuel", Title: "実給油量(L)", Width: 14, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(s.MeasuredFuel, export.FormatLiter)
+	}},
+	{Key: "estimated_fuel", Title: "推定給油量(L)", Width: 14, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(s.EstimatedFuel, export.FormatLiter)
+	}},
+	{Key: "trip_count", Title: "運行回数", Width: 10, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Int(int64(s.TripCount))
+	}},
+	{Key: "avg_fuel_efficiency", Title: "平均燃費(km/L)", Width: 16, Value: func(s *MonthlyFuelSummary) export.Cell {
+		return export.Number(averageFuelEfficiency(s.TotalDistance, s.TotalFuel), export.FormatKmPerLiter)
+	}},
+	{Key: "fuel_efficiency", Title: "推定燃費(km/L)", Width: 16, Value: func(s *MonthlyFuelSummary) export.Cell {
+		if s.FuelEfficiency <= 0 {
+			return export.Text("")
+		}
+		return export.Number(s.FuelEfficiency, export.FormatKmPerLiter)
+	}},
+}
+
+// 既定の出力列（リクエストで columns を省略した場合）
+var (
+	// CSVは従来の列の順序を変えず、給油量区分を末尾に追加する
+	defaultMonthlyFuelCSVColumns   = []string{"year_month", "car_cc", "total_distance", "total_fuel", "trip_count", "avg_fuel_efficiency", "fuel_basis"}
+	defaultMonthlyFuelSheetColumns = []string{"year_month", "total_distance", "total_fuel", "fuel_basis", "measured_fuel", "estimated_fuel", "avg_fuel_efficiency", "trip_count"}
+)
+
+// fleetSummarySheetTitles 集計シートの見出し
+var fleetSummarySheetTitles = []string{
+	"車両CC", "走行距離(km)", "給油量(L)", "実給油量(L)", "推定給油量(L)", "平均燃費(km/L)", "運行回数", "実績月数", "推定月数", "混在月数",
+}
+
+// ExportFormat エクスポートの出力形式（リクエストの ExportOptions を解釈したもの）
+type ExportFormat struct {
+	Encoding export.Encoding // CSVの文字コード
+	Columns  []string        // 出力する列のキー（空の場合は既定の列）
+}
+
+// NewExportFormat 文字コード名と列のキーから出力形式を作成
+func NewExportFormat(encoding string, columns []string) (ExportFormat, error) {
+	enc, err := export.ParseEncoding(encoding)
+	if err != nil {
+		return ExportFormat{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return ExportFormat{Encoding: enc, Columns: columns}, nil
+}
+
+// monthlyFuelColumnsFor 出力する列を選択（未指定の場合は defaults）
+func (f ExportFormat) monthlyFuelColumnsFor(defaults []string) ([]export.Column[*MonthlyFuelSummary], error) {
+	keys := f.Columns
+	if len(keys) == 0 {
+		keys = defaults
+	}
+	columns, err := export.SelectColumns(monthlyFuelColumns, keys)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return columns, nil
+}
+
+// EncodeMonthlyFuelCSV 月次給油量サマリーをCSVに変換
+func EncodeMonthlyFuelCSV(summaries []*MonthlyFuelSummary, format ExportFormat) ([]byte, error) {
+	columns, err := format.monthlyFuelColumnsFor(defaultMonthlyFuelCSVColumns)
+	if err != nil {
+		return nil, err
+	}
+	return export.EncodeCSV(columns, summaries, format.Encoding)
+}
+
+// BuildMonthlyFuelWorkbook 月次給油量サマリーのExcelブックを作成
+//
+// 先頭に車両ごとの合計と全体合計を並べた「集計」シート、続いて車輌CC順に
+// 車両ごとの月次シートを作成します。数値はすべて数値セルとして出力します。
+// 列の選択（format.Columns）は車両ごとのシートに適用されます。
+func BuildMonthlyFuelWorkbook(vehicles map[string][]*MonthlyFuelSummary, format ExportFormat) (*export.Workbook, error) {
+	columns, err := format.monthlyFuelColumnsFor(defaultMonthlyFuelSheetColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	carCCs := make([]string, 0, len(vehicles))
+	for carCC := range vehicles {
+		carCCs = append(carCCs, carCC)
+	}
+	sort.Strings(carCCs)
+
+	workbook := export.NewWorkbook()
+
+	summarySheet := workbook.AddSheet("集計")
+	summarySheet.AddHeader(fleetSummarySheetTitles...)
+	summarySheet.SetColumnWidths(12, 14, 14, 14, 14, 16, 10, 10, 10, 10)
+
+	var fleet SummaryTotals
+	for _, carCC := range carCCs {
+		summaries := vehicles[carCC]
+
+		sheet := workbook.AddSheet(carCC)
+		export.AddTable(sheet, columns, summaries)
+
+		var vehicle SummaryTotals
+		for _, s := range summaries {
+			vehicle.add(s)
+			fleet.add(s)
+		}
+		export.AddTableRow(sheet, columns, totalsSummary(vehicle, carCC, "合計"), true)
+
+		summarySheet.AddRow(totalsRow(vehicle, export.Text(carCC), false)...)
+	}
+	summarySheet.AddRow(totalsRow(fleet, export.Text("合計"), true)...)
+
+	return workbook, nil
+}
+
+// totalsSummary 合計行として出力するためのサマリー（給油量区分・燃費は空）
+func totalsSummary(t SummaryTotals, carCC, label string) *MonthlyFuelSummary {
+	return &MonthlyFuelSummary{
+		CarCC:         carCC,
+		YearMonth:     label,
+		TotalDistance: t.TotalDistance,
+		TotalFuel:     t.TotalFuel,
+		MeasuredFuel:  t.MeasuredFuel,
+		EstimatedFuel: t.EstimatedFuel,
+		FerryDistance: t.FerryDistance,
+		TripCount:     t.TripCount,
+		RefuelCount:   t.RefuelCount,
+	}
+}
+
+// totalsRow 集計シートの1行（見出しセル + 合計）
+func totalsRow(t SummaryTotals, label export.Cell, bold bool) []export.Cell {
+	cells := []export.Cell{
+		label,
+		export.Number(t.TotalDistance, export.FormatKilometer),
+		export.Number(t.TotalFuel, export.FormatLiter),
+		export.Number(t.MeasuredFuel, export.FormatLiter),
+		export.Number(t.EstimatedFuel, export.FormatLiter),
+		export.Number(t.AvgFuelEfficiency(), export.FormatKmPerLiter),
+		export.Int(int64(t.TripCount)),
+		export.Int(int64(t.MeasuredPeriods)),
+		export.Int(int64(t.EstimatedPeriods)),
+		export.Int(int64(t.MixedPeriods)),
+	}
+	if bold {
+		for i := range cells {
+			cells[i] = cells[i].Bold()
+		}
+	}
+	return cells
+}
+
+// averageFuelEfficiency 平均燃費 (km/L)（給油量が0の場合は0）
+func averageFuelEfficiency(distance, fuel float64) float64 {
+	if fuel <= 0 {
+		return 0
+	}
+	return distance / fuel
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fanOut キーごとの取得を最大 workers 件まで並列に実行し、キー → 結果を返す
+//
+// 同じキーは1回だけ取得します。NotFound のキーは結果に含めません。
+// それ以外のエラーが発生した場合は残りの取得をキャンセルし、最初のエラーを返します。
+func fanOut[V any](ctx context.Context, keys []string, workers int, fetch func(ctx context.Context, key string) (V, error)) (map[string]V, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	results := make(map[string]V, len(keys))
+	started := make(map[string]bool, len(keys))
+	slots := make(chan struct{}, workers)
+
+launch:
+	for _, key := range keys {
+		if started[key] {
+			continue
+		}
+		started[key] = true
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			value, err := fetch(ctx, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return
+				}
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			results[key] = value
+		}(key)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		// 呼び出し元のキャンセル
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// フェリー運行データのキャッシュの有効期間
+const (
+	ferryIndexTTL           = 10 * time.Minute
+	ferryIndexRetryInterval = 1 * time.Minute // 取得失敗後、再取得を試みるまでの間隔
+)
+
+// FerrySource フェリー運行データ（DTakoFerryRowsProd）の運行NO別キャッシュ
+//
+// db_service は運行NO以外の条件で絞り込めないため、全件を取得して運行NOでインデックス化します。
+// client が nil の場合は常にフェリー利用なしの扱いです。
+type FerrySource struct {
+	client dbpb.Db_DTakoFerryRowsProdServiceClient
+
+	mu          sync.Mutex
+	byOperation map[string][]*dbpb.Db_DTakoFerryRowsProd // 運行NO → フェリー乗船
+	loadedAt    time.Time
+	failedAt    time.Time // 最後に取得に失敗した日時
+	lastErr     error     // 最後の取得失敗のエラー（キャッシュがない間に返す）
+}
+
+// NewFerrySource フェリー運行データのキャッシュを作成
+func NewFerrySource(client dbpb.Db_DTakoFerryRowsProdServiceClient) *FerrySource {
+	return &FerrySource{client: client}
+}
+
+// Available フェリー運行データを参照できるか
+func (f *FerrySource) Available() bool {
+	return f != nil && f.client != nil
+}
+
+// Index 運行NO → フェリー乗船（キャッシュ付き）
+//
+// 取得に失敗した場合、前回のキャッシュがあればそれを返します。失敗後1分間は再取得しません。
+func (f *FerrySource) Index(ctx context.Context) (map[string][]*dbpb.Db_DTakoFerryRowsProd, error) {
+	if !f.Available() {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// 取得失敗後は ferryIndexRetryInterval の間、再取得せずに前回のキャッシュを使い続ける
+	if (f.byOperation == nil || time.Since(f.loadedAt) > ferryIndexTTL) && time.Since(f.failedAt) > ferryIndexRetryInterval {
+		byOperation, err := f.load(ctx)
+		if err != nil {
+			f.failedAt = time.Now()
+			f.lastErr = err
+			if f.byOperation != nil {
+				log.Printf("Warning: failed to reload ferry rows, using cached data: %v", err)
+			}
+		} else {
+			f.byOperation = byOperation
+			f.loadedAt = time.Now()
+		}
+	}
+	if f.byOperation == nil {
+		return nil, f.lastErr
+	}
+	return f.byOperation, nil
+}
+
+// load フェリー運行データを全件取得して運行NOでインデックス化
+func (f *FerrySource) load(ctx context.Context) (map[string][]*dbpb.Db_DTakoFerryRowsProd, error) {
+	req := &dbpb.Db_ListDTakoFerryRowsProdRequest{
+		Limit:  1000,
+		Offset: 0,
+	}
+
+	byOperation := make(map[string][]*dbpb.Db_DTakoFerryRowsProd)
+	total := 0
+	for {
+		resp, err := f.client.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ferry := range resp.Items {
+			if ferry.UnkoNo != "" {
+				byOperation[ferry.UnkoNo] = append(byOperation[ferry.UnkoNo], ferry)
+				total++
+			}
+		}
+
+		if len(resp.Items) < int(req.Limit) {
+			break
+		}
+		req.Offset += req.Limit
+	}
+
+	log.Printf("Loaded %d ferry rows for %d operations", total, len(byOperation))
+	return byOperation, nil
+}
+
+// ferryTally 集計中のフェリー乗船の計上（同じ運行NOの行が複数あっても1回だけ計上する）
+type ferryTally struct {
+	byOperation map[string][]*dbpb.Db_DTakoFerryRowsProd
+	counted     map[string]bool
+}
+
+// newFerryTally フェリー運行データを取得して計上を開始
+//
+// 取得できない場合は警告を出し、フェリー利用なしとして集計します。
+func (s *DtakoRowsService) newFerryTally(ctx context.Context) *ferryTally {
+	byOperation, err := s.ferries.Index(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to load ferry rows, ferry distances are not included: %v", err)
+	}
+	return &ferryTally{byOperation: byOperation, counted: make(map[string]bool)}
+}
+
+// take 運行NOのフェリー乗船（計上済みの運行NOは nil）
+func (t *ferryTally) take(operationNo string) []*dbpb.Db_DTakoFerryRowsProd {
+	if operationNo == "" || t.counted[operationNo] {
+		return nil
+	}
+	t.counted[operationNo] = true
+	return t.byOperation[operationNo]
+}
+
+// deemedDistance 運行NOのフェリーの見なし距離の合計（計上済みの運行NOは0）
+func (t *ferryTally) deemedDistance(operationNo string) float64 {
+	var distance float64
+	for _, ferry := range t.take(operationNo) {
+		distance += float64(ferry.MinashiKyori)
+	}
+	return distance
+}
+
+// ferryFare フェリー乗船の料金（契約料金、未設定の場合は標準料金）
+func ferryFare(ferry *dbpb.Db_DTakoFerryRowsProd) int64 {
+	if ferry.KeiyakuRyokin > 0 {
+		return int64(ferry.KeiyakuRyokin)
+	}
+	return int64(ferry.HyojunRyokin)
+}
+
+// FerryPeriodSummary 車両の集計期間ごとのフェリー利用
+type FerryPeriodSummary struct {
+	CarCC          string // 車輌CC（全車両の合計の場合は空）
+	Period         string // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket         Bucket // 集計期間（期間全体の合計の場合はゼロ値）
+	Crossings      int32  // 乗船回数
+	FerryCost      int64  // フェリー料金（契約料金、未設定の乗船は標準料金）
+	StandardFare   int64  // 標準料金の合計
+	DeemedDistance float64
+	TotalDistance  float64 // 走行距離（フェリー利用のない運行を含む）
+	TripCount      int32   // 運行回数（フェリー利用のない運行を含む）
+	FerryTripCount int32   // フェリーを利用した運行の回数
+}
+
+// DistanceWithFerry フェリーの見なし距離を含む距離
+func (p *FerryPeriodSummary) DistanceWithFerry() float64 {
+	return p.TotalDistance + p.DeemedDistance
+}
+
+// addTrip 運行とそのフェリー乗船を加算
+func (p *FerryPeriodSummary) addTrip(distance float64, ferries []*dbpb.Db_DTakoFerryRowsProd) {
+	p.TotalDistance += distance
+	p.TripCount++
+	if len(ferries) > 0 {
+		p.FerryTripCount++
+	}
+	for _, ferry := range ferries {
+		p.Crossings++
+		p.FerryCost += ferryFare(ferry)
+		p.StandardFare += int64(ferry.HyojunRyokin)
+		p.DeemedDistance += float64(ferry.MinashiKyori)
+	}
+}
+
+// VehicleFerries 1車両分の期間ごとのフェリー利用と期間全体の合計
+type VehicleFerries struct {
+	CarCC     string
+	Summaries []*FerryPeriodSummary // 期間順
+	Total     *FerryPeriodSummary
+}
+
+// FerrySummary フェリー利用の集計結果
+type FerrySummary struct {
+	Vehicles  []*VehicleFerries     // 車輌CC順
+	Periods   []*FerryPeriodSummary // 全車両の期間ごとの合計（期間順）
+	Total     *FerryPeriodSummary   // 全車両の期間全体の合計
+	Available bool                  // フェリー運行データを参照できたか
+}
+
+// GetFerrySummary 車両・集計期間ごとのフェリー利用（料金・乗船回数・見なし距離）を集計
+//
+// フェリー運行データの運行NO（unko_no）を運行データの運行NOと対応付け、運行日の期間に計上します。
+// 同じ運行NOの行が複数ある場合もフェリー乗船は1回だけ計上します。carCC が空の場合は全車両を集計します。
+func (s *DtakoRowsService) GetFerrySummary(ctx context.Context, carCC, startDate, endDate string, bucketing Bucketing) (*FerrySummary, error) {
+	log.Printf("GetFerrySummary: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	result := &FerrySummary{Total: &FerryPeriodSummary{}, Available: s.ferries.Available()}
+
+	byOperation, err := s.ferries.Index(ctx)
+	if err != nil {
+		log.Printf("Failed to load ferry rows: %v", err)
+		return nil, err
+	}
+	if !result.Available {
+		log.Printf("Warning: ferry rows client is not configured, ferry usage is not available")
+	}
+
+	var allRows []*dbpb.Db_DTakoRows
+	if carCC != "" {
+		allRows, err = s.ListByCarCCAndDateRange(ctx, carCC, startDate, endDate, 0)
+	} else {
+		allRows, err = s.ListByDateRange(ctx, startDate, endDate, 0)
+	}
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, err
+	}
+
+	tally := &ferryTally{byOperation: byOperation, counted: make(map[string]bool)}
+	rollup := newPeriodRollup(result.Total, func(carCC string, bucket Bucket) *FerryPeriodSummary {
+		return &FerryPeriodSummary{CarCC: carCC, Period: bucket.Key, Bucket: bucket}
+	})
+
+	for _, row := range allRows {
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			continue
+		}
+
+		ferries := tally.take(row.OperationNo)
+		rollup.add(row.CarCc, periods.Bucket(opDate), func(summary *FerryPeriodSummary) {
+			summary.addTrip(row.TotalDistance, ferries)
+		})
+	}
+
+	for _, carCC := range rollup.carCCs() {
+		result.Vehicles = append(result.Vehicles, &VehicleFerries{
+			CarCC:     carCC,
+			Summaries: rollup.vehicleSummaries(carCC),
+			Total:     rollup.vehicleTotal(carCC),
+		})
+	}
+	result.Periods = rollup.periods()
+
+	log.Printf("Aggregated %d ferry crossings for %d vehicles", result.Total.Crossings, len(result.Vehicles))
+	return result, nil
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 車両の並べ替え・ランキングの指標
+const (
+	VehicleMetricCarCC          = "car_cc"              // 車輌CC（並べ替えのみ）
+	VehicleMetricDistance       = "total_distance"      // 総走行距離
+	VehicleMetricTrips          = "trip_count"          // 運行回数
+	VehicleMetricFuel           = "total_fuel"          // 総給油量（並べ替えのみ）
+	VehicleMetricFuelEfficiency = "avg_fuel_efficiency" // 平均燃費
+)
+
+// defaultRankingSize ランキングの上位・下位の件数のデフォルト
+const defaultRankingSize = 5
+
+// rankingMetrics ランキングを作成する指標（レスポンスの順）
+var rankingMetrics = []string{VehicleMetricDistance, VehicleMetricTrips, VehicleMetricFuelEfficiency}
+
+// SummaryTotals 期間全体の合計
+type SummaryTotals struct {
+	TotalDistance    float64
+	TotalFuel        float64
+	MeasuredFuel     float64
+	EstimatedFuel    float64
+	TripCount        int32
+	RefuelCount      int32
+	FerryDistance    float64
+	MeasuredPeriods  int32 // 給油量が実績値の期間数（車両・期間ごとのサマリーの数）
+	MixedPeriods     int32 // 給油量が実績値 + 推定値の期間数
+	EstimatedPeriods int32 // 給油量が推定値の期間数
+}
+
+// add 期間ごとのサマリーを加算
+func (t *SummaryTotals) add(s *MonthlyFuelSummary) {
+	t.TotalDistance += s.TotalDistance
+	t.TotalFuel += s.TotalFuel
+	t.MeasuredFuel += s.MeasuredFuel
+	t.EstimatedFuel += s.EstimatedFuel
+	t.TripCount += s.TripCount
+	t.RefuelCount += s.RefuelCount
+	t.FerryDistance += s.FerryDistance
+	switch s.FuelBasis {
+	case FuelBasisMeasured:
+		t.MeasuredPeriods++
+	case FuelBasisMixed:
+		t.MixedPeriods++
+	default:
+		t.EstimatedPeriods++
+	}
+}
+
+// AvgFuelEfficiency 平均燃費 (km/L)（給油量が0の場合は0）
+func (t SummaryTotals) AvgFuelEfficiency() float64 {
+	return averageFuelEfficiency(t.TotalDistance, t.TotalFuel)
+}
+
+// VehicleSummaries 1車両分の期間ごとのサマリーと期間全体の合計
+type VehicleSummaries struct {
+	CarCC     string
+	Summaries []*MonthlyFuelSummary // 期間順
+	Totals    SummaryTotals
+}
+
+// RankedVehicle ランキングの1件
+type RankedVehicle struct {
+	Rank  int32 // 順位（1始まり、同値は同順位）
+	CarCC string
+	Value float64
+}
+
+// VehicleRanking 指標ごとの上位・下位の車両
+type VehicleRanking struct {
+	Metric string           // VehicleMetric*
+	Top    []*RankedVehicle // 値の大きい順
+	Bottom []*RankedVehicle // 値の小さい順
+}
+
+// FleetSummary 全車両のサマリー（車両の並び・全体合計・ランキング）
+type FleetSummary struct {
+	Vehicles []*VehicleSummaries
+	Periods  []*MonthlyFuelSummary // 全車両の期間ごとの合計（期間順、車輌CCは空）
+	Totals   SummaryTotals         // 全車両の期間全体の合計
+	Rankings []*VehicleRanking     // rankingMetrics の順
+}
+
+// FleetOptions 全車両サマリーの並べ替え・ランキングのオプション
+type FleetOptions struct {
+	SortBy      string // 並べ替えの指標（空の場合は車輌CC）
+	Descending  bool   // 降順
+	RankingSize int32  // ランキングの上位・下位の件数（0はデフォルト）
+}
+
+// Validate オプションを検証
+func (o FleetOptions) Validate() error {
+	if o.SortBy != "" && vehicleMetricValue(o.SortBy, SummaryTotals{}) == nil {
+		return status.Errorf(codes.InvalidArgument, "unsupported sort_by %q", o.SortBy)
+	}
+	if o.RankingSize < 0 {
+		return status.Errorf(codes.InvalidArgument, "ranking_size must not be negative: %d", o.RankingSize)
+	}
+	return nil
+}
+
+// BuildFleetSummary 車両ごとのサマリーから全体合計・ランキングを作成し、車両を並べ替える
+//
+// 同じ値の車両は車輌CC順に並べるため、同じリクエストには常に同じ順序で返します。
+// 燃費のランキングは給油量のある車両のみを対象とします。
+func BuildFleetSummary(summariesMap map[string][]*MonthlyFuelSummary, opts FleetOptions) (*FleetSummary, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.SortBy == "" {
+		opts.SortBy = VehicleMetricCarCC
+	}
+	if opts.RankingSize == 0 {
+		opts.RankingSize = defaultRankingSize
+	}
+
+	fleet := &FleetSummary{}
+	periods := make(map[string]*MonthlyFuelSummary)
+	for carCC, summaries := range summariesMap {
+		vehicle := &VehicleSummaries{CarCC: carCC, Summaries: summaries}
+		for _, summary := range summaries {
+			vehicle.Totals.add(summary)
+			fleet.Totals.add(summary)
+
+			period, exists := periods[summary.YearMonth]
+			if !exists {
+				period = &MonthlyFuelSummary{YearMonth: summary.YearMonth, Bucket: summary.Bucket, FuelBasis: summary.FuelBasis}
+				periods[summary.YearMonth] = period
+			}
+			// 給油量区分は、その期間のすべての車両が同じ区分の場合のみその区分、異なる場合は mixed
+			if summary.FuelBasis != period.FuelBasis {
+				period.FuelBasis = FuelBasisMixed
+			}
+			period.TotalDistance += summary.TotalDistance
+			period.TotalFuel += summary.TotalFuel
+			period.MeasuredFuel += summary.MeasuredFuel
+			period.EstimatedFuel += summary.EstimatedFuel
+			period.TripCount += summary.TripCount
+			period.RefuelCount += summary.RefuelCount
+			period.UncoveredDistance += summary.UncoveredDistance
+			period.FerryDistance += summary.FerryDistance
+		}
+		fleet.Vehicles = append(fleet.Vehicles, vehicle)
+	}
+	// 燃費は推定給油量の合計に対する走行距離（各車両の燃費を走行距離で加重した値）
+	for _, period := range periods {
+		period.FuelEfficiency = averageFuelEfficiency(period.TotalDistance, period.EstimatedFuel)
+	}
+	fleet.Periods = sortedSummaries(periods)
+
+	sortVehicles(fleet.Vehicles, opts.SortBy, opts.Descending)
+	for _, metric := range rankingMetrics {
+		fleet.Rankings = append(fleet.Rankings, rankVehicles(fleet.Vehicles, metric, int(opts.RankingSize)))
+	}
+	return fleet, nil
+}
+
+// vehicleMetricValue 指標の値（車輌CCの場合は nil 以外の0、未定義の指標は nil）
+func vehicleMetricValue(metric string, t SummaryTotals) *float64 {
+	var v float64
+	switch metric {
+	case VehicleMetricCarCC:
+	case VehicleMetricDistance:
+		v = t.TotalDistance
+	case VehicleMetricTrips:
+		v = float64(t.TripCount)
+	case VehicleMetricFuel:
+		v = t.TotalFuel
+	case VehicleMetricFuelEfficiency:
+		v = t.AvgFuelEfficiency()
+	default:
+		return nil
+	}
+	return &v
+}
+
+// sortVehicles 指標で並べ替え（同値は車輌CC順）
+func sortVehicles(vehicles []*VehicleSummaries, metric string, descending bool) {
+	sort.SliceStable(vehicles, func(i, j int) bool {
+		a := *vehicleMetricValue(metric, vehicles[i].Totals)
+		b := *vehicleMetricValue(metric, vehicles[j].Totals)
+		if a != b {
+			if descending {
+				return a > b
+			}
+			return a < b
+		}
+		if descending && metric == VehicleMetricCarCC {
+			return vehicles[i].CarCC > vehicles[j].CarCC
+		}
+		return vehicles[i].CarCC < vehicles[j].CarCC
+	})
+}
+
+// rankVehicles 指標の上位・下位 size 件
+func rankVehicles(vehicles []*VehicleSummaries, metric string, size int) *VehicleRanking {
+	candidates := make([]*VehicleSummaries, 0, len(vehicles))
+	for _, v := range vehicles {
+		if metric == VehicleMetricFuelEfficiency && v.Totals.TotalFuel <= 0 {
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+
+	ranking := &VehicleRanking{Metric: metric}
+
+	sortVehicles(candidates, metric, true)
+	ranking.Top = rankedVehicles(candidates, metric, size)
+
+	sortVehicles(candidates, metric, false)
+	ranking.Bottom = rankedVehicles(candidates, metric, size)
+	return ranking
+}
+
+// rankedVehicles 並べ替え済みの車両の先頭 size 件に順位を付ける（同値は同順位）
+func rankedVehicles(sorted []*VehicleSummaries, metric string, size int) []*RankedVehicle {
+	if size > len(sorted) {
+		size = len(sorted)
+	}
+	ranked := make([]*RankedVehicle, size)
+	for i := 0; i < size; i++ {
+		value := *vehicleMetricValue(metric, sorted[i].Totals)
+		rank := int32(i + 1)
+		if i > 0 && value == ranked[i-1].Value {
+			rank = ranked[i-1].Rank
+		}
+		ranked[i] = &RankedVehicle{Rank: rank, CarCC: sorted[i].CarCC, Value: value}
+	}
+	return ranked
+}
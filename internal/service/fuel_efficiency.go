@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// 燃費の決定元
+const (
+	FuelEfficiencySourceOverride = "override"        // 車両別上書きファイル
+	FuelEfficiencySourceCarClass = "car_class"       // 車種区分テーブル
+	FuelEfficiencySourceWeight   = "max_load_weight" // 最大積載量テーブル
+	FuelEfficiencySourceDefault  = "default"         // デフォルト燃費
+)
+
+// defaultFuelEfficiency 設定がない場合のデフォルト燃費 (km/L)
+const defaultFuelEfficiency = 10.0
+
+// FuelEfficiency 車両に適用する燃費
+type FuelEfficiency struct {
+	KmPerLiter float64 // 燃費 (km/L)
+	Source     string  // 決定元 (FuelEfficiencySource*)
+}
+
+// WeightBand 最大積載量による燃費区分
+type WeightBand struct {
+	MaxKg      int32   `json:"max_kg"`   // 最大積載量の上限 (kg, この値以下が対象)
+	KmPerLiter float64 `json:"km_per_l"` // 燃費 (km/L)
+}
+
+// FuelEfficiencyConfig 燃費テーブル設定
+//
+// JSONファイル例:
+//
+//	{
+//	  "default_km_per_l": 10.0,
+//	  "class_field": 1,
+//	  "class_table": {"1": 9.0, "2": 6.5, "3": 4.0},
+//	  "weight_bands": [{"max_kg": 2000, "km_per_l": 9.0}, {"max_kg": 8000, "km_per_l": 5.5}]
+//	}
+type FuelEfficiencyConfig struct {
+	DefaultKmPerLiter float64            `json:"default_km_per_l"` // デフォルト燃費
+	ClassField        int                `json:"class_field"`      // 参照する車種区分 (1〜5 → car_class1〜5)
+	ClassTable        map[string]float64 `json:"class_table"`      // 車種区分値 → 燃費
+	WeightBands       []WeightBand       `json:"weight_bands"`     // 最大積載量の昇順
+}
+
+// DefaultFuelEfficiencyConfig デフォルトの燃費設定
+func DefaultFuelEfficiencyConfig() *FuelEfficiencyConfig {
+	return &FuelEfficiencyConfig{
+		DefaultKmPerLiter: defaultFuelEfficiency,
+		ClassField:        1,
+		ClassTable:        map[string]float64{},
+	}
+}
+
+// LoadFuelEfficiencyConfig JSONファイルから燃費テーブル設定を読み込む
+func LoadFuelEfficiencyConfig(path string) (*FuelEfficiencyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := DefaultFuelEfficiencyConfig()
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid fuel efficiency config %s: %w", path, err)
+	}
+	if config.DefaultKmPerLiter <= 0 {
+		return nil, fmt.Errorf("invalid fuel efficiency config %s: default_km_per_l must be positive", path)
+	}
+	if config.ClassField < 1 || config.ClassField > 5 {
+		return nil, fmt.Errorf("invalid fuel efficiency config %s: class_field must be 1-5", path)
+	}
+	return config, nil
+}
+
+// LoadFuelEfficiencyOverrides 車両別の燃費上書きファイルを読み込む
+//
+// JSONファイル形式: {"<車輌CC>": <燃費 km/L>, ...}
+func LoadFuelEfficiencyOverrides(path string) (map[string]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]float64)
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("invalid fuel efficiency overrides %s: %w", path, err)
+	}
+	for carCC, kmPerLiter := range overrides {
+		if kmPerLiter <= 0 {
+			return nil, fmt.Errorf("invalid fuel efficiency overrides %s: car_cc=%s must be positive", path, carCC)
+		}
+	}
+	return overrides, nil
+}
+
+// FuelEfficiencyResolver 車輌CCごとの燃費を決定する
+//
+// 優先順位:
+//  1. 車両別上書きファイル
+//  2. 車両マスタ（DTakoCars）の車種区分 → 燃費テーブル
+//  3. 車両マスタの最大積載量 → 燃費テーブル
+//  4. デフォルト燃費
+type FuelEfficiencyResolver struct {
+	cars      *CarMaster
+	config    *FuelEfficiencyConfig
+	overrides map[string]float64
+}
+
+// NewFuelEfficiencyResolver 燃費リゾルバーの作成
+//
+// 車両マスタを参照できない場合は、上書きファイルとデフォルト燃費のみを使用します。
+func NewFuelEfficiencyResolver(cars *CarMaster, config *FuelEfficiencyConfig, overrides map[string]float64) *FuelEfficiencyResolver {
+	if config == nil {
+		config = DefaultFuelEfficiencyConfig()
+	}
+	if overrides == nil {
+		overrides = make(map[string]float64)
+	}
+	return &FuelEfficiencyResolver{
+		cars:      cars,
+		config:    config,
+		overrides: overrides,
+	}
+}
+
+// NewFuelEfficiencyResolverFromEnv 環境変数の設定から燃費リゾルバーを作成
+//
+//   - FUEL_EFFICIENCY_CONFIG: 燃費テーブル設定ファイル (JSON)
+//   - FUEL_EFFICIENCY_OVERRIDES: 車両別燃費上書きファイル (JSON)
+//
+// ファイルの読み込みに失敗した場合は警告を出力し、デフォルト設定で動作します。
+func NewFuelEfficiencyResolverFromEnv(cars *CarMaster) *FuelEfficiencyResolver {
+	config := DefaultFuelEfficiencyConfig()
+	if path := os.Getenv("FUEL_EFFICIENCY_CONFIG"); path != "" {
+		loaded, err := LoadFuelEfficiencyConfig(path)
+		if err != nil {
+			log.Printf("Warning: failed to load fuel efficiency config, using defaults: %v", err)
+		} else {
+			config = loaded
+			log.Printf("Loaded fuel efficiency config from %s", path)
+		}
+	}
+
+	var overrides map[string]float64
+	if path := os.Getenv("FUEL_EFFICIENCY_OVERRIDES"); path != "" {
+		loaded, err := LoadFuelEfficiencyOverrides(path)
+		if err != nil {
+			log.Printf("Warning: failed to load fuel efficiency overrides: %v", err)
+		} else {
+			overrides = loaded
+			log.Printf("Loaded %d fuel efficiency overrides from %s", len(loaded), path)
+		}
+	}
+
+	return NewFuelEfficiencyResolver(cars, config, overrides)
+}
+
+// Resolve 車輌CC・車輌CD（不明な場合は0）に適用する燃費を決定
+func (r *FuelEfficiencyResolver) Resolve(ctx context.Context, carCC string, carCode int32) FuelEfficiency {
+	if kmPerLiter, ok := r.overrides[carCC]; ok {
+		return FuelEfficiency{KmPerLiter: kmPerLiter, Source: FuelEfficiencySourceOverride}
+	}
+
+	if car := r.cars.Lookup(ctx, carCC, carCode); car != nil {
+		classKey := strconv.Itoa(int(carClass(car, r.config.ClassField)))
+		if kmPerLiter, ok := r.config.ClassTable[classKey]; ok && kmPerLiter > 0 {
+			return FuelEfficiency{KmPerLiter: kmPerLiter, Source: FuelEfficiencySourceCarClass}
+		}
+
+		if car.MaxLoadWeightKg > 0 {
+			for _, band := range r.config.WeightBands {
+				if car.MaxLoadWeightKg <= band.MaxKg && band.KmPerLiter > 0 {
+					return FuelEfficiency{KmPerLiter: band.KmPerLiter, Source: FuelEfficiencySourceWeight}
+				}
+			}
+		}
+	}
+
+	return FuelEfficiency{KmPerLiter: r.config.DefaultKmPerLiter, Source: FuelEfficiencySourceDefault}
+}
+
+// carClass 車両マスタから指定番号の車種区分を取得
+func carClass(car *dbpb.Db_DTakoCars, field int) int32 {
+	switch field {
+	case 2:
+		return car.CarClass2
+	case 3:
+		return car.CarClass3
+	case 4:
+		return car.CarClass4
+	case 5:
+		return car.CarClass5
+	default:
+		return car.CarClass1
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yhonda-ohishi/dtako_rows/v3/internal/export"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// 給油量の根拠
+const (
+	FuelBasisMeasured  = "measured"  // 実給油データ
+	FuelBasisMixed     = "mixed"     // 実給油データ + 最後の給油より後の走行距離の推定
+	FuelBasisEstimated = "estimated" // 走行距離 / 燃費 による推定
+)
+
+// RefuelRecord 実給油データ（1回の給油）
+type RefuelRecord struct {
+	CarCC  string    // 車輌CC
+	Date   time.Time // 給油日
+	Liters float64   // 給油量 (L)
+	Amount float64   // 金額（円、不明な場合は0）
+}
+
+// FuelSource 実給油データの取得元
+//
+// 給油カードのCSVやdb_serviceのテーブルなど、取得元ごとに実装します。
+type FuelSource interface {
+	// Name ログ出力用の取得元名
+	Name() string
+	// ListRefuels 指定期間（start〜end、日付で両端を含む）の給油データを取得
+	// carCC が空の場合は全車両を返す
+	ListRefuels(ctx context.Context, carCC string, start, end time.Time) ([]*RefuelRecord, error)
+}
+
+// NewFuelSourceFromEnv 環境変数から実給油データの取得元を作成
+//
+// FUEL_CARD_CSV が未設定、または読み込みに失敗した場合は nil を返し、
+// 給油量はすべて推定値になります。
+func NewFuelSourceFromEnv() FuelSource {
+	path := os.Getenv("FUEL_CARD_CSV")
+	if path == "" {
+		return nil
+	}
+
+	source, err := LoadCSVFuelSource(path, os.Getenv("FUEL_CARD_CSV_ENCODING"))
+	if err != nil {
+		log.Printf("Warning: failed to load fuel card CSV, using estimates only: %v", err)
+		return nil
+	}
+	log.Printf("Loaded %d refuel records from %s", len(source.records), path)
+	return source
+}
+
+// CSVFuelSource 給油カードのCSVを取得元とする FuelSource
+//
+// 1行目はヘッダーで、以下の列名を認識します（順不同、それ以外の列は無視）:
+//
+//	車輌CC (car_cc), 給油日 (date), 給油量 (liters), 金額 (amount, 任意)
+//
+// 給油日は YYYY-MM-DD / YYYY/MM/DD（時刻付きも可）または RFC3339 形式です。
+type CSVFuelSource struct {
+	path    string
+	records []*RefuelRecord
+}
+
+// csvFuelColumns ヘッダー名 → 項目
+var csvFuelColumns = map[string]string{
+	"車輌CC":        "car_cc",
+	"車両CC":        "car_cc",
+	"car_cc":      "car_cc",
+	"給油日":         "date",
+	"date":        "date",
+	"refuel_date": "date",
+	"給油量":         "liters",
+	"給油量(L)":      "liters",
+	"liters":      "liters",
+	"金額":          "amount",
+	"金額(円)":       "amount",
+	"amount":      "amount",
+}
+
+// refuelDateLayouts 給油日として受け付ける形式
+var refuelDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"2006/1/2",
+}
+
+// LoadCSVFuelSource 給油カードのCSVファイルを読み込む
+//
+// encoding に "shift_jis" を指定した場合はShift_JISとして読み込みます（省略時はUTF-8）。
+func LoadCSVFuelSource(path, encoding string) (*CSVFuelSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	enc, err := export.ParseEncoding(encoding)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = f
+	if enc == export.EncodingShiftJIS {
+		r = transform.NewReader(f, japanese.ShiftJIS.NewDecoder())
+	}
+
+	records, err := parseRefuelCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fuel card CSV %s: %w", path, err)
+	}
+	return &CSVFuelSource{path: path, records: records}, nil
+}
+
+// parseRefuelCSV ヘッダー付きCSVから給油データを読み込む
+func parseRefuelCSV(r io.Reader) ([]*RefuelRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if key, ok := csvFuelColumns[name]; ok {
+			columns[key] = i
+		}
+	}
+	for _, required := range []string{"car_cc", "date", "liters"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %s", required)
+		}
+	}
+
+	var records []*RefuelRecord
+	for line := 2; ; line++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(key string) string {
+			i, ok := columns[key]
+			if !ok || i >= len(fields) {
+				return ""
+			}
+			return strings.TrimSpace(fields[i])
+		}
+
+		carCC := field("car_cc")
+		if carCC == "" {
+			continue
+		}
+		date, err := parseRefuelDate(field("date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		liters, err := strconv.ParseFloat(strings.ReplaceAll(field("liters"), ",", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid liters %q", line, field("liters"))
+		}
+
+		record := &RefuelRecord{CarCC: carCC, Date: date, Liters: liters}
+		if value := strings.ReplaceAll(field("amount"), ",", ""); value != "" {
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid amount %q", line, field("amount"))
+			}
+			record.Amount = amount
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseRefuelDate 給油日をパース
+//
+// オフセットのない日時は業務タイムゾーンの時刻として扱います。
+func parseRefuelDate(value string) (time.Time, error) {
+	for _, layout := range refuelDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, BusinessLocation()); err == nil {
+			return t.In(BusinessLocation()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// Name ログ出力用の取得元名
+func (c *CSVFuelSource) Name() string {
+	return "csv:" + c.path
+}
+
+// ListRefuels 指定期間の給油データを取得
+func (c *CSVFuelSource) ListRefuels(ctx context.Context, carCC string, start, end time.Time) ([]*RefuelRecord, error) {
+	startKey := start.Format("2006-01-02")
+	endKey := end.Format("2006-01-02")
+
+	var results []*RefuelRecord
+	for _, record := range c.records {
+		if carCC != "" && record.CarCC != carCC {
+			continue
+		}
+		dateKey := record.Date.Format("2006-01-02")
+		if dateKey < startKey || dateKey > endKey {
+			continue
+		}
+		results = append(results, record)
+	}
+	return results, nil
+}
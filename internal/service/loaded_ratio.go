@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// 実車率の判定しきい値のデフォルト
+const (
+	defaultPoorLoadedRatio  = 0.5 // 実車率（距離）がこの値未満の期間を低実車率とする
+	defaultPoorMonthsStreak = 3   // 低実車率の期間がこの回数連続した車両を要注意とする
+)
+
+// LoadedRatioSummary 集計期間（通常は月）ごとの実車率サマリー
+type LoadedRatioSummary struct {
+	CarCC               string  // 車輌CC
+	YearMonth           string  // 集計期間のキー（月次の場合は YYYY-MM形式）
+	Bucket              Bucket  // 集計期間（範囲・表示名）
+	TotalDistance       float64 // 総走行距離
+	LoadedDistance      float64 // 実車走行距離
+	EmptyDistance       float64 // 空車走行距離（総走行距離 - 実車走行距離）
+	LoadedDriveTime     int32   // 実車走行時間
+	EmptyDriveTime      int32   // 空車走行時間
+	TripCount           int32   // 運行回数
+	LoadedDistanceRatio float64 // 実車率（距離）
+	LoadedTimeRatio     float64 // 実車率（時間）
+	Poor                bool    // しきい値未満の期間
+}
+
+// VehicleLoadedRatio 車両別の実車率
+type VehicleLoadedRatio struct {
+	CarCC               string
+	Summaries           []*LoadedRatioSummary // 期間順
+	LoadedDistanceRatio float64               // 期間全体の実車率（距離）
+	LoadedTimeRatio     float64               // 期間全体の実車率（時間）
+	PoorMonths          int32                 // しきい値未満の期間数
+	Flagged             bool                  // しきい値未満の期間が連続した車両
+}
+
+// LoadedRatioOptions 実車率判定のオプション
+type LoadedRatioOptions struct {
+	PoorRatio        float64 // 低実車率とする実車率（距離）のしきい値（0以下でデフォルト）
+	PoorMonthsStreak int32   // 要注意とする低実車率の連続期間数（0以下でデフォルト）
+}
+
+// GetLoadedRatioSummary 車両ごと・集計期間（bucketing、通常は月）ごとの実車率を集計
+//
+// carCC が空の場合は全車両を集計します。
+// 低実車率の期間が PoorMonthsStreak 回以上連続した車両を Flagged とします
+// （運行のない期間を挟んだ場合は連続とみなしません）。
+func (s *DtakoRowsService) GetLoadedRatioSummary(ctx context.Context, carCC string, startDate, endDate string, bucketing Bucketing, opts LoadedRatioOptions) ([]*VehicleLoadedRatio, error) {
+	log.Printf("GetLoadedRatioSummary: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	if opts.PoorRatio <= 0 {
+		opts.PoorRatio = defaultPoorLoadedRatio
+	}
+	if opts.PoorMonthsStreak <= 0 {
+		opts.PoorMonthsStreak = defaultPoorMonthsStreak
+	}
+
+	var allRows []*dbpb.Db_DTakoRows
+	if carCC != "" {
+		allRows, err = s.ListByCarCCAndDateRange(ctx, carCC, startDate, endDate, 0)
+	} else {
+		allRows, err = s.ListByDateRange(ctx, startDate, endDate, 0)
+	}
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, err
+	}
+
+	// 車両ごと・期間ごとに集計
+	vehicleData := make(map[string]map[string]*LoadedRatioSummary)
+
+	for _, row := range allRows {
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			continue
+		}
+
+		bucket := periods.Bucket(opDate)
+
+		if _, exists := vehicleData[row.CarCc]; !exists {
+			vehicleData[row.CarCc] = make(map[string]*LoadedRatioSummary)
+		}
+		if _, exists := vehicleData[row.CarCc][bucket.Key]; !exists {
+			vehicleData[row.CarCc][bucket.Key] = &LoadedRatioSummary{
+				CarCC:     row.CarCc,
+				YearMonth: bucket.Key,
+				Bucket:    bucket,
+			}
+		}
+
+		summary := vehicleData[row.CarCc][bucket.Key]
+		summary.TotalDistance += row.TotalDistance
+		if row.LoadedDistance != nil {
+			summary.LoadedDistance += *row.LoadedDistance
+		}
+		summary.LoadedDriveTime += row.LoadedDriveTime
+		summary.EmptyDriveTime += row.EmptyDriveTime
+		summary.TripCount++
+	}
+
+	results := make([]*VehicleLoadedRatio, 0, len(vehicleData))
+	for carCC, periodData := range vehicleData {
+		vehicle := &VehicleLoadedRatio{CarCC: carCC}
+
+		var totalDistance, loadedDistance float64
+		var loadedTime, emptyTime int32
+		for _, summary := range periodData {
+			summary.EmptyDistance = summary.TotalDistance - summary.LoadedDistance
+			if summary.EmptyDistance < 0 {
+				summary.EmptyDistance = 0
+			}
+			summary.LoadedDistanceRatio = ratio(summary.LoadedDistance, summary.TotalDistance)
+			summary.LoadedTimeRatio = ratio(float64(summary.LoadedDriveTime), float64(summary.LoadedDriveTime+summary.EmptyDriveTime))
+			summary.Poor = summary.TotalDistance > 0 && summary.LoadedDistanceRatio < opts.PoorRatio
+
+			totalDistance += summary.TotalDistance
+			loadedDistance += summary.LoadedDistance
+			loadedTime += summary.LoadedDriveTime
+			emptyTime += summary.EmptyDriveTime
+			vehicle.Summaries = append(vehicle.Summaries, summary)
+		}
+
+		// 期間でソート
+		sort.Slice(vehicle.Summaries, func(i, j int) bool {
+			return vehicle.Summaries[i].YearMonth < vehicle.Summaries[j].YearMonth
+		})
+
+		// 低実車率の連続期間数を判定（運行のない期間を挟んだ場合は連続とみなさない）
+		streak := int32(0)
+		var previous *LoadedRatioSummary
+		for _, summary := range vehicle.Summaries {
+			if previous != nil && !consecutiveBuckets(previous.Bucket, summary.Bucket) {
+				streak = 0
+			}
+			previous = summary
+			if !summary.Poor {
+				streak = 0
+				continue
+			}
+			vehicle.PoorMonths++
+			streak++
+			if streak >= opts.PoorMonthsStreak {
+				vehicle.Flagged = true
+			}
+		}
+
+		vehicle.LoadedDistanceRatio = ratio(loadedDistance, totalDistance)
+		vehicle.LoadedTimeRatio = ratio(float64(loadedTime), float64(loadedTime+emptyTime))
+		results = append(results, vehicle)
+	}
+
+	// 車輌CCでソート
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CarCC < results[j].CarCC
+	})
+
+	log.Printf("Aggregated loaded ratio for %d vehicles", len(results))
+	return results, nil
+}
+
+// consecutiveBuckets next が prev の直後の集計期間か
+func consecutiveBuckets(prev, next Bucket) bool {
+	return prev.End.AddDate(0, 0, 1).Equal(next.Start)
+}
+
+// ratio 比率を計算（分母が0の場合は0）
+func ratio(numerator, denominator float64) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return numerator / denominator
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// UnassignedOfficeCode 車両マスタにない（または所属事業所が未設定の）車両をまとめる集計キー
+const UnassignedOfficeCode = "unassigned"
+
+// OfficeSummary 事業所の集計期間ごとのサマリー
+type OfficeSummary struct {
+	OfficeCode string // 所属事業所C（未設定の場合は UnassignedOfficeCode）
+	Period     string // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket     Bucket // 集計期間（期間全体の合計の場合はゼロ値）
+	SummaryTotals
+	ActiveVehicles int32 // 期間内に運行のあった車両数
+}
+
+// PerVehicle 稼働車両1台あたりの値（稼働車両がない場合は0）
+func (s *OfficeSummary) PerVehicle(value float64) float64 {
+	if s.ActiveVehicles == 0 {
+		return 0
+	}
+	return value / float64(s.ActiveVehicles)
+}
+
+// OfficeSummaries 1事業所分の期間ごとのサマリーと期間全体の合計
+type OfficeSummaries struct {
+	OfficeCode string
+	Summaries  []*OfficeSummary // 期間順
+	Total      *OfficeSummary   // 期間全体の合計
+	CarCCs     []string         // 集計対象の車輌CC（車輌CC順）
+}
+
+// GetOfficeMonthlySummary 事業所（所属事業所C）ごとの月次サマリーを取得
+//
+// GetVehicleMonthlySummary と同じ方法で車両ごとに集計し、車両マスタ（DTakoCars）の
+// belong_office_code で事業所ごとに合計します。車両マスタとは運行データの car_cc、見つからない場合は
+// 運行データの car_code で対応付け、どちらもない車両は UnassignedOfficeCode にまとめます。
+// officeCode を指定した場合はその事業所のみを返します。戻り値は事業所C順（未割当は末尾）です。
+func (s *DtakoRowsService) GetOfficeMonthlySummary(ctx context.Context, startDate, endDate string, officeCode *int32, bucketing Bucketing) ([]*OfficeSummaries, error) {
+	log.Printf("GetOfficeMonthlySummary: start=%s, end=%s, bucket=%s", startDate, endDate, bucketing.Kind)
+
+	if !s.cars.Available() {
+		log.Printf("Warning: car master is not available, all vehicles are %s", UnassignedOfficeCode)
+	}
+
+	summariesMap, err := s.GetVehicleMonthlySummary(ctx, startDate, endDate, bucketing)
+	if err != nil {
+		return nil, err
+	}
+
+	offices := make(map[string]*OfficeSummaries)
+	periods := make(map[string]map[string]*OfficeSummary)
+	for carCC, summaries := range summariesMap {
+		code := officeKey(s.cars.Lookup(ctx, carCC, summariesCarCode(summaries)))
+		if officeCode != nil && code != strconv.Itoa(int(*officeCode)) {
+			continue
+		}
+
+		office, exists := offices[code]
+		if !exists {
+			office = &OfficeSummaries{
+				OfficeCode: code,
+				Total:      &OfficeSummary{OfficeCode: code},
+			}
+			offices[code] = office
+			periods[code] = make(map[string]*OfficeSummary)
+		}
+		office.CarCCs = append(office.CarCCs, carCC)
+
+		active := false
+		for _, summary := range summaries {
+			period, exists := periods[code][summary.YearMonth]
+			if !exists {
+				period = &OfficeSummary{OfficeCode: code, Period: summary.YearMonth, Bucket: summary.Bucket}
+				periods[code][summary.YearMonth] = period
+			}
+			period.add(summary)
+			office.Total.add(summary)
+			if summary.TripCount > 0 {
+				period.ActiveVehicles++
+				active = true
+			}
+		}
+		if active {
+			office.Total.ActiveVehicles++
+		}
+	}
+
+	codes := make([]string, 0, len(offices))
+	for code := range offices {
+		codes = append(codes, code)
+	}
+	sortCodes(codes, UnassignedOfficeCode)
+
+	results := make([]*OfficeSummaries, len(codes))
+	for i, code := range codes {
+		office := offices[code]
+		sort.Strings(office.CarCCs)
+		for _, period := range periods[code] {
+			office.Summaries = append(office.Summaries, period)
+		}
+		sort.Slice(office.Summaries, func(a, b int) bool {
+			return office.Summaries[a].Period < office.Summaries[b].Period
+		})
+		results[i] = office
+	}
+
+	log.Printf("Aggregated data for %d offices", len(results))
+	return results, nil
+}
+
+// officeKey 車両の事業所集計キー（車両マスタの所属事業所C、車両が不明な場合は UnassignedOfficeCode）
+func officeKey(car *dbpb.Db_DTakoCars) string {
+	if car == nil || car.BelongOfficeCode == 0 {
+		return UnassignedOfficeCode
+	}
+	return strconv.Itoa(int(car.BelongOfficeCode))
+}
+
+// summariesCarCode 1車両分のサマリーの車輌CD（0以外の最後の値、ない場合は0）
+func summariesCarCode(summaries []*MonthlyFuelSummary) int32 {
+	var carCode int32
+	for _, summary := range summaries {
+		if summary.CarCode != 0 {
+			carCode = summary.CarCode
+		}
+	}
+	return carCode
+}
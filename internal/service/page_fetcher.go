@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// defaultFetchWorkers db_serviceからの並列取得数のデフォルト
+const defaultFetchWorkers = 4
+
+// fetchWorkersFromEnv 環境変数 DB_FETCH_WORKERS から並列取得数を取得
+func fetchWorkersFromEnv() int {
+	value := os.Getenv("DB_FETCH_WORKERS")
+	if value == "" {
+		return defaultFetchWorkers
+	}
+
+	workers, err := strconv.Atoi(value)
+	if err != nil || workers < 1 {
+		log.Printf("Warning: invalid DB_FETCH_WORKERS=%q, using %d", value, defaultFetchWorkers)
+		return defaultFetchWorkers
+	}
+	return workers
+}
+
+// PageFetcher db_serviceの運行データをページ単位で並列に取得する
+//
+// 1ページ目のレスポンスの TotalCount から残りのオフセットを算出し、
+// 最大 workers 件を同時に取得します。コールバックには常にオフセット順で渡されます。
+type PageFetcher struct {
+	client   dbpb.Db_DTakoRowsServiceClient
+	pageSize int32
+	workers  int
+}
+
+// NewPageFetcher ページ取得器の作成
+//
+// workers が1以下の場合は1ページずつ順番に取得します。
+func NewPageFetcher(client dbpb.Db_DTakoRowsServiceClient, pageSize int32, workers int) *PageFetcher {
+	if workers < 1 {
+		workers = 1
+	}
+	return &PageFetcher{
+		client:   client,
+		pageSize: pageSize,
+		workers:  workers,
+	}
+}
+
+// pageResult 1ページ分の取得結果
+type pageResult struct {
+	items []*dbpb.Db_DTakoRows
+	err   error
+}
+
+// Fetch 全ページを取得し、オフセット順に fn に渡す
+//
+// fn がエラーを返した場合、またはいずれかのページの取得に失敗した場合は
+// 残りの取得をキャンセルして最初のエラーを返します。
+// 戻り値はdb_serviceから取得した行数です。
+func (f *PageFetcher) Fetch(ctx context.Context, orderBy string, fn func(items []*dbpb.Db_DTakoRows) error) (int32, error) {
+	// 1ページ目（総件数の取得を兼ねる）
+	first, err := f.fetchPage(ctx, orderBy, 0)
+	if err != nil {
+		log.Printf("Failed to list rows: %v", err)
+		return 0, err
+	}
+	totalFetched := int32(len(first.Items))
+	if err := fn(first.Items); err != nil {
+		return totalFetched, err
+	}
+	if len(first.Items) < int(f.pageSize) {
+		return totalFetched, nil
+	}
+
+	// 残りのページ数を算出
+	pages := int((first.TotalCount + f.pageSize - 1) / f.pageSize)
+
+	fetched, lastFull, err := f.fetchParallel(ctx, orderBy, 1, pages, fn)
+	totalFetched += fetched
+	if err != nil || !lastFull {
+		return totalFetched, err
+	}
+
+	// 取得中に行が追加され総件数を超えた場合は、残りを順番に取得
+	offset := int32(pages) * f.pageSize
+	for {
+		resp, err := f.fetchPage(ctx, orderBy, offset)
+		if err != nil {
+			log.Printf("Failed to list rows: %v", err)
+			return totalFetched, err
+		}
+		totalFetched += int32(len(resp.Items))
+		if err := fn(resp.Items); err != nil {
+			return totalFetched, err
+		}
+		if len(resp.Items) < int(f.pageSize) {
+			return totalFetched, nil
+		}
+		offset += f.pageSize
+	}
+}
+
+// fetchParallel ページ番号 [from, to) を並列に取得し、順番に fn に渡す
+//
+// 取得済みで未処理のページは最大 workers 件に制限されます。
+// 戻り値の lastFull は最後のページが pageSize 件ちょうどだったかどうかです。
+func (f *PageFetcher) fetchParallel(ctx context.Context, orderBy string, from, to int, fn func(items []*dbpb.Db_DTakoRows) error) (int32, bool, error) {
+	if from >= to {
+		return 0, true, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var firstErr error
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	results := make([]chan pageResult, to-from)
+	for i := range results {
+		results[i] = make(chan pageResult, 1)
+	}
+
+	// 同時取得数の制御（コンシューマーが処理したページ分だけ次を取得）
+	slots := make(chan struct{}, f.workers)
+	var wg sync.WaitGroup
+
+	// 終了時は残りの取得をキャンセルし、全goroutineの終了を待つ
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range results {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				offset := int32(from+i) * f.pageSize
+				resp, err := f.fetchPage(ctx, orderBy, offset)
+				if err != nil {
+					fail(err)
+					results[i] <- pageResult{err: err}
+					return
+				}
+				results[i] <- pageResult{items: resp.Items}
+			}(i)
+		}
+	}()
+
+	totalFetched := int32(0)
+	lastFull := false
+	for i := range results {
+		var result pageResult
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			// 他のページの取得失敗、または呼び出し元のキャンセル
+			fail(ctx.Err())
+			return totalFetched, false, firstErr
+		}
+		<-slots
+
+		if result.err != nil {
+			fail(result.err)
+			log.Printf("Failed to list rows: %v", firstErr)
+			return totalFetched, false, firstErr
+		}
+
+		totalFetched += int32(len(result.items))
+		if err := fn(result.items); err != nil {
+			fail(err)
+			return totalFetched, false, err
+		}
+
+		lastFull = len(result.items) == int(f.pageSize)
+		if !lastFull {
+			break
+		}
+	}
+
+	return totalFetched, lastFull, nil
+}
+
+// fetchPage 指定オフセットの1ページを取得
+func (f *PageFetcher) fetchPage(ctx context.Context, orderBy string, offset int32) (*dbpb.Db_ListDTakoRowsResponse, error) {
+	req := &dbpb.Db_ListDTakoRowsRequest{
+		Limit:  f.pageSize,
+		Offset: offset,
+	}
+	if orderBy != "" {
+		req.OrderBy = &orderBy
+	}
+	return f.client.List(ctx, req)
+}
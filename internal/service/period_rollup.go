@@ -0,0 +1,92 @@
+package service
+
+import "sort"
+
+// periodRollup 運行ごとの値を車両・集計期間ごとに集計
+//
+// 各運行を「車両・期間」「車両の期間全体」「全車両の期間」「全車両の期間全体」の4つの集計に加算します。
+// T は期間ごとの集計（*TollPeriodSummary など）で、newSummary で空の集計を作成します
+// （carCC が空の場合は全車両、bucket がゼロ値の場合は期間全体の集計）。
+type periodRollup[T any] struct {
+	newSummary     func(carCC string, bucket Bucket) T
+	total          T
+	vehicleTotals  map[string]T
+	vehiclePeriods map[string]map[string]T
+	fleetPeriods   map[string]T
+}
+
+// newPeriodRollup 集計を開始（total は全車両の期間全体の集計）
+func newPeriodRollup[T any](total T, newSummary func(carCC string, bucket Bucket) T) *periodRollup[T] {
+	return &periodRollup[T]{
+		newSummary:     newSummary,
+		total:          total,
+		vehicleTotals:  make(map[string]T),
+		vehiclePeriods: make(map[string]map[string]T),
+		fleetPeriods:   make(map[string]T),
+	}
+}
+
+// add 運行の値を加算（add は4つの集計それぞれに対して呼び出されます）
+func (r *periodRollup[T]) add(carCC string, bucket Bucket, add func(summary T)) {
+	vehicleTotal, exists := r.vehicleTotals[carCC]
+	if !exists {
+		vehicleTotal = r.newSummary(carCC, Bucket{})
+		r.vehicleTotals[carCC] = vehicleTotal
+		r.vehiclePeriods[carCC] = make(map[string]T)
+	}
+	period, exists := r.vehiclePeriods[carCC][bucket.Key]
+	if !exists {
+		period = r.newSummary(carCC, bucket)
+		r.vehiclePeriods[carCC][bucket.Key] = period
+	}
+	fleetPeriod, exists := r.fleetPeriods[bucket.Key]
+	if !exists {
+		fleetPeriod = r.newSummary("", bucket)
+		r.fleetPeriods[bucket.Key] = fleetPeriod
+	}
+
+	add(period)
+	add(vehicleTotal)
+	add(fleetPeriod)
+	add(r.total)
+}
+
+// carCCs 集計した車輌CC（車輌CC順）
+func (r *periodRollup[T]) carCCs() []string {
+	carCCs := make([]string, 0, len(r.vehicleTotals))
+	for carCC := range r.vehicleTotals {
+		carCCs = append(carCCs, carCC)
+	}
+	sort.Strings(carCCs)
+	return carCCs
+}
+
+// vehicleTotal 車両の期間全体の集計
+func (r *periodRollup[T]) vehicleTotal(carCC string) T {
+	return r.vehicleTotals[carCC]
+}
+
+// vehicleSummaries 車両の期間ごとの集計（期間順）
+func (r *periodRollup[T]) vehicleSummaries(carCC string) []T {
+	return sortedByPeriod(r.vehiclePeriods[carCC])
+}
+
+// periods 全車両の期間ごとの集計（期間順）
+func (r *periodRollup[T]) periods() []T {
+	return sortedByPeriod(r.fleetPeriods)
+}
+
+// sortedByPeriod 集計期間のキー順に並べる
+func sortedByPeriod[T any](data map[string]T) []T {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	summaries := make([]T, len(keys))
+	for i, key := range keys {
+		summaries[i] = data[key]
+	}
+	return summaries
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProfitOptions 採算計算のオプション
+type ProfitOptions struct {
+	FuelPricePerLiter *float64 // 燃料単価（円/L、nilの場合は環境変数 FUEL_PRICE_PER_LITER）
+	RevenueCodes      []int32  // 売上とする経費C（空の場合は環境変数 REVENUE_KEIHI_CODES）
+}
+
+// withDefaults 未指定の項目に環境変数の値を設定
+func (o ProfitOptions) withDefaults() ProfitOptions {
+	if o.FuelPricePerLiter == nil {
+		price := fuelPriceFromEnv()
+		o.FuelPricePerLiter = &price
+	}
+	if len(o.RevenueCodes) == 0 {
+		o.RevenueCodes = revenueCodesFromEnv()
+	}
+	return o
+}
+
+// Validate オプションを検証
+func (o ProfitOptions) Validate() error {
+	if o.fuelPrice() < 0 {
+		return status.Errorf(codes.InvalidArgument, "fuel_price_per_liter must not be negative: %v", o.fuelPrice())
+	}
+	return nil
+}
+
+// fuelPrice 燃料単価（未設定の場合は0）
+func (o ProfitOptions) fuelPrice() float64 {
+	if o.FuelPricePerLiter == nil {
+		return 0
+	}
+	return *o.FuelPricePerLiter
+}
+
+// isRevenue 経費Cが売上か
+func (o ProfitOptions) isRevenue(keihiC int32) bool {
+	for _, code := range o.RevenueCodes {
+		if code == keihiC {
+			return true
+		}
+	}
+	return false
+}
+
+// fuelPriceFromEnv 環境変数 FUEL_PRICE_PER_LITER から燃料単価を取得（未設定・不正な場合は0）
+func fuelPriceFromEnv() float64 {
+	value := os.Getenv("FUEL_PRICE_PER_LITER")
+	if value == "" {
+		return 0
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 {
+		log.Printf("Warning: invalid FUEL_PRICE_PER_LITER=%q, fuel costs are not included", value)
+		return 0
+	}
+	return price
+}
+
+// revenueCodesFromEnv 環境変数 REVENUE_KEIHI_CODES（カンマ区切り）から売上とする経費Cを取得
+func revenueCodesFromEnv() []int32 {
+	var revenueCodes []int32
+	for _, field := range strings.Split(os.Getenv("REVENUE_KEIHI_CODES"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		code, err := strconv.ParseInt(field, 10, 32)
+		if err != nil {
+			log.Printf("Warning: invalid keihi_c %q in REVENUE_KEIHI_CODES, ignored", field)
+			continue
+		}
+		revenueCodes = append(revenueCodes, int32(code))
+	}
+	return revenueCodes
+}
+
+// ProfitTotals 売上・費用の合計
+type ProfitTotals struct {
+	TotalDistance float64 // 走行距離 (km)
+	TripCount     int32
+	Revenue       float64 // 売上（売上とする経費Cの合計）
+	Expenses      float64 // 経費（売上以外の経費Cの合計）
+	FuelLiters    float64 // 推定燃料使用量 (L、走行距離 / 燃費)
+	FuelCost      float64 // 推定燃料費（推定燃料使用量 × 燃料単価）
+	TollCost      float64 // 通行料金（ETC明細）
+	FerryCost     float64 // フェリー料金
+}
+
+// add 他の合計を加算
+func (t *ProfitTotals) add(o ProfitTotals) {
+	t.TotalDistance += o.TotalDistance
+	t.TripCount += o.TripCount
+	t.Revenue += o.Revenue
+	t.Expenses += o.Expenses
+	t.FuelLiters += o.FuelLiters
+	t.FuelCost += o.FuelCost
+	t.TollCost += o.TollCost
+	t.FerryCost += o.FerryCost
+}
+
+// TotalCost 費用の合計（経費・燃料費・通行料金・フェリー料金）
+func (t ProfitTotals) TotalCost() float64 {
+	return t.Expenses + t.FuelCost + t.TollCost + t.FerryCost
+}
+
+// GrossMargin 粗利（売上 - 費用の合計）
+func (t ProfitTotals) GrossMargin() float64 {
+	return t.Revenue - t.TotalCost()
+}
+
+// MarginRatio 粗利率（売上が0の場合は0）
+func (t ProfitTotals) MarginRatio() float64 {
+	if t.Revenue == 0 {
+		return 0
+	}
+	return t.GrossMargin() / t.Revenue
+}
+
+// RevenuePerKm 走行距離1kmあたりの売上（走行距離が0の場合は0）
+func (t ProfitTotals) RevenuePerKm() float64 {
+	return ratio(t.Revenue, t.TotalDistance)
+}
+
+// KeihiAmount 経費Cごとの金額
+type KeihiAmount struct {
+	KeihiC  int32
+	Revenue bool    // 売上として計上した
+	Amount  float64 // 金額の合計
+	Km      float64 // 距離の合計 (km、売上経費データに記録された距離)
+	Count   int32
+}
+
+// TripProfit 運行ごとの採算
+type TripProfit struct {
+	RowID         string
+	OperationNo   string
+	CarCC         string
+	OperationDate time.Time
+	Bucket        Bucket // 運行日の集計期間
+	ProfitTotals
+	Lines []*KeihiAmount // 経費C順
+}
+
+// ProfitPeriodSummary 車両の集計期間ごとの採算
+type ProfitPeriodSummary struct {
+	CarCC  string // 車輌CC（全車両の合計の場合は空）
+	Period string // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket Bucket // 集計期間（期間全体の合計の場合はゼロ値）
+	ProfitTotals
+}
+
+// VehicleProfit 1車両分の期間ごとの採算と期間全体の合計
+type VehicleProfit struct {
+	CarCC     string
+	Summaries []*ProfitPeriodSummary // 期間順
+	Total     *ProfitPeriodSummary
+}
+
+// ProfitabilityReport 採算の集計結果
+type ProfitabilityReport struct {
+	Trips    []*TripProfit          // 運行日・運行NO順
+	Vehicles []*VehicleProfit       // 車輌CC順
+	Periods  []*ProfitPeriodSummary // 全車両の期間ごとの合計（期間順）
+	Total    *ProfitPeriodSummary   // 全車両の期間全体の合計
+	Options  ProfitOptions          // 適用したオプション（環境変数の値を含む）
+
+	UnlinkedKeihi    int32 // 期間内の売上経費データのうち、運行に対応付けられなかった件数
+	KeihiAvailable   bool  // 売上経費データを参照できたか
+	TollsAvailable   bool  // ETC明細を参照できたか
+	FerriesAvailable bool  // フェリー運行データを参照できたか
+}
+
+// GetTripProfitability 運行・車両・集計期間ごとの採算を集計
+//
+// 売上経費データ（DTakoUriageKeihi）を dtako_row_id（見つからない場合は dtako_row_id_r）で
+// 運行に対応付け、売上とする経費C（opts.RevenueCodes）を売上、それ以外を経費とします。
+// 燃料費は走行距離と車両ごとの燃費から推定した燃料使用量 × 燃料単価、通行料金は
+// GetTollSummary、フェリー料金は GetFerrySummary と同じ方法で運行に対応付けます。
+// carCC が空の場合は全車両を集計します。
+func (s *DtakoRowsService) GetTripProfitability(ctx context.Context, carCC, startDate, endDate string, bucketing Bucketing, opts ProfitOptions) (*ProfitabilityReport, error) {
+	log.Printf("GetTripProfitability: car_cc=%s, start=%s, end=%s, bucket=%s", carCC, startDate, endDate, bucketing.Kind)
+
+	opts = opts.withDefaults()
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if len(opts.RevenueCodes) == 0 {
+		log.Printf("Warning: no revenue keihi_c configured (REVENUE_KEIHI_CODES), all entries are counted as expenses")
+	}
+	if opts.fuelPrice() == 0 {
+		log.Printf("Warning: fuel price is not configured (FUEL_PRICE_PER_LITER), fuel costs are not included")
+	}
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	periods := bucketing.Range(start, end)
+
+	tolls, _, err := s.tripTolls(ctx, carCC, start, end, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ProfitabilityReport{
+		Total:            &ProfitPeriodSummary{},
+		Options:          opts,
+		KeihiAvailable:   s.uriageKeihi != nil,
+		TollsAvailable:   s.etc.Available(),
+		FerriesAvailable: s.ferries.Available(),
+	}
+
+	trips := make(map[string]*TripProfit, len(tolls))
+	efficiencies := make(map[string]FuelEfficiency)
+	ferries := s.newFerryTally(ctx)
+	// 同じ運行NOの行が複数ある場合にフェリー料金を計上する行を固定するため、ID順に処理する
+	ids := make([]string, 0, len(tolls))
+	for id := range tolls {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		toll := tolls[id]
+		efficiency, exists := efficiencies[toll.CarCC]
+		if !exists {
+			efficiency = s.resolveFuelEfficiency(ctx, toll.CarCC, toll.CarCode)
+			efficiencies[toll.CarCC] = efficiency
+		}
+
+		trip := &TripProfit{
+			RowID:         toll.RowID,
+			OperationNo:   toll.OperationNo,
+			CarCC:         toll.CarCC,
+			OperationDate: toll.OperationDate,
+			Bucket:        toll.Bucket,
+		}
+		trip.TotalDistance = toll.TotalDistance
+		trip.TripCount = 1
+		trip.FuelLiters = ratio(toll.TotalDistance, efficiency.KmPerLiter)
+		trip.FuelCost = trip.FuelLiters * opts.fuelPrice()
+		trip.TollCost = float64(toll.TollAmount)
+		for _, ferry := range ferries.take(toll.OperationNo) {
+			trip.FerryCost += float64(ferryFare(ferry))
+		}
+		trips[id] = trip
+	}
+
+	if report.KeihiAvailable {
+		unlinked, err := s.applyKeihi(ctx, trips, opts, start, end)
+		if err != nil {
+			return nil, err
+		}
+		report.UnlinkedKeihi = unlinked
+	}
+
+	summarizeProfits(report, trips)
+
+	log.Printf("Aggregated profitability for %d trips, %d vehicles", len(report.Trips), len(report.Vehicles))
+	return report, nil
+}
+
+// applyKeihi 売上経費データを運行に計上し、期間内で対応付けられなかった件数を返す
+//
+// dtako_row_id で対応付ける売上経費データは運行ごとに取得するため、運行から何日後に
+// 計上されたデータも含みます。dtako_row_id_r で対応付けるデータと、対応付けられなかった
+// 件数は日時が期間内のデータから求めます。
+func (s *DtakoRowsService) applyKeihi(ctx context.Context, trips map[string]*TripProfit, opts ProfitOptions, start, end time.Time) (int32, error) {
+	byRow, err := s.keihiByRowID(ctx, trips)
+	if err != nil {
+		log.Printf("Failed to list uriage keihi by row id: %v", err)
+		return 0, err
+	}
+	startDate, endDate := datetimeFilterRange(start, end)
+	periodEntries, err := s.listKeihi(ctx, &dbpb.Db_ListDTakoUriageKeihiRequest{StartDate: &startDate, EndDate: &endDate})
+	if err != nil {
+		log.Printf("Failed to list uriage keihi: %v", err)
+		return 0, err
+	}
+
+	lines := make(map[string]map[int32]*KeihiAmount)
+	addEntry := func(trip *TripProfit, entry *dbpb.Db_DTakoUriageKeihi) {
+		revenue := opts.isRevenue(entry.KeihiC)
+		if revenue {
+			trip.Revenue += entry.Price
+		} else {
+			trip.Expenses += entry.Price
+		}
+
+		if lines[trip.RowID] == nil {
+			lines[trip.RowID] = make(map[int32]*KeihiAmount)
+		}
+		line, exists := lines[trip.RowID][entry.KeihiC]
+		if !exists {
+			line = &KeihiAmount{KeihiC: entry.KeihiC, Revenue: revenue}
+			lines[trip.RowID][entry.KeihiC] = line
+			trip.Lines = append(trip.Lines, line)
+		}
+		line.Amount += entry.Price
+		line.Km += entry.GetKm()
+		line.Count++
+	}
+
+	for rowID, entries := range byRow {
+		for _, entry := range entries {
+			addEntry(trips[rowID], entry)
+		}
+	}
+
+	unlinked := int32(0)
+	for _, entry := range periodEntries {
+		if _, exists := trips[entry.DtakoRowId]; exists {
+			// dtako_row_id での取得で計上済み
+			continue
+		}
+		trip, exists := trips[entry.DtakoRowIdR]
+		if !exists {
+			unlinked++
+			continue
+		}
+		addEntry(trip, entry)
+	}
+
+	for _, trip := range trips {
+		sort.Slice(trip.Lines, func(i, j int) bool {
+			return trip.Lines[i].KeihiC < trip.Lines[j].KeihiC
+		})
+	}
+
+	if unlinked > 0 {
+		log.Printf("%d uriage keihi entries are not linked to trips in the period", unlinked)
+	}
+	return unlinked, nil
+}
+
+// keihiByRowID 運行データID → dtako_row_id がその運行の売上経費データ
+//
+// 最大 fetchWorkers 件の運行を同時に取得します。
+func (s *DtakoRowsService) keihiByRowID(ctx context.Context, trips map[string]*TripProfit) (map[string][]*dbpb.Db_DTakoUriageKeihi, error) {
+	ids := make([]string, 0, len(trips))
+	for id := range trips {
+		ids = append(ids, id)
+	}
+	return fanOut(ctx, ids, s.fetchWorkers, func(ctx context.Context, id string) ([]*dbpb.Db_DTakoUriageKeihi, error) {
+		return s.listKeihi(ctx, &dbpb.Db_ListDTakoUriageKeihiRequest{DtakoRowId: &id})
+	})
+}
+
+// listKeihi 条件に一致する売上経費データを全件取得
+func (s *DtakoRowsService) listKeihi(ctx context.Context, req *dbpb.Db_ListDTakoUriageKeihiRequest) ([]*dbpb.Db_DTakoUriageKeihi, error) {
+	req.Limit = 1000
+	req.Offset = 0
+
+	var entries []*dbpb.Db_DTakoUriageKeihi
+	for {
+		resp, err := s.uriageKeihi.List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, resp.Items...)
+
+		if len(resp.Items) < int(req.Limit) {
+			break
+		}
+		req.Offset += req.Limit
+	}
+
+	if req.DtakoRowId == nil {
+		log.Printf("Loaded %d uriage keihi entries (%s ~ %s)", len(entries), req.GetStartDate(), req.GetEndDate())
+	}
+	return entries, nil
+}
+
+// summarizeProfits 運行ごとの採算を車両・期間ごとに集計
+func summarizeProfits(report *ProfitabilityReport, trips map[string]*TripProfit) {
+	rollup := newPeriodRollup(report.Total, func(carCC string, bucket Bucket) *ProfitPeriodSummary {
+		return &ProfitPeriodSummary{CarCC: carCC, Period: bucket.Key, Bucket: bucket}
+	})
+
+	for _, trip := range trips {
+		rollup.add(trip.CarCC, trip.Bucket, func(summary *ProfitPeriodSummary) {
+			summary.add(trip.ProfitTotals)
+		})
+		report.Trips = append(report.Trips, trip)
+	}
+
+	sort.Slice(report.Trips, func(i, j int) bool {
+		a, b := report.Trips[i], report.Trips[j]
+		if !a.OperationDate.Equal(b.OperationDate) {
+			return a.OperationDate.Before(b.OperationDate)
+		}
+		if a.OperationNo != b.OperationNo {
+			return a.OperationNo < b.OperationNo
+		}
+		return a.RowID < b.RowID
+	})
+
+	for _, carCC := range rollup.carCCs() {
+		report.Vehicles = append(report.Vehicles, &VehicleProfit{
+			CarCC:     carCC,
+			Summaries: rollup.vehicleSummaries(carCC),
+			Total:     rollup.vehicleTotal(carCC),
+		})
+	}
+	report.Periods = rollup.periods()
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 取得経路
+const (
+	QueryPathOperationNoLookup = "operation_no_lookup" // GetByOperationNoで運行NOごとに直接取得
+	QueryPathDateRangeSeek     = "date_range_seek"     // 運行日降順で取得し、開始日より古くなった時点で打ち切り
+	QueryPathFullScan          = "full_scan"           // 全件スキャン（メモリ上でフィルタリング）
+)
+
+// db_service（prod_db.dtako_rows）のカラム名
+//
+// Db_ListDTakoRowsRequest.order_by はそのままORDER BY句として使われるため、DB上のカラム名で指定する。
+const (
+	columnID            = "id"
+	columnReadDate      = "読取日"
+	columnOperationDate = "運行日"
+	columnCarCC         = "車輌CC"
+)
+
+// QueryPlan FilterOptions の実行計画
+//
+// db_service v1.8 の Db_DTakoRowsService がサーバー側で処理できるのは
+// 運行NOの完全一致（GetByOperationNo）と order_by のみです。
+// car_cc・min_distance などはリクエストに該当フィールドがないため、
+// 常にメモリ上で評価します（Residual）。
+type QueryPlan struct {
+	Path     string   // 取得経路 (QueryPath*)
+	OrderBy  string   // db_serviceに渡すORDER BY句
+	Pushdown []string // db_service側で処理される条件
+	Residual []string // メモリ上で評価される条件
+}
+
+// String ログ出力用の表現
+func (p *QueryPlan) String() string {
+	return fmt.Sprintf("path=%s pushdown=[%s] residual=[%s]",
+		p.Path, strings.Join(p.Pushdown, ","), strings.Join(p.Residual, ","))
+}
+
+// PlanQuery フィルタ条件から実行計画を作成
+func PlanQuery(filter *FilterOptions) *QueryPlan {
+	plan := &QueryPlan{
+		Path:    QueryPathFullScan,
+		OrderBy: fmt.Sprintf("%s DESC, %s DESC", columnReadDate, columnID),
+	}
+	if filter == nil {
+		return plan
+	}
+
+	switch {
+	case len(filter.OperationNos) > 0:
+		// 運行NOが指定されていれば対象行だけを直接取得できる
+		plan.Path = QueryPathOperationNoLookup
+		plan.OrderBy = ""
+		plan.Pushdown = append(plan.Pushdown, "operation_nos")
+	case filter.OrderBy != "":
+		// 並び順が指定された場合は打ち切りができないため全件スキャン
+		plan.OrderBy = filter.OrderBy
+	case filter.StartDate != nil && !filter.IncludeInvalidDates:
+		// 運行日降順で取得すれば、開始日より古い行が現れた時点で以降を読む必要がない
+		// （運行日が不正な行も含める場合は、並び順が保証できないため打ち切らない）
+		plan.Path = QueryPathDateRangeSeek
+		plan.OrderBy = fmt.Sprintf("%s DESC, %s DESC", columnOperationDate, columnID)
+		plan.Pushdown = append(plan.Pushdown, "start_date")
+	}
+
+	plan.Residual = residualPredicates(filter, plan.Path)
+	return plan
+}
+
+// PlanQueryByCarCC 車輌CC順に取得する実行計画を作成
+//
+// 車両ごとの集計を車輌CCが切り替わった時点で確定させたい場合（ストリーミング）に使用します。
+// 車輌CC順に並べるため、date_range_seek による打ち切りや運行NOでの直接取得は行いません。
+func PlanQueryByCarCC(filter *FilterOptions) *QueryPlan {
+	plan := &QueryPlan{
+		Path:    QueryPathFullScan,
+		OrderBy: fmt.Sprintf("%s ASC, %s ASC, %s ASC", columnCarCC, columnOperationDate, columnID),
+	}
+	plan.Residual = residualPredicates(filter, plan.Path)
+	return plan
+}
+
+// PlanQueryByReadDate 読取日順に取得する実行計画を作成
+//
+// カーソルページング（ListWithCursor）で使用します。並び順を固定するため、
+// date_range_seek による打ち切りは行いません。運行NOが指定された場合は直接取得します。
+func PlanQueryByReadDate(filter *FilterOptions) *QueryPlan {
+	plan := &QueryPlan{
+		Path:    QueryPathFullScan,
+		OrderBy: fmt.Sprintf("%s DESC, %s DESC", columnReadDate, columnID),
+	}
+	if filter != nil && len(filter.OperationNos) > 0 {
+		plan.Path = QueryPathOperationNoLookup
+		plan.OrderBy = ""
+		plan.Pushdown = append(plan.Pushdown, "operation_nos")
+	}
+	plan.Residual = residualPredicates(filter, plan.Path)
+	return plan
+}
+
+// residualPredicates 取得経路でカバーされず、メモリ上で評価が必要な条件の一覧
+func residualPredicates(filter *FilterOptions, path string) []string {
+	if filter == nil {
+		return nil
+	}
+
+	var residual []string
+	if filter.CarCC != nil {
+		residual = append(residual, "car_cc")
+	}
+	if path != QueryPathOperationNoLookup && len(filter.OperationNos) > 0 {
+		residual = append(residual, "operation_nos")
+	}
+	if path != QueryPathDateRangeSeek && filter.StartDate != nil {
+		residual = append(residual, "start_date")
+	}
+	if filter.EndDate != nil {
+		residual = append(residual, "end_date")
+	}
+	if filter.MinDistance != nil {
+		residual = append(residual, "min_distance")
+	}
+	if filter.ExcludeZeroDistance {
+		residual = append(residual, "exclude_zero_distance")
+	}
+	if filter.DriverCode != nil {
+		residual = append(residual, "driver_code")
+	}
+	return residual
+}
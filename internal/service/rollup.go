@@ -0,0 +1,690 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// rollupSchemaVersion ロールアップの形式バージョン
+//
+// 集計ロジック（行から日次集計への変換）を変更した場合は値を上げてください。
+// バージョンが異なるファイルは読み込まず、再構築されるまで運行データから直接集計します。
+//
+//	1: 初版
+//	2: 運行日を業務タイムゾーン（BUSINESS_TIMEZONE）の日付で集計
+//	3: 日ごとの運行NOを保持（フェリーの見なし距離の集計）
+//	4: 車輌CDを保持（車両マスタとの対応付け）
+//	5: 日付のみの運行日（UTCの0時）を変換せずにその年月日で集計
+const rollupSchemaVersion = 5
+
+// defaultRollupPollInterval 読取日の更新を取り込む間隔のデフォルト
+const defaultRollupPollInterval = time.Minute
+
+// defaultRollupRebuildInterval 全運行データから作り直す間隔のデフォルト
+//
+// 差分の取り込み（Fold）は読取日が更新された行しか取得しないため、削除された行や
+// 読取日を変えずに修正された行は、次に作り直すまでロールアップに残ります。
+const defaultRollupRebuildInterval = 24 * time.Hour
+
+// rollupFetchPageSize 差分取得で1回に取得する行数
+const rollupFetchPageSize = 1000
+
+// RollupDay 車両・日ごとの集計（ロールアップ）
+type RollupDay struct {
+	CarCC         string           `json:"car_cc"`
+	CarCode       int32            `json:"car_code,omitempty"` // 車輌CD（最後に取り込んだ0以外の値）
+	Date          string           `json:"date"`               // 運行日 (YYYY-MM-DD)
+	TotalDistance float64          `json:"total_distance"`
+	TripCount     int32            `json:"trip_count"`
+	Operations    map[string]int32 `json:"operations,omitempty"` // 運行NO → 行数
+}
+
+// rollupRow 取り込み済みの行（同じ行を再取得した場合に差し替えるため保持）
+type rollupRow struct {
+	CarCC       string  `json:"c"`
+	CarCode     int32   `json:"k,omitempty"`
+	Date        string  `json:"d"`
+	Distance    float64 `json:"km"`
+	OperationNo string  `json:"o,omitempty"`
+}
+
+// rollupFile ロールアップのファイル形式 (JSON)
+type rollupFile struct {
+	Version   int                  `json:"version"`
+	BuildID   string               `json:"build_id"`
+	BuiltAt   time.Time            `json:"built_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Watermark string               `json:"watermark"` // 取り込み済みの最新の読取日
+	Days      []*RollupDay         `json:"days"`
+	Rows      map[string]rollupRow `json:"rows"`
+}
+
+// RollupStatus ロールアップの状態
+type RollupStatus struct {
+	Path      string
+	Ready     bool // 構築済みで、集計に使用できる
+	BuildID   string
+	BuiltAt   time.Time
+	UpdatedAt time.Time
+	Watermark string
+	Rows      int
+	Days      int
+	Vehicles  int
+}
+
+// RollupStore 車両・日ごとの集計を保持するストア（ファイル保存）
+//
+// 運行データの行IDごとの寄与を保持しているため、同じ行を複数回取り込んでも
+// 集計は重複しません（走行距離や運行日が変わった行は差し替えます）。
+type RollupStore struct {
+	path string
+
+	mu        sync.RWMutex
+	buildID   string
+	builtAt   time.Time
+	updatedAt time.Time
+	watermark string
+	days      map[string]map[string]*RollupDay // 車輌CC → 運行日 → 集計
+	rows      map[string]rollupRow
+
+	savedModTime time.Time // 最後に読み書きしたファイルの更新日時
+}
+
+// newRollupStore 空のストアを作成（未構築）
+func newRollupStore(path string) *RollupStore {
+	return &RollupStore{
+		path: path,
+		days: make(map[string]map[string]*RollupDay),
+		rows: make(map[string]rollupRow),
+	}
+}
+
+// OpenRollupStore ファイルからストアを読み込む
+//
+// ファイルがない場合、または形式バージョンが異なる場合は未構築のストアを返します。
+func OpenRollupStore(path string) (*RollupStore, error) {
+	store := newRollupStore(path)
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// load ファイルの内容で置き換える
+func (r *RollupStore) load() error {
+	info, err := os.Stat(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("Rollup store %s not found, rebuild required", r.path)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	var file rollupFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse rollup store %s: %w", r.path, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.savedModTime = info.ModTime()
+	if file.Version != rollupSchemaVersion {
+		log.Printf("Rollup store %s has version %d (current %d), rebuild required", r.path, file.Version, rollupSchemaVersion)
+		r.reset()
+		return nil
+	}
+
+	r.buildID = file.BuildID
+	r.builtAt = file.BuiltAt
+	r.updatedAt = file.UpdatedAt
+	r.watermark = file.Watermark
+	r.rows = file.Rows
+	if r.rows == nil {
+		r.rows = make(map[string]rollupRow)
+	}
+	r.days = make(map[string]map[string]*RollupDay)
+	for _, day := range file.Days {
+		if r.days[day.CarCC] == nil {
+			r.days[day.CarCC] = make(map[string]*RollupDay)
+		}
+		r.days[day.CarCC][day.Date] = day
+	}
+
+	log.Printf("Loaded rollup store %s: %d rows, watermark=%s", r.path, len(r.rows), r.watermark)
+	return nil
+}
+
+// reset 未構築の状態に戻す（r.mu を保持して呼び出す）
+func (r *RollupStore) reset() {
+	r.buildID = ""
+	r.builtAt = time.Time{}
+	r.updatedAt = time.Time{}
+	r.watermark = ""
+	r.days = make(map[string]map[string]*RollupDay)
+	r.rows = make(map[string]rollupRow)
+}
+
+// Ready 構築済みで集計に使用できるか
+func (r *RollupStore) Ready() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.buildID != ""
+}
+
+// BuiltAt 全運行データから構築した日時（未構築の場合はゼロ値）
+func (r *RollupStore) BuiltAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.builtAt
+}
+
+// Watermark 取り込み済みの最新の読取日
+func (r *RollupStore) Watermark() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.watermark
+}
+
+// Fold 運行データの行を取り込む
+//
+// 取り込み済みの行は差し替え、運行日がパースできない行は除外します。
+// 戻り値は集計が変わった行数です。
+//
+// 渡された行しか反映しないため、db_service から削除された行や、読取日を変えずに
+// 修正された行（差分の取得対象にならない行）は反映されません。これらは
+// RollupUpdater の定期的な作り直し（ROLLUP_REBUILD_INTERVAL）で解消します。
+func (r *RollupStore) Fold(rows []*dbpb.Db_DTakoRows) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := 0
+	for _, row := range rows {
+		if r.watermark == "" || compareReadDates(row.ReadDate, r.watermark) > 0 {
+			r.watermark = row.ReadDate
+		}
+
+		old, exists := r.rows[row.Id]
+		opDate, ok := parseOperationDate(row)
+		if !ok {
+			if exists {
+				r.subtract(old)
+				delete(r.rows, row.Id)
+				changed++
+			}
+			continue
+		}
+
+		contribution := rollupRow{
+			CarCC:       row.CarCc,
+			CarCode:     row.CarCode,
+			Date:        opDate.Format("2006-01-02"),
+			Distance:    row.TotalDistance,
+			OperationNo: row.OperationNo,
+		}
+		if exists {
+			if old == contribution {
+				continue
+			}
+			r.subtract(old)
+		}
+		r.add(contribution)
+		r.rows[row.Id] = contribution
+		changed++
+	}
+
+	if changed > 0 {
+		r.updatedAt = time.Now()
+	}
+	return changed
+}
+
+// add 行の寄与を日次集計に加算（r.mu を保持して呼び出す）
+func (r *RollupStore) add(c rollupRow) {
+	if r.days[c.CarCC] == nil {
+		r.days[c.CarCC] = make(map[string]*RollupDay)
+	}
+	day := r.days[c.CarCC][c.Date]
+	if day == nil {
+		day = &RollupDay{CarCC: c.CarCC, Date: c.Date}
+		r.days[c.CarCC][c.Date] = day
+	}
+	day.TotalDistance += c.Distance
+	day.TripCount++
+	if c.CarCode != 0 {
+		day.CarCode = c.CarCode
+	}
+	if c.OperationNo != "" {
+		if day.Operations == nil {
+			day.Operations = make(map[string]int32)
+		}
+		day.Operations[c.OperationNo]++
+	}
+}
+
+// subtract 行の寄与を日次集計から減算（r.mu を保持して呼び出す）
+func (r *RollupStore) subtract(c rollupRow) {
+	day := r.days[c.CarCC][c.Date]
+	if day == nil {
+		return
+	}
+	day.TotalDistance -= c.Distance
+	day.TripCount--
+	if c.OperationNo != "" {
+		day.Operations[c.OperationNo]--
+		if day.Operations[c.OperationNo] <= 0 {
+			delete(day.Operations, c.OperationNo)
+		}
+	}
+	if day.TripCount <= 0 {
+		delete(r.days[c.CarCC], c.Date)
+		if len(r.days[c.CarCC]) == 0 {
+			delete(r.days, c.CarCC)
+		}
+	}
+}
+
+// Days 期間内の日次集計を取得（車輌CC・運行日順の複製）
+//
+// carCC が空の場合は全車両を返します。
+func (r *RollupStore) Days(carCC string, start, end time.Time) []*RollupDay {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	var days []*RollupDay
+	for car, byDate := range r.days {
+		if carCC != "" && car != carCC {
+			continue
+		}
+		for date, day := range byDate {
+			if date < from || date > to {
+				continue
+			}
+			copied := *day
+			if day.Operations != nil {
+				copied.Operations = make(map[string]int32, len(day.Operations))
+				for operationNo, count := range day.Operations {
+					copied.Operations[operationNo] = count
+				}
+			}
+			days = append(days, &copied)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].CarCC != days[j].CarCC {
+			return days[i].CarCC < days[j].CarCC
+		}
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// Status 状態を取得
+func (r *RollupStore) Status() RollupStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	days := 0
+	for _, byDate := range r.days {
+		days += len(byDate)
+	}
+	return RollupStatus{
+		Path:      r.path,
+		Ready:     r.buildID != "",
+		BuildID:   r.buildID,
+		BuiltAt:   r.builtAt,
+		UpdatedAt: r.updatedAt,
+		Watermark: r.watermark,
+		Rows:      len(r.rows),
+		Days:      days,
+		Vehicles:  len(r.days),
+	}
+}
+
+// markBuilt 全件の取り込みが完了したことを記録
+func (r *RollupStore) markBuilt() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		id = []byte(time.Now().Format("20060102150405"))
+	}
+	r.buildID = hex.EncodeToString(id)
+	r.builtAt = time.Now()
+	r.updatedAt = r.builtAt
+}
+
+// replaceWith 再構築したストアの内容で置き換える
+func (r *RollupStore) replaceWith(built *RollupStore) {
+	built.mu.RLock()
+	defer built.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buildID = built.buildID
+	r.builtAt = built.builtAt
+	r.updatedAt = built.updatedAt
+	r.watermark = built.watermark
+	r.days = built.days
+	r.rows = built.rows
+}
+
+// Save ファイルに保存（一時ファイルに書き込んでから置き換える）
+func (r *RollupStore) Save() error {
+	r.mu.RLock()
+	file := rollupFile{
+		Version:   rollupSchemaVersion,
+		BuildID:   r.buildID,
+		BuiltAt:   r.builtAt,
+		UpdatedAt: r.updatedAt,
+		Watermark: r.watermark,
+		Rows:      r.rows,
+	}
+	for _, byDate := range r.days {
+		for _, day := range byDate {
+			file.Days = append(file.Days, day)
+		}
+	}
+	sort.Slice(file.Days, func(i, j int) bool {
+		if file.Days[i].CarCC != file.Days[j].CarCC {
+			return file.Days[i].CarCC < file.Days[j].CarCC
+		}
+		return file.Days[i].Date < file.Days[j].Date
+	})
+	data, err := json.Marshal(file)
+	r.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(r.path); err == nil {
+		r.mu.Lock()
+		r.savedModTime = info.ModTime()
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// reloadIfChanged 他のプロセス（再構築コマンド）がファイルを書き換えていれば読み込み直す
+func (r *RollupStore) reloadIfChanged() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return nil
+	}
+	r.mu.RLock()
+	changed := !info.ModTime().Equal(r.savedModTime)
+	r.mu.RUnlock()
+	if !changed {
+		return nil
+	}
+	log.Printf("Rollup store %s was modified externally, reloading", r.path)
+	return r.load()
+}
+
+// BuildRollups 全運行データからロールアップを構築
+//
+// 運行データを読取日降順に全件取得して取り込みます。path には保存先を指定します（保存は呼び出し元）。
+func (s *DtakoRowsService) BuildRollups(ctx context.Context, path string) (*RollupStore, error) {
+	store := newRollupStore(path)
+	plan := PlanQuery(nil)
+	log.Printf("BuildRollups: %s", plan)
+
+	totalFetched, err := s.scan(ctx, nil, plan, func(rows []*dbpb.Db_DTakoRows) error {
+		store.Fold(rows)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	store.markBuilt()
+
+	status := store.Status()
+	log.Printf("Built rollups from %d rows: %d vehicles, %d days, watermark=%s",
+		totalFetched, status.Vehicles, status.Days, status.Watermark)
+	return store, nil
+}
+
+// RowsReadSince 読取日が readDate 以降の行を取得
+//
+// 読取日降順に取得し、readDate より前の行が現れた時点で打ち切ります。
+// 同じ読取日の行が後から追加される場合に備えて readDate と同じ行も含めます。
+func (s *DtakoRowsService) RowsReadSince(ctx context.Context, readDate string) ([]*dbpb.Db_DTakoRows, error) {
+	var rows []*dbpb.Db_DTakoRows
+	fetcher := NewPageFetcher(s.dbClient, rollupFetchPageSize, 1)
+	_, err := fetcher.Fetch(ctx, PlanQueryByReadDate(nil).OrderBy, func(items []*dbpb.Db_DTakoRows) error {
+		for _, item := range items {
+			if compareReadDates(item.ReadDate, readDate) < 0 {
+				return errStopScan
+			}
+			rows = append(rows, item)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// RollupUpdater ロールアップをバックグラウンドで更新する
+//
+// interval ごとに読取日が取り込み済みの最新の読取日以降の行を取得して取り込みます。
+// ストアが未構築の場合、または最後の構築から rebuildInterval 以上経った場合は全件から構築します。
+type RollupUpdater struct {
+	service         *DtakoRowsService
+	store           *RollupStore
+	interval        time.Duration
+	rebuildInterval time.Duration // 全運行データから作り直す間隔
+}
+
+// NewRollupUpdater 更新ジョブの作成
+//
+// 作り直す間隔は環境変数 ROLLUP_REBUILD_INTERVAL（既定24時間）で変更できます。
+func NewRollupUpdater(service *DtakoRowsService, store *RollupStore, interval time.Duration) *RollupUpdater {
+	if interval <= 0 {
+		interval = defaultRollupPollInterval
+	}
+	return &RollupUpdater{
+		service:         service,
+		store:           store,
+		interval:        interval,
+		rebuildInterval: durationFromEnv("ROLLUP_REBUILD_INTERVAL", defaultRollupRebuildInterval),
+	}
+}
+
+// Start バックグラウンドで更新を開始（ctx がキャンセルされると終了）
+//
+// 戻り値は更新ジョブが終了したときに閉じられるチャネルです。
+func (u *RollupUpdater) Start(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(u.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := u.Update(ctx); err != nil {
+				log.Printf("Rollup update failed: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+// Update 1回分の更新（未構築・作り直しの時期なら構築、それ以外は差分の取り込み）
+func (u *RollupUpdater) Update(ctx context.Context) error {
+	if err := u.store.reloadIfChanged(); err != nil {
+		return err
+	}
+
+	if !u.store.Ready() || time.Since(u.store.BuiltAt()) >= u.rebuildInterval {
+		built, err := u.service.BuildRollups(ctx, u.store.path)
+		if err != nil {
+			return err
+		}
+		u.store.replaceWith(built)
+		return u.store.Save()
+	}
+
+	watermark := u.store.Watermark()
+	rows, err := u.service.RowsReadSince(ctx, watermark)
+	if err != nil {
+		return err
+	}
+	changed := u.store.Fold(rows)
+	if changed == 0 {
+		return nil
+	}
+	log.Printf("Rollup: folded %d changed rows since %s", changed, watermark)
+	return u.store.Save()
+}
+
+// EnableRollupsFromEnv 環境変数 ROLLUP_STORE_PATH が設定されていればロールアップを有効にする
+//
+// ストアを読み込み、ROLLUP_POLL_INTERVAL ごとに更新するジョブを開始します。
+// 構築が完了するまでは運行データから直接集計します。
+// 更新ジョブは ctx がキャンセルされるか Close を呼び出すと終了します。
+func (s *DtakoRowsService) EnableRollupsFromEnv(ctx context.Context) {
+	path := os.Getenv("ROLLUP_STORE_PATH")
+	if path == "" {
+		return
+	}
+
+	store, err := OpenRollupStore(path)
+	if err != nil {
+		log.Printf("Warning: failed to open rollup store, aggregating from rows: %v", err)
+		return
+	}
+	s.rollups = store
+
+	interval := durationFromEnv("ROLLUP_POLL_INTERVAL", defaultRollupPollInterval)
+	ctx, cancel := context.WithCancel(ctx)
+	done := NewRollupUpdater(s, store, interval).Start(ctx)
+	s.stopRollups = func() {
+		cancel()
+		<-done
+	}
+	log.Printf("Rollup store enabled: path=%s, poll_interval=%s", path, interval)
+}
+
+// Close バックグラウンドのロールアップの更新ジョブを停止（実行中の更新は終了を待つ）
+func (s *DtakoRowsService) Close() {
+	if s.stopRollups != nil {
+		s.stopRollups()
+		s.stopRollups = nil
+	}
+}
+
+// rollupDays ロールアップが使用できれば期間内の日次集計を返す
+//
+// ロールアップが無効・未構築、または期間がパースできない場合は false を返します
+// （運行データから直接集計し、期間のエラーはそちらで返します）。
+func (s *DtakoRowsService) rollupDays(carCC, startDate, endDate string) ([]*RollupDay, bool) {
+	if s.rollups == nil || !s.rollups.Ready() {
+		return nil, false
+	}
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, false
+	}
+	days := s.rollups.Days(carCC, start, end)
+	log.Printf("Using rollups: car_cc=%s, %d days (watermark=%s)", carCC, len(days), s.rollups.Watermark())
+	return days, true
+}
+
+// summarizeRollupDays 日次集計を集計期間ごとのサマリーに変換
+//
+// 運行データから集計する場合と同様に、車両ごとの燃費と実給油データを適用します。
+// 戻り値は車輌CC → 期間 → サマリーです（finalizeFuel 適用済み）。
+func (s *DtakoRowsService) summarizeRollupDays(ctx context.Context, days []*RollupDay, refuels map[string][]*RefuelRecord, periods BucketRange) map[string]map[string]*MonthlyFuelSummary {
+	data := make(map[string]map[string]*MonthlyFuelSummary)
+	efficiencies := make(map[string]FuelEfficiency)
+	ferries := s.newFerryTally(ctx)
+
+	vehicle := func(carCC string, carCode int32) map[string]*MonthlyFuelSummary {
+		if _, exists := data[carCC]; !exists {
+			data[carCC] = make(map[string]*MonthlyFuelSummary)
+			efficiencies[carCC] = s.resolveFuelEfficiency(ctx, carCC, carCode)
+		}
+		return data[carCC]
+	}
+
+	for _, day := range days {
+		date, err := parseBusinessDate(day.Date)
+		if err != nil {
+			continue
+		}
+		bucket := periods.Bucket(date)
+		periodData := vehicle(day.CarCC, day.CarCode)
+		if _, exists := periodData[bucket.Key]; !exists {
+			periodData[bucket.Key] = newPeriodSummary(day.CarCC, bucket, efficiencies[day.CarCC])
+		}
+		if day.CarCode != 0 {
+			periodData[bucket.Key].CarCode = day.CarCode
+		}
+		periodData[bucket.Key].addDistance(date, day.TotalDistance)
+		periodData[bucket.Key].TripCount += day.TripCount
+		for operationNo := range day.Operations {
+			periodData[bucket.Key].FerryDistance += ferries.deemedDistance(operationNo)
+		}
+	}
+
+	// 運行のない車両・期間の実給油データも集計する
+	for carCC, carRefuels := range refuels {
+		applyRefuels(vehicle(carCC, 0), carRefuels, periods, carCC, efficiencies[carCC])
+	}
+
+	for _, periodData := range data {
+		for _, summary := range periodData {
+			finalizeFuel(summary)
+		}
+	}
+	return data
+}
+
+// sortedSummaries 期間ごとのサマリーを期間順の配列に変換
+func sortedSummaries(periodData map[string]*MonthlyFuelSummary) []*MonthlyFuelSummary {
+	results := make([]*MonthlyFuelSummary, 0, len(periodData))
+	for _, summary := range periodData {
+		results = append(results, summary)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].YearMonth < results[j].YearMonth
+	})
+	return results
+}
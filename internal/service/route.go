@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"github.com/yhonda-ohishi/dtako_rows/v3/internal/export"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GPS座標の単位
+const (
+	GPSUnitAuto        = "auto"        // 経度の大きさから判定（|経度| > 180,000,000 はミリ秒、それ以外は1/1,000,000度）
+	GPSUnitMillisecond = "msec"        // 1/1000秒
+	GPSUnitMicrodegree = "microdegree" // 1/1,000,000度
+)
+
+// GPS座標の測地系
+const (
+	GPSDatumWGS84 = "wgs84" // 世界測地系（変換なし）
+	GPSDatumTokyo = "tokyo" // 日本測地系（WGS84に変換）
+)
+
+// earthRadiusKm 地球の平均半径 (km)
+const earthRadiusKm = 6371.0
+
+// gpsConverter イベントデータのGPS座標（整数）をWGS84の度に変換
+type gpsConverter struct {
+	unit  string
+	datum string
+}
+
+// gpsConverterFromEnv 環境変数 GPS_COORDINATE_UNIT・GPS_DATUM から変換方法を決定
+func gpsConverterFromEnv() gpsConverter {
+	c := gpsConverter{unit: GPSUnitAuto, datum: GPSDatumWGS84}
+
+	switch value := strings.ToLower(os.Getenv("GPS_COORDINATE_UNIT")); value {
+	case "":
+	case GPSUnitAuto, GPSUnitMillisecond, GPSUnitMicrodegree:
+		c.unit = value
+	default:
+		log.Printf("Warning: invalid GPS_COORDINATE_UNIT=%q, using %s", value, GPSUnitAuto)
+	}
+
+	switch value := strings.ToLower(os.Getenv("GPS_DATUM")); value {
+	case "":
+	case GPSDatumWGS84, GPSDatumTokyo:
+		c.datum = value
+	default:
+		log.Printf("Warning: invalid GPS_DATUM=%q, using %s", value, GPSDatumWGS84)
+	}
+	return c
+}
+
+// convert 整数の緯度・経度をWGS84の度に変換（範囲外の場合は false）
+func (c gpsConverter) convert(latitude, longitude int64) (float64, float64, bool) {
+	if latitude == 0 && longitude == 0 {
+		return 0, 0, false
+	}
+
+	unit := c.unit
+	if unit == GPSUnitAuto {
+		unit = GPSUnitMicrodegree
+		if longitude > 180_000_000 || longitude < -180_000_000 {
+			unit = GPSUnitMillisecond
+		}
+	}
+
+	var lat, lon float64
+	switch unit {
+	case GPSUnitMillisecond:
+		lat = float64(latitude) / 3_600_000
+		lon = float64(longitude) / 3_600_000
+	default:
+		lat = float64(latitude) / 1_000_000
+		lon = float64(longitude) / 1_000_000
+	}
+
+	if c.datum == GPSDatumTokyo {
+		lat, lon = tokyoToWGS84(lat, lon)
+	}
+
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// tokyoToWGS84 日本測地系の緯度・経度を世界測地系に変換（国土地理院の近似式、誤差は数m程度）
+func tokyoToWGS84(lat, lon float64) (float64, float64) {
+	wgsLat := lat - 0.00010695*lat + 0.000017464*lon + 0.0046017
+	wgsLon := lon - 0.000046038*lat - 0.000083043*lon + 0.010040
+	return wgsLat, wgsLon
+}
+
+// haversineKm 2地点間の大円距離 (km)
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// RoutePoint 経路上の地点（イベントの開始・終了位置）
+type RoutePoint struct {
+	Time      time.Time // 通過日時（パースできない場合はゼロ値）
+	Latitude  float64   // 緯度（WGS84、度）
+	Longitude float64   // 経度（WGS84、度）
+	PlaceName string    // 場所名（未設定の場合は市町村名）
+	CityName  string    // 市町村名
+	EventCode int32     // イベントCD（未設定の場合は-1）
+	EventName string
+	Edge      string  // start（イベントの開始位置） / end（イベントの終了位置）
+	Mileage   float64 // 走行距離計 (km)
+}
+
+// TripRoute 運行の経路
+type TripRoute struct {
+	OperationNo  string
+	CarCC        string
+	Points       []*RoutePoint // 通過順
+	EventCount   int32         // イベントの件数
+	DroppedCount int32         // GPSが無効・範囲外のため除いた位置の数
+	Available    bool          // イベントデータを参照できたか
+}
+
+// Distance 地点間の直線距離の合計 (km)
+func (r *TripRoute) Distance() float64 {
+	var distance float64
+	for i := 1; i < len(r.Points); i++ {
+		a, b := r.Points[i-1], r.Points[i]
+		distance += haversineKm(a.Latitude, a.Longitude, b.Latitude, b.Longitude)
+	}
+	return distance
+}
+
+// GeoPoints ファイル出力用の地点
+func (r *TripRoute) GeoPoints() []export.GeoPoint {
+	points := make([]export.GeoPoint, len(r.Points))
+	for i, p := range r.Points {
+		description := p.EventName
+		if p.Edge != "" && description != "" {
+			description += " (" + p.Edge + ")"
+		}
+		points[i] = export.GeoPoint{
+			Latitude:    p.Latitude,
+			Longitude:   p.Longitude,
+			Time:        p.Time,
+			Name:        p.PlaceName,
+			Description: description,
+		}
+	}
+	return points
+}
+
+// GetTripRoute 運行NOのイベントのGPS位置から経路を復元
+//
+// イベントを開始日時順に並べ、各イベントの開始位置・終了位置を通過順の地点とします。
+// GPS有効フラグ（start_gps_valid / end_gps_valid）が0・未設定の位置、変換後に範囲外となる位置は除きます。
+// 直前の地点と同じ位置・日時の地点（前のイベントの終了 = 次のイベントの開始）は1つにまとめます。
+func (s *DtakoRowsService) GetTripRoute(ctx context.Context, operationNo string) (*TripRoute, error) {
+	log.Printf("GetTripRoute: operation_no=%s", operationNo)
+
+	if operationNo == "" {
+		return nil, status.Error(codes.InvalidArgument, "operation_no is required")
+	}
+
+	route := &TripRoute{OperationNo: operationNo, Available: s.events.Available()}
+	if !route.Available {
+		log.Printf("Warning: events client is not configured, trip route is not available")
+		return route, nil
+	}
+
+	events, err := s.events.ByOperation(ctx, operationNo)
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Printf("Failed to get events for operation %s: %v", operationNo, err)
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no events found for operation_no %s", operationNo)
+	}
+
+	converter := gpsConverterFromEnv()
+	for _, event := range events {
+		route.EventCount++
+		if route.CarCC == "" {
+			route.CarCC = event.CarCc
+		}
+
+		start := routePoint(converter, event, "start")
+		end := routePoint(converter, event, "end")
+		for _, point := range []*RoutePoint{start, end} {
+			if point == nil {
+				route.DroppedCount++
+				continue
+			}
+			if n := len(route.Points); n > 0 && sameRoutePoint(route.Points[n-1], point) {
+				continue
+			}
+			route.Points = append(route.Points, point)
+		}
+	}
+
+	if route.DroppedCount > 0 {
+		log.Printf("Dropped %d invalid GPS positions for operation %s", route.DroppedCount, operationNo)
+	}
+	log.Printf("Reconstructed route with %d points for operation %s", len(route.Points), operationNo)
+	return route, nil
+}
+
+// routePoint イベントの開始・終了位置（GPSが無効・範囲外の場合は nil）
+func routePoint(converter gpsConverter, event *dbpb.Db_DTakoEvents, edge string) *RoutePoint {
+	var (
+		valid               *int32
+		latitude, longitude *int64
+		datetime            string
+		placeName, cityName string
+		mileage             float64
+	)
+	if edge == "start" {
+		valid, latitude, longitude = event.StartGpsValid, event.StartGpsLatitude, event.StartGpsLongitude
+		datetime, placeName, cityName, mileage = event.StartDatetime, event.StartPlaceName, event.StartCityName, event.StartMileage
+	} else {
+		valid, latitude, longitude = event.EndGpsValid, event.EndGpsLatitude, event.EndGpsLongitude
+		datetime, placeName, cityName, mileage = event.EndDatetime, event.EndPlaceName, event.EndCityName, event.EndMileage
+	}
+
+	if valid == nil || *valid == 0 || latitude == nil || longitude == nil {
+		return nil
+	}
+	lat, lon, ok := converter.convert(*latitude, *longitude)
+	if !ok {
+		return nil
+	}
+
+	point := &RoutePoint{
+		Latitude:  lat,
+		Longitude: lon,
+		PlaceName: placeName,
+		CityName:  cityName,
+		EventCode: -1,
+		EventName: event.EventName,
+		Edge:      edge,
+		Mileage:   mileage,
+	}
+	if point.PlaceName == "" {
+		point.PlaceName = cityName
+	}
+	if event.EventCode != nil {
+		point.EventCode = *event.EventCode
+	}
+	if t, err := time.Parse(time.RFC3339, datetime); err == nil {
+		point.Time = t
+	}
+	return point
+}
+
+// sameRoutePoint 同じ位置・日時の地点か
+func sameRoutePoint(a, b *RoutePoint) bool {
+	return a.Latitude == b.Latitude && a.Longitude == b.Longitude && a.Time.Equal(b.Time)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+	_ "time/tzdata" // タイムゾーンデータベースのないWindows環境でも Asia/Tokyo を読み込めるようにする
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultBusinessTimezone 業務上のタイムゾーンのデフォルト
+const defaultBusinessTimezone = "Asia/Tokyo"
+
+var (
+	businessLocationOnce sync.Once
+	businessLocation     *time.Location
+)
+
+// BusinessLocation 業務上のタイムゾーン
+//
+// 運行日の日付・月の判定と、リクエストの開始日・終了日の解釈に使用します。
+// 環境変数 BUSINESS_TIMEZONE（IANAのタイムゾーン名）で変更でき、
+// 未設定・不正な場合は Asia/Tokyo です。
+func BusinessLocation() *time.Location {
+	businessLocationOnce.Do(func() {
+		name := os.Getenv("BUSINESS_TIMEZONE")
+		if name == "" {
+			name = defaultBusinessTimezone
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Warning: invalid BUSINESS_TIMEZONE=%q, using %s: %v", name, defaultBusinessTimezone, err)
+			loc, _ = time.LoadLocation(defaultBusinessTimezone)
+		}
+		businessLocation = loc
+	})
+	return businessLocation
+}
+
+// parseBusinessDate 日付 (YYYY-MM-DD) を業務タイムゾーンのその日の0時としてパース
+func parseBusinessDate(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, BusinessLocation())
+}
+
+// parseOperationDateValue 運行日 (RFC3339) をパースし、業務タイムゾーンの時刻にする
+//
+// db_service は DATE 型の運行日を UTC の0時（"2024-01-31T00:00:00Z"）として出力するため、
+// 時刻が0時の値は文字列の年月日をそのまま業務タイムゾーンの0時とします
+// （UTCより遅れたタイムゾーンで前日に変換されないようにするため）。
+// 時刻を含む値は業務タイムゾーンに変換します。
+func parseOperationDateValue(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, BusinessLocation()), nil
+	}
+	return t.In(BusinessLocation()), nil
+}
+
+// endOfBusinessDay 業務タイムゾーンでのその日の最終時刻（翌日0時の直前）
+//
+// 夏時間の切り替わる日（23時間・25時間の日）も翌日0時を基準にするため、
+// 終了日を指定した場合はその日の運行をすべて含みます。
+func endOfBusinessDay(t time.Time) time.Time {
+	t = t.In(BusinessLocation())
+	next := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	return next.Add(-time.Nanosecond)
+}
+
+// datetimeFilterRange 開始日の0時〜終了日の最終時刻を db_service の日時フィルタ（RFC3339）にする
+//
+// db_service は StartDate/EndDate を RFC3339 としてパースし、パースできない値は
+// 無視する（または0時刻として扱う）ため、日付のみの文字列は使えません。
+func datetimeFilterRange(start, end time.Time) (string, string) {
+	return startOfBusinessDay(start).Format(time.RFC3339), endOfBusinessDay(end).Format(time.RFC3339)
+}
+
+// startOfBusinessDay 業務タイムゾーンでのその日の0時
+func startOfBusinessDay(t time.Time) time.Time {
+	t = t.In(BusinessLocation())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// parseStartDate 開始日 (YYYY-MM-DD) をパース（その日の0時から）
+func parseStartDate(value string) (time.Time, error) {
+	start, err := parseBusinessDate(value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid start_date format: %v", err)
+	}
+	return start, nil
+}
+
+// parseEndDate 終了日 (YYYY-MM-DD) をパース（その日の終わりまでを含む）
+func parseEndDate(value string) (time.Time, error) {
+	end, err := parseBusinessDate(value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid end_date format: %v", err)
+	}
+	return endOfBusinessDay(end), nil
+}
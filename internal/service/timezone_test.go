@@ -0,0 +1,192 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// withBusinessTimezone テスト中の業務タイムゾーンを変更
+func withBusinessTimezone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	t.Setenv("BUSINESS_TIMEZONE", name)
+	resetBusinessLocation()
+	t.Cleanup(resetBusinessLocation)
+
+	loc := BusinessLocation()
+	if loc.String() != name {
+		t.Fatalf("BusinessLocation() = %s, want %s", loc, name)
+	}
+	return loc
+}
+
+// resetBusinessLocation 業務タイムゾーンを環境変数から読み込み直す
+func resetBusinessLocation() {
+	businessLocationOnce = sync.Once{}
+	businessLocation = nil
+}
+
+// mustOperationDate 運行日の文字列をパース（失敗した場合はテストを中断）
+func mustOperationDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	opDate, ok := parseOperationDate(&dbpb.Db_DTakoRows{Id: "test", OperationDate: value})
+	if !ok {
+		t.Fatalf("parseOperationDate(%q) failed", value)
+	}
+	return opDate
+}
+
+// TestParseDateRangeIncludesWholeEndDay 開始日は0時から、終了日はその日の終わりまでを含む
+func TestParseDateRangeIncludesWholeEndDay(t *testing.T) {
+	loc := withBusinessTimezone(t, "Asia/Tokyo")
+
+	start, end, err := parseDateRange("2024-01-01", "2024-01-31")
+	if err != nil {
+		t.Fatalf("parseDateRange: %v", err)
+	}
+
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, loc); !start.Equal(want) {
+		t.Errorf("start = %s, want %s", start, want)
+	}
+	if want := time.Date(2024, 2, 1, 0, 0, 0, 0, loc).Add(-time.Nanosecond); !end.Equal(want) {
+		t.Errorf("end = %s, want %s", end, want)
+	}
+
+	lastTrip := mustOperationDate(t, "2024-01-31T23:30:00+09:00")
+	if lastTrip.After(end) {
+		t.Errorf("trip at %s is after end %s", lastTrip, end)
+	}
+	nextTrip := mustOperationDate(t, "2024-02-01T00:30:00+09:00")
+	if !nextTrip.After(end) {
+		t.Errorf("trip at %s is not after end %s", nextTrip, end)
+	}
+}
+
+// TestParseDateRangeInvalidFormat 不正な日付は InvalidArgument
+func TestParseDateRangeInvalidFormat(t *testing.T) {
+	withBusinessTimezone(t, "Asia/Tokyo")
+
+	tests := []struct {
+		name       string
+		start, end string
+	}{
+		{"start", "2024/01/01", "2024-01-31"},
+		{"end", "2024-01-01", "2024-01-32"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseDateRange(tt.start, tt.end)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("parseDateRange(%q, %q) error = %v, want InvalidArgument", tt.start, tt.end, err)
+			}
+		})
+	}
+}
+
+// TestMonthlyBucketAtMonthBoundary 月末 23:30・月初 00:30 の運行が業務タイムゾーンの月に集計される
+func TestMonthlyBucketAtMonthBoundary(t *testing.T) {
+	withBusinessTimezone(t, "Asia/Tokyo")
+
+	start, end, err := parseDateRange("2024-01-01", "2024-02-29")
+	if err != nil {
+		t.Fatalf("parseDateRange: %v", err)
+	}
+	buckets := MonthlyBucketing().Range(start, end)
+
+	tests := []struct {
+		operationDate string
+		want          string
+	}{
+		{"2024-01-31T23:30:00+09:00", "2024-01"},
+		{"2024-02-01T00:30:00+09:00", "2024-02"},
+		{"2024-01-31T14:30:00Z", "2024-01"}, // 日本時間 2024-01-31 23:30
+		{"2024-01-31T15:30:00Z", "2024-02"}, // 日本時間 2024-02-01 00:30
+		{"2024-01-31T00:00:00Z", "2024-01"}, // DATE 型の運行日
+		{"2024-02-01T00:00:00Z", "2024-02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationDate, func(t *testing.T) {
+			opDate := mustOperationDate(t, tt.operationDate)
+			if got := buckets.Bucket(opDate).Key; got != tt.want {
+				t.Errorf("bucket key = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDateOnlyOperationDateInNegativeOffsetZone UTCより遅れたタイムゾーンでも DATE 型の運行日が前日にならない
+func TestDateOnlyOperationDateInNegativeOffsetZone(t *testing.T) {
+	loc := withBusinessTimezone(t, "America/New_York")
+
+	tests := []struct {
+		operationDate string
+		wantDate      string
+		wantMonth     string
+	}{
+		{"2024-11-01T00:00:00Z", "2024-11-01", "2024-11"},
+		{"2024-03-01T00:00:00Z", "2024-03-01", "2024-03"},
+		{"2024-04-01T03:30:00Z", "2024-03-31", "2024-03"}, // 時刻を含む値は変換する（EDT 23:30）
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationDate, func(t *testing.T) {
+			opDate := mustOperationDate(t, tt.operationDate)
+			if opDate.Location() != loc {
+				t.Errorf("location = %s, want %s", opDate.Location(), loc)
+			}
+			if got := opDate.Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("date = %s, want %s", got, tt.wantDate)
+			}
+			if got := MonthlyBucketing().Of(opDate).Key; got != tt.wantMonth {
+				t.Errorf("month = %s, want %s", got, tt.wantMonth)
+			}
+		})
+	}
+}
+
+// TestDaylightSavingDaysAreWhole 夏時間の切り替わる日（23時間・25時間）も1日すべてを含む
+func TestDaylightSavingDaysAreWhole(t *testing.T) {
+	withBusinessTimezone(t, "America/New_York")
+
+	tests := []struct {
+		name      string
+		date      string
+		hours     float64
+		lastTrip  string // その日の 23:30（UTC）
+		nextTrip  string // 翌日の 00:30（UTC）
+		wantStart string
+	}{
+		{"spring forward", "2024-03-10", 23, "2024-03-11T03:30:00Z", "2024-03-11T04:30:00Z", "2024-03-10T05:00:00Z"},
+		{"fall back", "2024-11-03", 25, "2024-11-04T04:30:00Z", "2024-11-04T05:30:00Z", "2024-11-03T04:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseDateRange(tt.date, tt.date)
+			if err != nil {
+				t.Fatalf("parseDateRange: %v", err)
+			}
+			if got := start.UTC().Format(time.RFC3339); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := end.Add(time.Nanosecond).Sub(start).Hours(); got != tt.hours {
+				t.Errorf("day length = %vh, want %vh", got, tt.hours)
+			}
+
+			buckets := DailyBucketing().Range(start, end)
+			lastTrip := mustOperationDate(t, tt.lastTrip)
+			if lastTrip.Before(start) || lastTrip.After(end) {
+				t.Errorf("trip at %s is outside %s〜%s", lastTrip, start, end)
+			}
+			if got := buckets.Bucket(lastTrip).Key; got != tt.date {
+				t.Errorf("bucket key of %s = %s, want %s", tt.lastTrip, got, tt.date)
+			}
+			nextTrip := mustOperationDate(t, tt.nextTrip)
+			if !nextTrip.After(end) {
+				t.Errorf("trip at %s is not after end %s", nextTrip, end)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+)
+
+// データ品質チェックの検出項目
+const (
+	ValidationCodeInvalidDate           = "invalid_date"            // RFC3339としてパースできない日時
+	ValidationCodeReturnBeforeDeparture = "return_before_departure" // 帰庫日時が出庫日時より前
+	ValidationCodeOverlappingTrip       = "overlapping_trip"        // 同一車両で運行期間が重複
+	ValidationCodeMeterDiscontinuity    = "meter_discontinuity"     // 出庫メーターが前回運行の帰庫メーターと不一致
+	ValidationCodeDistanceMismatch      = "distance_mismatch"       // 総走行距離がメーター差と不一致
+)
+
+// データ品質チェックの重大度
+const (
+	ValidationSeverityError   = "error"   // 集計から除外される、または明らかに不正なデータ
+	ValidationSeverityWarning = "warning" // 集計には含まれるが確認が必要なデータ
+)
+
+// メーター比較の許容誤差のデフォルト (km)
+const (
+	defaultMeterToleranceKm    = 1.0
+	defaultDistanceToleranceKm = 1.0
+)
+
+// ValidationOptions データ品質チェックのオプション
+type ValidationOptions struct {
+	MeterToleranceKm    float64 // 出庫メーターと前回帰庫メーターの許容差（0以下でデフォルト）
+	DistanceToleranceKm float64 // 総走行距離とメーター差の許容差（0以下でデフォルト）
+}
+
+// ValidationIssue データ品質の問題
+type ValidationIssue struct {
+	Code     string   // 検出項目 (ValidationCode*)
+	Severity string   // 重大度 (ValidationSeverity*)
+	CarCC    string   // 車輌CC
+	RowIDs   []string // 該当する運行データID
+	Field    string   // 該当フィールド
+	Message  string   // 説明
+}
+
+// ValidationReport データ品質チェックの結果
+type ValidationReport struct {
+	Issues          []*ValidationIssue
+	RowsChecked     int32
+	VehiclesChecked int32
+	ErrorCount      int32
+	WarningCount    int32
+}
+
+// validationTrip 時系列チェック用の運行
+type validationTrip struct {
+	row       *dbpb.Db_DTakoRows
+	departure time.Time
+	ret       time.Time
+}
+
+// ValidateRows 車両ごとに運行データを時系列順にチェック
+//
+// carCC が空の場合は全車両をチェックします。
+// 運行日がパースできない行も対象に含めるため、集計RPCで除外されている行を確認できます。
+func (s *DtakoRowsService) ValidateRows(ctx context.Context, carCC string, startDate, endDate string, opts ValidationOptions) (*ValidationReport, error) {
+	log.Printf("ValidateRows: car_cc=%s, start=%s, end=%s", carCC, startDate, endDate)
+
+	if opts.MeterToleranceKm <= 0 {
+		opts.MeterToleranceKm = defaultMeterToleranceKm
+	}
+	if opts.DistanceToleranceKm <= 0 {
+		opts.DistanceToleranceKm = defaultDistanceToleranceKm
+	}
+
+	start, end, err := parseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	filter := &FilterOptions{
+		StartDate:           &start,
+		EndDate:             &end,
+		IncludeInvalidDates: true,
+	}
+	if carCC != "" {
+		filter.CarCC = &carCC
+	}
+
+	allRows, _, err := s.ListWithFilter(ctx, filter, 0, 0)
+	if err != nil {
+		log.Printf("Failed to list rows with filter: %v", err)
+		return nil, err
+	}
+
+	report := &ValidationReport{RowsChecked: int32(len(allRows))}
+
+	// 車両ごとに分類（日時が不正な行は時系列チェックの対象外）
+	vehicleTrips := make(map[string][]*validationTrip)
+	for _, row := range allRows {
+		trips := vehicleTrips[row.CarCc]
+		if trip, ok := validateRowDates(row, report); ok {
+			trips = append(trips, trip)
+		}
+		vehicleTrips[row.CarCc] = trips
+	}
+	report.VehiclesChecked = int32(len(vehicleTrips))
+
+	carCCs := make([]string, 0, len(vehicleTrips))
+	for cc := range vehicleTrips {
+		carCCs = append(carCCs, cc)
+	}
+	sort.Strings(carCCs)
+
+	for _, cc := range carCCs {
+		validateVehicleTrips(vehicleTrips[cc], opts, report)
+	}
+
+	// 車輌CC順に並べる（同一車両内は検出順）
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].CarCC < report.Issues[j].CarCC
+	})
+
+	log.Printf("Validated %d rows of %d vehicles: %d errors, %d warnings",
+		report.RowsChecked, report.VehiclesChecked, report.ErrorCount, report.WarningCount)
+	return report, nil
+}
+
+// validateRowDates 単一行の日時をチェック
+//
+// 出庫・帰庫日時がパースでき、帰庫が出庫以降の場合のみ時系列チェック用の運行を返します。
+func validateRowDates(row *dbpb.Db_DTakoRows, report *ValidationReport) (*validationTrip, bool) {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"operation_date", row.OperationDate},
+		{"departure_datetime", row.DepartureDatetime},
+		{"return_datetime", row.ReturnDatetime},
+	}
+
+	parsed := make(map[string]time.Time, len(fields))
+	for _, f := range fields {
+		t, err := time.Parse(time.RFC3339, f.value)
+		if err != nil {
+			report.add(&ValidationIssue{
+				Code:     ValidationCodeInvalidDate,
+				Severity: ValidationSeverityError,
+				CarCC:    row.CarCc,
+				RowIDs:   []string{row.Id},
+				Field:    f.name,
+				Message:  fmt.Sprintf("運行NO %s の %s %q をパースできません", row.OperationNo, f.name, f.value),
+			})
+			continue
+		}
+		parsed[f.name] = t
+	}
+
+	departure, depOK := parsed["departure_datetime"]
+	ret, retOK := parsed["return_datetime"]
+	if !depOK || !retOK {
+		return nil, false
+	}
+
+	if ret.Before(departure) {
+		report.add(&ValidationIssue{
+			Code:     ValidationCodeReturnBeforeDeparture,
+			Severity: ValidationSeverityError,
+			CarCC:    row.CarCc,
+			RowIDs:   []string{row.Id},
+			Field:    "return_datetime",
+			Message: fmt.Sprintf("運行NO %s の帰庫日時 %s が出庫日時 %s より前です",
+				row.OperationNo, row.ReturnDatetime, row.DepartureDatetime),
+		})
+		return nil, false
+	}
+
+	return &validationTrip{row: row, departure: departure, ret: ret}, true
+}
+
+// validateVehicleTrips 1車両の運行を出庫日時順にチェック
+func validateVehicleTrips(trips []*validationTrip, opts ValidationOptions, report *ValidationReport) {
+	sort.SliceStable(trips, func(i, j int) bool {
+		return trips[i].departure.Before(trips[j].departure)
+	})
+
+	for i, trip := range trips {
+		row := trip.row
+
+		// 総走行距離とメーター差
+		meterDistance := row.ReturnMeter - row.DepartureMeter
+		if math.Abs(row.TotalDistance-meterDistance) > opts.DistanceToleranceKm {
+			report.add(&ValidationIssue{
+				Code:     ValidationCodeDistanceMismatch,
+				Severity: ValidationSeverityWarning,
+				CarCC:    row.CarCc,
+				RowIDs:   []string{row.Id},
+				Field:    "total_distance",
+				Message: fmt.Sprintf("運行NO %s の総走行距離 %.1fkm がメーター差 %.1fkm (%.1f → %.1f) と一致しません",
+					row.OperationNo, row.TotalDistance, meterDistance, row.DepartureMeter, row.ReturnMeter),
+			})
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := trips[i-1]
+
+		// 運行期間の重複
+		if trip.departure.Before(prev.ret) {
+			report.add(&ValidationIssue{
+				Code:     ValidationCodeOverlappingTrip,
+				Severity: ValidationSeverityError,
+				CarCC:    row.CarCc,
+				RowIDs:   []string{prev.row.Id, row.Id},
+				Field:    "departure_datetime",
+				Message: fmt.Sprintf("運行NO %s の出庫日時 %s が運行NO %s の帰庫日時 %s より前です",
+					row.OperationNo, row.DepartureDatetime, prev.row.OperationNo, prev.row.ReturnDatetime),
+			})
+		}
+
+		// メーターの連続性
+		if math.Abs(row.DepartureMeter-prev.row.ReturnMeter) > opts.MeterToleranceKm {
+			report.add(&ValidationIssue{
+				Code:     ValidationCodeMeterDiscontinuity,
+				Severity: ValidationSeverityWarning,
+				CarCC:    row.CarCc,
+				RowIDs:   []string{prev.row.Id, row.Id},
+				Field:    "departure_meter",
+				Message: fmt.Sprintf("運行NO %s の出庫メーター %.1f が前回運行NO %s の帰庫メーター %.1f と一致しません",
+					row.OperationNo, row.DepartureMeter, prev.row.OperationNo, prev.row.ReturnMeter),
+			})
+		}
+	}
+}
+
+// add 問題を追加し、重大度ごとの件数を更新
+func (r *ValidationReport) add(issue *ValidationIssue) {
+	r.Issues = append(r.Issues, issue)
+	switch issue.Severity {
+	case ValidationSeverityError:
+		r.ErrorCount++
+	case ValidationSeverityWarning:
+		r.WarningCount++
+	}
+}
@@ -0,0 +1,155 @@
+package registry
+
+import (
+	"context"
+
+	dbpb "github.com/yhonda-ohishi/db_service/src/proto"
+	"google.golang.org/grpc"
+)
+
+// localCarsClient 車両マスタ（DTakoCars）のサーバー実装をクライアントインターフェースに適合させるアダプター
+type localCarsClient struct {
+	server dbpb.Db_DTakoCarsServiceServer
+}
+
+func (c *localCarsClient) Get(ctx context.Context, req *dbpb.Db_GetDTakoCarsRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoCarsResponse, error) {
+	return c.server.Get(ctx, req)
+}
+
+func (c *localCarsClient) List(ctx context.Context, req *dbpb.Db_ListDTakoCarsRequest, opts ...grpc.CallOption) (*dbpb.Db_ListDTakoCarsResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+func (c *localCarsClient) GetByCarCode(ctx context.Context, req *dbpb.Db_GetDTakoCarsByCarCodeRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoCarsResponse, error) {
+	return c.server.GetByCarCode(ctx, req)
+}
+
+// localETCMeisaiClient ETC明細のサーバー実装をクライアントインターフェースに適合させるアダプター
+type localETCMeisaiClient struct {
+	server dbpb.Db_ETCMeisaiServiceServer
+}
+
+func (c *localETCMeisaiClient) Create(ctx context.Context, req *dbpb.Db_CreateETCMeisaiRequest, opts ...grpc.CallOption) (*dbpb.Db_ETCMeisaiResponse, error) {
+	return c.server.Create(ctx, req)
+}
+
+func (c *localETCMeisaiClient) Get(ctx context.Context, req *dbpb.Db_GetETCMeisaiRequest, opts ...grpc.CallOption) (*dbpb.Db_ETCMeisaiResponse, error) {
+	return c.server.Get(ctx, req)
+}
+
+func (c *localETCMeisaiClient) Update(ctx context.Context, req *dbpb.Db_UpdateETCMeisaiRequest, opts ...grpc.CallOption) (*dbpb.Db_ETCMeisaiResponse, error) {
+	return c.server.Update(ctx, req)
+}
+
+func (c *localETCMeisaiClient) Delete(ctx context.Context, req *dbpb.Db_DeleteETCMeisaiRequest, opts ...grpc.CallOption) (*dbpb.Db_Empty, error) {
+	return c.server.Delete(ctx, req)
+}
+
+func (c *localETCMeisaiClient) List(ctx context.Context, req *dbpb.Db_ListETCMeisaiRequest, opts ...grpc.CallOption) (*dbpb.Db_ListETCMeisaiResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+// localETCMappingClient ETC明細と運行データの対応付けのサーバー実装をクライアントインターフェースに適合させるアダプター
+type localETCMappingClient struct {
+	server dbpb.Db_ETCMeisaiMappingServiceServer
+}
+
+func (c *localETCMappingClient) Create(ctx context.Context, req *dbpb.Db_CreateETCMeisaiMappingRequest, opts ...grpc.CallOption) (*dbpb.Db_ETCMeisaiMappingResponse, error) {
+	return c.server.Create(ctx, req)
+}
+
+func (c *localETCMappingClient) Get(ctx context.Context, req *dbpb.Db_GetETCMeisaiMappingRequest, opts ...grpc.CallOption) (*dbpb.Db_ETCMeisaiMappingResponse, error) {
+	return c.server.Get(ctx, req)
+}
+
+func (c *localETCMappingClient) Update(ctx context.Context, req *dbpb.Db_UpdateETCMeisaiMappingRequest, opts ...grpc.CallOption) (*dbpb.Db_ETCMeisaiMappingResponse, error) {
+	return c.server.Update(ctx, req)
+}
+
+func (c *localETCMappingClient) Delete(ctx context.Context, req *dbpb.Db_DeleteETCMeisaiMappingRequest, opts ...grpc.CallOption) (*dbpb.Db_Empty, error) {
+	return c.server.Delete(ctx, req)
+}
+
+func (c *localETCMappingClient) List(ctx context.Context, req *dbpb.Db_ListETCMeisaiMappingRequest, opts ...grpc.CallOption) (*dbpb.Db_ListETCMeisaiMappingResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+func (c *localETCMappingClient) GetDTakoRowIDByHash(ctx context.Context, req *dbpb.Db_GetDTakoRowIDByHashRequest, opts ...grpc.CallOption) (*dbpb.Db_GetDTakoRowIDByHashResponse, error) {
+	return c.server.GetDTakoRowIDByHash(ctx, req)
+}
+
+// localETCNumClient ETCカード番号マスタのサーバー実装をクライアントインターフェースに適合させるアダプター
+type localETCNumClient struct {
+	server dbpb.Db_ETCNumServiceServer
+}
+
+func (c *localETCNumClient) List(ctx context.Context, req *dbpb.Db_ListETCNumRequest, opts ...grpc.CallOption) (*dbpb.Db_ListETCNumResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+func (c *localETCNumClient) GetByETCCardNum(ctx context.Context, req *dbpb.Db_GetETCNumByETCCardNumRequest, opts ...grpc.CallOption) (*dbpb.Db_ListETCNumResponse, error) {
+	return c.server.GetByETCCardNum(ctx, req)
+}
+
+func (c *localETCNumClient) GetByCarID(ctx context.Context, req *dbpb.Db_GetETCNumByCarIDRequest, opts ...grpc.CallOption) (*dbpb.Db_ListETCNumResponse, error) {
+	return c.server.GetByCarID(ctx, req)
+}
+
+// localFerriesClient フェリー運行データのサーバー実装をクライアントインターフェースに適合させるアダプター
+type localFerriesClient struct {
+	server dbpb.Db_DTakoFerryRowsProdServiceServer
+}
+
+func (c *localFerriesClient) Get(ctx context.Context, req *dbpb.Db_GetDTakoFerryRowsProdRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoFerryRowsProdResponse, error) {
+	return c.server.Get(ctx, req)
+}
+
+func (c *localFerriesClient) List(ctx context.Context, req *dbpb.Db_ListDTakoFerryRowsProdRequest, opts ...grpc.CallOption) (*dbpb.Db_ListDTakoFerryRowsProdResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+func (c *localFerriesClient) GetByUnkoNo(ctx context.Context, req *dbpb.Db_GetDTakoFerryRowsProdByUnkoNoRequest, opts ...grpc.CallOption) (*dbpb.Db_ListDTakoFerryRowsProdResponse, error) {
+	return c.server.GetByUnkoNo(ctx, req)
+}
+
+// localUriageKeihiClient 売上・経費のサーバー実装をクライアントインターフェースに適合させるアダプター
+type localUriageKeihiClient struct {
+	server dbpb.Db_DTakoUriageKeihiServiceServer
+}
+
+func (c *localUriageKeihiClient) Create(ctx context.Context, req *dbpb.Db_CreateDTakoUriageKeihiRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoUriageKeihiResponse, error) {
+	return c.server.Create(ctx, req)
+}
+
+func (c *localUriageKeihiClient) Get(ctx context.Context, req *dbpb.Db_GetDTakoUriageKeihiRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoUriageKeihiResponse, error) {
+	return c.server.Get(ctx, req)
+}
+
+func (c *localUriageKeihiClient) Update(ctx context.Context, req *dbpb.Db_UpdateDTakoUriageKeihiRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoUriageKeihiResponse, error) {
+	return c.server.Update(ctx, req)
+}
+
+func (c *localUriageKeihiClient) Delete(ctx context.Context, req *dbpb.Db_DeleteDTakoUriageKeihiRequest, opts ...grpc.CallOption) (*dbpb.Db_Empty, error) {
+	return c.server.Delete(ctx, req)
+}
+
+func (c *localUriageKeihiClient) List(ctx context.Context, req *dbpb.Db_ListDTakoUriageKeihiRequest, opts ...grpc.CallOption) (*dbpb.Db_ListDTakoUriageKeihiResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+// localEventsClient イベントデータのサーバー実装をクライアントインターフェースに適合させるアダプター
+type localEventsClient struct {
+	server dbpb.Db_DTakoEventsServiceServer
+}
+
+func (c *localEventsClient) Get(ctx context.Context, req *dbpb.Db_GetDTakoEventsRequest, opts ...grpc.CallOption) (*dbpb.Db_DTakoEventsResponse, error) {
+	return c.server.Get(ctx, req)
+}
+
+func (c *localEventsClient) List(ctx context.Context, req *dbpb.Db_ListDTakoEventsRequest, opts ...grpc.CallOption) (*dbpb.Db_ListDTakoEventsResponse, error) {
+	return c.server.List(ctx, req)
+}
+
+func (c *localEventsClient) GetByOperationNo(ctx context.Context, req *dbpb.Db_GetDTakoEventsByOperationNoRequest, opts ...grpc.CallOption) (*dbpb.Db_ListDTakoEventsResponse, error) {
+	return c.server.GetByOperationNo(ctx, req)
+}
@@ -14,17 +14,21 @@ import (
 // Register dtako_rowsサービスをgRPCサーバーに登録
 //
 // 使い方:
-//   1. Standalone モード: Register(grpcServer)
-//      - 外部の db_service (localhost:50051) に接続
-//      - Db_DTakoRowsService と DtakoRowsService の両方を登録
 //
-//   2. Desktop-server 統合モード: Register(grpcServer, dbServer)
-//      - 同一プロセス内の db_service サーバー実装を使用
-//      - DtakoRowsService のみ登録（Db_DTakoRowsService は重複回避のため登録しない）
+//  1. Standalone モード: Register(grpcServer)
+//     - 外部の db_service (localhost:50051) に接続
+//     - Db_DTakoRowsService と DtakoRowsService の両方を登録
+//
+//  2. Desktop-server 統合モード: Register(grpcServer, dbServer)
+//     - 同一プロセス内の db_service サーバー実装を使用
+//     - DtakoRowsService のみ登録（Db_DTakoRowsService は重複回避のため登録しない）
 //
 // パラメータ:
 //   - grpcServer: gRPCサーバーインスタンス
 //   - dbServer: (オプショナル) 同一プロセス内の db_service サーバー実装
+//
+// ロールアップ（ROLLUP_STORE_PATH）の更新ジョブはプロセスの終了まで動作します。
+// サーバーの停止時に止める場合は RegisterWithClients・RegisterWithServers の戻り値の停止関数を使用してください。
 func Register(grpcServer *grpc.Server, dbServer ...dbpb.Db_DTakoRowsServiceServer) error {
 	// Desktop-server統合モード: dbServerが渡された場合
 	if len(dbServer) > 0 && dbServer[0] != nil {
@@ -43,10 +47,11 @@ func Register(grpcServer *grpc.Server, dbServer ...dbpb.Db_DTakoRowsServiceServe
 		return err
 	}
 
-	dbClient := dbpb.NewDb_DTakoRowsServiceClient(conn)
+	// 運行データ・車両マスタなどのクライアントを同一接続から作成
+	clients := service.NewDBClientsFromConn(conn)
 
-	// Register both services using RegisterWithClient
-	RegisterWithClient(grpcServer, dbClient)
+	// Register both services using RegisterWithClients
+	RegisterWithClients(grpcServer, clients)
 
 	log.Println("dtako_rows services registered successfully (Db_DTakoRowsService + DtakoRowsService)")
 	return nil
@@ -54,19 +59,34 @@ func Register(grpcServer *grpc.Server, dbServer ...dbpb.Db_DTakoRowsServiceServe
 
 // RegisterWithClient 既存のdb_serviceクライアントを使ってサービスを登録（desktop-server統合用）
 //
-// desktop-server内で同一プロセスのdb_serviceに接続する場合に使用
-func RegisterWithClient(grpcServer *grpc.Server, dbClient dbpb.Db_DTakoRowsServiceClient) {
+// desktop-server内で同一プロセスのdb_serviceに接続する場合に使用。
+// 運行データのクライアントのみを使うため、車両マスタ・ETC・フェリー・売上経費・イベントを
+// 使う集計は縮退動作します。これらも使う場合は RegisterWithClients を使用してください。
+// 戻り値は RegisterWithClients と同じ停止関数です。
+func RegisterWithClient(grpcServer *grpc.Server, dbClient dbpb.Db_DTakoRowsServiceClient) func() {
+	return RegisterWithClients(grpcServer, &service.DBClients{Rows: dbClient})
+}
+
+// RegisterWithClients 既存のdb_serviceクライアント群を使ってサービスを登録
+//
+// 車両マスタ（DTakoCars）などのクライアントも渡すことで、
+// 車両ごとの燃費など db_service の他テーブルを使った集計が有効になります。
+//
+// 戻り値はバックグラウンドのジョブ（ロールアップの更新）の停止関数です。
+// gRPCサーバーの GracefulStop の後に呼び出してください。
+func RegisterWithClients(grpcServer *grpc.Server, clients *service.DBClients) func() {
 	log.Println("Registering dtako_rows services with existing db_service client...")
 
 	// 既存クライアントを使ってサービスを作成
-	svc := service.NewDtakoRowsServiceWithClient(dbClient)
+	svc := service.NewDtakoRowsServiceWithClients(clients)
 	dbpb.RegisterDb_DTakoRowsServiceServer(grpcServer, svc)
 
 	// 集計サービスも登録
-	aggSvc := service.NewDtakoRowsAggregationServiceWithClient(dbClient)
+	aggSvc := service.NewDtakoRowsAggregationServiceWithClients(clients)
 	pb.RegisterDtakoRowsServiceServer(grpcServer, aggSvc)
 
 	log.Println("dtako_rows services registered successfully (Db_DTakoRowsService + DtakoRowsService)")
+	return aggSvc.Close
 }
 
 // RegisterWithServer 既存のdb_serviceサーバー実装を使ってサービスを登録
@@ -74,20 +94,70 @@ func RegisterWithClient(grpcServer *grpc.Server, dbClient dbpb.Db_DTakoRowsServi
 // 同一プロセス内でdb_serviceとdtako_rowsを統合する場合に使用。
 // desktop-server側でアダプター実装が不要になります。
 //
+// 運行データのサーバー実装のみを使います。車両マスタなど他のテーブルも使う場合は
+// RegisterWithServers を使用してください。戻り値は RegisterWithServers と同じ停止関数です。
+func RegisterWithServer(grpcServer *grpc.Server, dbServer dbpb.Db_DTakoRowsServiceServer) func() {
+	return RegisterWithServers(grpcServer, &DBServers{Rows: dbServer})
+}
+
+// DBServers 同一プロセス内のdb_serviceの各サーバー実装
+//
+// Rows は必須です。その他はオプショナルで、nil の場合は該当する機能が縮退動作します。
+type DBServers struct {
+	Rows        dbpb.Db_DTakoRowsServiceServer          // 運行データ（必須）
+	Cars        dbpb.Db_DTakoCarsServiceServer          // 車両マスタ
+	ETCMeisai   dbpb.Db_ETCMeisaiServiceServer          // ETC明細
+	ETCMapping  dbpb.Db_ETCMeisaiMappingServiceServer   // ETC明細と運行データの対応付け
+	ETCNum      dbpb.Db_ETCNumServiceServer             // ETCカード番号マスタ
+	Ferries     dbpb.Db_DTakoFerryRowsProdServiceServer // フェリー運行データ
+	UriageKeihi dbpb.Db_DTakoUriageKeihiServiceServer   // 売上・経費
+	Events      dbpb.Db_DTakoEventsServiceServer        // イベントデータ
+}
+
+// RegisterWithServers 既存のdb_serviceサーバー実装群を使ってサービスを登録
+//
+// 各サーバー実装をクライアントインターフェースとしてラップし、
+// 車両マスタ・ETC・フェリー・売上経費・イベントを使う集計も有効にします。
+//
 // 注意: この関数は DtakoRowsService のみを登録します。
 // Db_DTakoRowsService は desktop-server 側で既に登録されているため、
 // 重複登録を避けるためにここでは登録しません。
-func RegisterWithServer(grpcServer *grpc.Server, dbServer dbpb.Db_DTakoRowsServiceServer) {
-	log.Println("Registering DtakoRowsService (aggregation + proxy) with existing db_service server...")
+//
+// 戻り値はバックグラウンドのジョブ（ロールアップの更新）の停止関数です。
+// gRPCサーバーの GracefulStop の後に呼び出してください。
+func RegisterWithServers(grpcServer *grpc.Server, servers *DBServers) func() {
+	log.Println("Registering DtakoRowsService (aggregation + proxy) with existing db_service servers...")
 
 	// サーバー実装をクライアントインターフェースとしてラップ
-	client := &localServerClient{server: dbServer}
+	clients := &service.DBClients{Rows: &localServerClient{server: servers.Rows}}
+	if servers.Cars != nil {
+		clients.Cars = &localCarsClient{server: servers.Cars}
+	}
+	if servers.ETCMeisai != nil {
+		clients.ETCMeisai = &localETCMeisaiClient{server: servers.ETCMeisai}
+	}
+	if servers.ETCMapping != nil {
+		clients.ETCMapping = &localETCMappingClient{server: servers.ETCMapping}
+	}
+	if servers.ETCNum != nil {
+		clients.ETCNum = &localETCNumClient{server: servers.ETCNum}
+	}
+	if servers.Ferries != nil {
+		clients.Ferries = &localFerriesClient{server: servers.Ferries}
+	}
+	if servers.UriageKeihi != nil {
+		clients.UriageKeihi = &localUriageKeihiClient{server: servers.UriageKeihi}
+	}
+	if servers.Events != nil {
+		clients.Events = &localEventsClient{server: servers.Events}
+	}
 
 	// DtakoRowsService のみ登録（Db_DTakoRowsService は登録しない）
-	aggSvc := service.NewDtakoRowsAggregationServiceWithClient(client)
+	aggSvc := service.NewDtakoRowsAggregationServiceWithClients(clients)
 	pb.RegisterDtakoRowsServiceServer(grpcServer, aggSvc)
 
 	log.Println("DtakoRowsService registered successfully")
+	return aggSvc.Close
 }
 
 // localServerClient はサーバー実装をクライアントインターフェースに適合させるアダプター
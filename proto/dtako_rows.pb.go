@@ -21,22 +21,170 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 集計期間の区切り方（省略時は各RPCの既定: 月次または日次）
+type Bucketing struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Kind                 string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                                                  // day / iso_week / month / closing_month / quarter / fiscal_year
+	ClosingDay           int32                  `protobuf:"varint,2,opt,name=closing_day,json=closingDay,proto3" json:"closing_day,omitempty"`                                   // 締め日（closing_month、省略時は CLOSING_DAY、未設定なら月末）
+	FiscalYearStartMonth int32                  `protobuf:"varint,3,opt,name=fiscal_year_start_month,json=fiscalYearStartMonth,proto3" json:"fiscal_year_start_month,omitempty"` // 年度の開始月（quarter / fiscal_year、省略時は FISCAL_YEAR_START_MONTH、未設定なら4）
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Bucketing) Reset() {
+	*x = Bucketing{}
+	mi := &file_dtako_rows_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bucketing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucketing) ProtoMessage() {}
+
+func (x *Bucketing) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucketing.ProtoReflect.Descriptor instead.
+func (*Bucketing) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Bucketing) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Bucketing) GetClosingDay() int32 {
+	if x != nil {
+		return x.ClosingDay
+	}
+	return 0
+}
+
+func (x *Bucketing) GetFiscalYearStartMonth() int32 {
+	if x != nil {
+		return x.FiscalYearStartMonth
+	}
+	return 0
+}
+
+// 集計期間
+type PeriodBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                              // 並べ替え用のキー (例: 2025-10, 2025-W41, FY2025-Q1, FY2025)
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`                          // 表示名 (例: 2025-10 20日締 (2025-09-21〜2025-10-20))
+	StartDate     string                 `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 集計した範囲の開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 集計した範囲の終了日 (YYYY-MM-DD)
+	Partial       bool                   `protobuf:"varint,5,opt,name=partial,proto3" json:"partial,omitempty"`                     // リクエストの期間で切り詰められた（期間の一部のみ集計）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodBucket) Reset() {
+	*x = PeriodBucket{}
+	mi := &file_dtako_rows_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodBucket) ProtoMessage() {}
+
+func (x *PeriodBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodBucket.ProtoReflect.Descriptor instead.
+func (*PeriodBucket) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PeriodBucket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PeriodBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PeriodBucket) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *PeriodBucket) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *PeriodBucket) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
 // 月次給油量サマリー
 type MonthlyFuelSummary struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	CarCc             string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                         // 車輌CC
-	YearMonth         string                 `protobuf:"bytes,2,opt,name=year_month,json=yearMonth,proto3" json:"year_month,omitempty"`                             // 年月 (YYYY-MM形式)
-	TotalDistance     float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`               // 総走行距離 (km)
-	TotalFuel         float64                `protobuf:"fixed64,4,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`                           // 総給油量 (L)
-	TripCount         int32                  `protobuf:"varint,5,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                            // 運行回数
-	AvgFuelEfficiency float64                `protobuf:"fixed64,6,opt,name=avg_fuel_efficiency,json=avgFuelEfficiency,proto3" json:"avg_fuel_efficiency,omitempty"` // 平均燃費 (km/L)
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CarCc                string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                                // 車輌CC
+	YearMonth            string                 `protobuf:"bytes,2,opt,name=year_month,json=yearMonth,proto3" json:"year_month,omitempty"`                                    // 集計期間のキー（月次の場合は年月 YYYY-MM形式）
+	TotalDistance        float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`                      // 総走行距離 (km)
+	TotalFuel            float64                `protobuf:"fixed64,4,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`                                  // 総給油量 (L, fuel_basis が measured なら実績値、mixed なら実績値 + 推定値、estimated なら推定値)
+	TripCount            int32                  `protobuf:"varint,5,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                                   // 運行回数
+	AvgFuelEfficiency    float64                `protobuf:"fixed64,6,opt,name=avg_fuel_efficiency,json=avgFuelEfficiency,proto3" json:"avg_fuel_efficiency,omitempty"`        // 平均燃費 (km/L)
+	FuelEfficiency       float64                `protobuf:"fixed64,7,opt,name=fuel_efficiency,json=fuelEfficiency,proto3" json:"fuel_efficiency,omitempty"`                   // 給油量の推定に使用した燃費 (km/L)
+	FuelEfficiencySource string                 `protobuf:"bytes,8,opt,name=fuel_efficiency_source,json=fuelEfficiencySource,proto3" json:"fuel_efficiency_source,omitempty"` // 燃費の決定元 (override/car_class/max_load_weight/default)
+	FuelBasis            string                 `protobuf:"bytes,9,opt,name=fuel_basis,json=fuelBasis,proto3" json:"fuel_basis,omitempty"`                                    // total_fuel の根拠 (measured: 実給油データ / mixed: 実給油データ + 最後の給油より後の推定 / estimated: 走行距離 / 燃費)
+	MeasuredFuel         float64                `protobuf:"fixed64,10,opt,name=measured_fuel,json=measuredFuel,proto3" json:"measured_fuel,omitempty"`                        // 実給油量の合計 (L)
+	EstimatedFuel        float64                `protobuf:"fixed64,11,opt,name=estimated_fuel,json=estimatedFuel,proto3" json:"estimated_fuel,omitempty"`                     // 推定給油量 (L)
+	RefuelCount          int32                  `protobuf:"varint,12,opt,name=refuel_count,json=refuelCount,proto3" json:"refuel_count,omitempty"`                            // 実給油データの件数
+	Bucket               *PeriodBucket          `protobuf:"bytes,13,opt,name=bucket,proto3" json:"bucket,omitempty"`                                                          // 集計期間
+	FerryDistance        float64                `protobuf:"fixed64,14,opt,name=ferry_distance,json=ferryDistance,proto3" json:"ferry_distance,omitempty"`                     // フェリーの見なし距離 (km、total_distance・給油量の推定には含まない)
+	DistanceWithFerry    float64                `protobuf:"fixed64,15,opt,name=distance_with_ferry,json=distanceWithFerry,proto3" json:"distance_with_ferry,omitempty"`       // フェリーの見なし距離を含む距離 (km)
+	UncoveredDistance    float64                `protobuf:"fixed64,16,opt,name=uncovered_distance,json=uncoveredDistance,proto3" json:"uncovered_distance,omitempty"`         // 実給油データでカバーされない走行距離 (km、期間内の最後の給油日より後の運行)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *MonthlyFuelSummary) Reset() {
 	*x = MonthlyFuelSummary{}
-	mi := &file_dtako_rows_proto_msgTypes[0]
+	mi := &file_dtako_rows_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -48,7 +196,7 @@ func (x *MonthlyFuelSummary) String() string {
 func (*MonthlyFuelSummary) ProtoMessage() {}
 
 func (x *MonthlyFuelSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[0]
+	mi := &file_dtako_rows_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,7 +209,7 @@ func (x *MonthlyFuelSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MonthlyFuelSummary.ProtoReflect.Descriptor instead.
 func (*MonthlyFuelSummary) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{0}
+	return file_dtako_rows_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *MonthlyFuelSummary) GetCarCc() string {
@@ -106,19 +254,91 @@ func (x *MonthlyFuelSummary) GetAvgFuelEfficiency() float64 {
 	return 0
 }
 
+func (x *MonthlyFuelSummary) GetFuelEfficiency() float64 {
+	if x != nil {
+		return x.FuelEfficiency
+	}
+	return 0
+}
+
+func (x *MonthlyFuelSummary) GetFuelEfficiencySource() string {
+	if x != nil {
+		return x.FuelEfficiencySource
+	}
+	return ""
+}
+
+func (x *MonthlyFuelSummary) GetFuelBasis() string {
+	if x != nil {
+		return x.FuelBasis
+	}
+	return ""
+}
+
+func (x *MonthlyFuelSummary) GetMeasuredFuel() float64 {
+	if x != nil {
+		return x.MeasuredFuel
+	}
+	return 0
+}
+
+func (x *MonthlyFuelSummary) GetEstimatedFuel() float64 {
+	if x != nil {
+		return x.EstimatedFuel
+	}
+	return 0
+}
+
+func (x *MonthlyFuelSummary) GetRefuelCount() int32 {
+	if x != nil {
+		return x.RefuelCount
+	}
+	return 0
+}
+
+func (x *MonthlyFuelSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *MonthlyFuelSummary) GetFerryDistance() float64 {
+	if x != nil {
+		return x.FerryDistance
+	}
+	return 0
+}
+
+func (x *MonthlyFuelSummary) GetDistanceWithFerry() float64 {
+	if x != nil {
+		return x.DistanceWithFerry
+	}
+	return 0
+}
+
+func (x *MonthlyFuelSummary) GetUncoveredDistance() float64 {
+	if x != nil {
+		return x.UncoveredDistance
+	}
+	return 0
+}
+
 // 月次給油量取得リクエスト
 type GetMonthlyFuelConsumptionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`             // 車輌CC（必須）
-	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
-	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                         // 車輌CC（必須）
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`             // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                   // 終了日 (YYYY-MM-DD)
+	ExportOptions *ExportOptions         `protobuf:"bytes,4,opt,name=export_options,json=exportOptions,proto3" json:"export_options,omitempty"` // 出力オプション（エクスポートRPCのみ）
+	Bucketing     *Bucketing             `protobuf:"bytes,5,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                              // 集計期間の区切り方（省略時は月次）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetMonthlyFuelConsumptionRequest) Reset() {
 	*x = GetMonthlyFuelConsumptionRequest{}
-	mi := &file_dtako_rows_proto_msgTypes[1]
+	mi := &file_dtako_rows_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -130,7 +350,7 @@ func (x *GetMonthlyFuelConsumptionRequest) String() string {
 func (*GetMonthlyFuelConsumptionRequest) ProtoMessage() {}
 
 func (x *GetMonthlyFuelConsumptionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[1]
+	mi := &file_dtako_rows_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -143,7 +363,7 @@ func (x *GetMonthlyFuelConsumptionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMonthlyFuelConsumptionRequest.ProtoReflect.Descriptor instead.
 func (*GetMonthlyFuelConsumptionRequest) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{1}
+	return file_dtako_rows_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetMonthlyFuelConsumptionRequest) GetCarCc() string {
@@ -167,6 +387,20 @@ func (x *GetMonthlyFuelConsumptionRequest) GetEndDate() string {
 	return ""
 }
 
+func (x *GetMonthlyFuelConsumptionRequest) GetExportOptions() *ExportOptions {
+	if x != nil {
+		return x.ExportOptions
+	}
+	return nil
+}
+
+func (x *GetMonthlyFuelConsumptionRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
 // 月次給油量取得レスポンス
 type MonthlyFuelConsumptionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -179,7 +413,7 @@ type MonthlyFuelConsumptionResponse struct {
 
 func (x *MonthlyFuelConsumptionResponse) Reset() {
 	*x = MonthlyFuelConsumptionResponse{}
-	mi := &file_dtako_rows_proto_msgTypes[2]
+	mi := &file_dtako_rows_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -191,7 +425,7 @@ func (x *MonthlyFuelConsumptionResponse) String() string {
 func (*MonthlyFuelConsumptionResponse) ProtoMessage() {}
 
 func (x *MonthlyFuelConsumptionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[2]
+	mi := &file_dtako_rows_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +438,7 @@ func (x *MonthlyFuelConsumptionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MonthlyFuelConsumptionResponse.ProtoReflect.Descriptor instead.
 func (*MonthlyFuelConsumptionResponse) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{2}
+	return file_dtako_rows_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MonthlyFuelConsumptionResponse) GetSummaries() []*MonthlyFuelSummary {
@@ -231,15 +465,20 @@ func (x *MonthlyFuelConsumptionResponse) GetPeriod() string {
 // 全車両月次サマリー取得リクエスト
 type GetVehicleMonthlySummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
-	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`             // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                   // 終了日 (YYYY-MM-DD)
+	ExportOptions *ExportOptions         `protobuf:"bytes,3,opt,name=export_options,json=exportOptions,proto3" json:"export_options,omitempty"` // 出力オプション（エクスポートRPCのみ）
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                              // 集計期間の区切り方（省略時は月次）
+	SortBy        string                 `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                      // 車両の並び順: car_cc（省略時） / total_distance / trip_count / total_fuel / avg_fuel_efficiency
+	Descending    bool                   `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`                           // 降順（同値の車両は車輌CC順）
+	RankingSize   int32                  `protobuf:"varint,7,opt,name=ranking_size,json=rankingSize,proto3" json:"ranking_size,omitempty"`      // ランキングの上位・下位の件数（省略時5）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetVehicleMonthlySummaryRequest) Reset() {
 	*x = GetVehicleMonthlySummaryRequest{}
-	mi := &file_dtako_rows_proto_msgTypes[3]
+	mi := &file_dtako_rows_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -251,7 +490,7 @@ func (x *GetVehicleMonthlySummaryRequest) String() string {
 func (*GetVehicleMonthlySummaryRequest) ProtoMessage() {}
 
 func (x *GetVehicleMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[3]
+	mi := &file_dtako_rows_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +503,7 @@ func (x *GetVehicleMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVehicleMonthlySummaryRequest.ProtoReflect.Descriptor instead.
 func (*GetVehicleMonthlySummaryRequest) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{3}
+	return file_dtako_rows_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetVehicleMonthlySummaryRequest) GetStartDate() string {
@@ -281,30 +520,75 @@ func (x *GetVehicleMonthlySummaryRequest) GetEndDate() string {
 	return ""
 }
 
-// 車両別月次データ
-type VehicleMonthlySummaries struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
-	Summaries     []*MonthlyFuelSummary  `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetVehicleMonthlySummaryRequest) GetExportOptions() *ExportOptions {
+	if x != nil {
+		return x.ExportOptions
+	}
+	return nil
 }
 
-func (x *VehicleMonthlySummaries) Reset() {
-	*x = VehicleMonthlySummaries{}
-	mi := &file_dtako_rows_proto_msgTypes[4]
+func (x *GetVehicleMonthlySummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+func (x *GetVehicleMonthlySummaryRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *GetVehicleMonthlySummaryRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *GetVehicleMonthlySummaryRequest) GetRankingSize() int32 {
+	if x != nil {
+		return x.RankingSize
+	}
+	return 0
+}
+
+// 期間全体の合計
+type SummaryTotals struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalDistance     float64                `protobuf:"fixed64,1,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`               // 総走行距離 (km)
+	TotalFuel         float64                `protobuf:"fixed64,2,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`                           // 総給油量 (L)
+	MeasuredFuel      float64                `protobuf:"fixed64,3,opt,name=measured_fuel,json=measuredFuel,proto3" json:"measured_fuel,omitempty"`                  // 実給油量の合計 (L)
+	EstimatedFuel     float64                `protobuf:"fixed64,4,opt,name=estimated_fuel,json=estimatedFuel,proto3" json:"estimated_fuel,omitempty"`               // 推定給油量の合計 (L)
+	TripCount         int32                  `protobuf:"varint,5,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                            // 運行回数
+	RefuelCount       int32                  `protobuf:"varint,6,opt,name=refuel_count,json=refuelCount,proto3" json:"refuel_count,omitempty"`                      // 実給油データの件数
+	AvgFuelEfficiency float64                `protobuf:"fixed64,7,opt,name=avg_fuel_efficiency,json=avgFuelEfficiency,proto3" json:"avg_fuel_efficiency,omitempty"` // 平均燃費 (km/L)
+	FerryDistance     float64                `protobuf:"fixed64,8,opt,name=ferry_distance,json=ferryDistance,proto3" json:"ferry_distance,omitempty"`               // フェリーの見なし距離 (km)
+	DistanceWithFerry float64                `protobuf:"fixed64,9,opt,name=distance_with_ferry,json=distanceWithFerry,proto3" json:"distance_with_ferry,omitempty"` // フェリーの見なし距離を含む距離 (km)
+	MeasuredPeriods   int32                  `protobuf:"varint,10,opt,name=measured_periods,json=measuredPeriods,proto3" json:"measured_periods,omitempty"`         // 給油量が実績値の期間数（車両・期間ごと）
+	EstimatedPeriods  int32                  `protobuf:"varint,11,opt,name=estimated_periods,json=estimatedPeriods,proto3" json:"estimated_periods,omitempty"`      // 給油量が推定値の期間数（車両・期間ごと）
+	MixedPeriods      int32                  `protobuf:"varint,12,opt,name=mixed_periods,json=mixedPeriods,proto3" json:"mixed_periods,omitempty"`                  // 給油量が実績値 + 推定値の期間数（車両・期間ごと）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SummaryTotals) Reset() {
+	*x = SummaryTotals{}
+	mi := &file_dtako_rows_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VehicleMonthlySummaries) String() string {
+func (x *SummaryTotals) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VehicleMonthlySummaries) ProtoMessage() {}
+func (*SummaryTotals) ProtoMessage() {}
 
-func (x *VehicleMonthlySummaries) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[4]
+func (x *SummaryTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,38 +599,294 @@ func (x *VehicleMonthlySummaries) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VehicleMonthlySummaries.ProtoReflect.Descriptor instead.
-func (*VehicleMonthlySummaries) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SummaryTotals.ProtoReflect.Descriptor instead.
+func (*SummaryTotals) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *VehicleMonthlySummaries) GetCarCc() string {
+func (x *SummaryTotals) GetTotalDistance() float64 {
 	if x != nil {
-		return x.CarCc
+		return x.TotalDistance
 	}
-	return ""
+	return 0
 }
 
-func (x *VehicleMonthlySummaries) GetSummaries() []*MonthlyFuelSummary {
+func (x *SummaryTotals) GetTotalFuel() float64 {
 	if x != nil {
-		return x.Summaries
+		return x.TotalFuel
 	}
-	return nil
+	return 0
 }
 
-// 全車両月次サマリーレスポンス
-type VehicleMonthlySummaryResponse struct {
-	state            protoimpl.MessageState     `protogen:"open.v1"`
-	VehicleSummaries []*VehicleMonthlySummaries `protobuf:"bytes,1,rep,name=vehicle_summaries,json=vehicleSummaries,proto3" json:"vehicle_summaries,omitempty"`
-	TotalVehicles    int32                      `protobuf:"varint,2,opt,name=total_vehicles,json=totalVehicles,proto3" json:"total_vehicles,omitempty"`
-	Period           string                     `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+func (x *SummaryTotals) GetMeasuredFuel() float64 {
+	if x != nil {
+		return x.MeasuredFuel
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetEstimatedFuel() float64 {
+	if x != nil {
+		return x.EstimatedFuel
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetRefuelCount() int32 {
+	if x != nil {
+		return x.RefuelCount
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetAvgFuelEfficiency() float64 {
+	if x != nil {
+		return x.AvgFuelEfficiency
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetFerryDistance() float64 {
+	if x != nil {
+		return x.FerryDistance
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetDistanceWithFerry() float64 {
+	if x != nil {
+		return x.DistanceWithFerry
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetMeasuredPeriods() int32 {
+	if x != nil {
+		return x.MeasuredPeriods
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetEstimatedPeriods() int32 {
+	if x != nil {
+		return x.EstimatedPeriods
+	}
+	return 0
+}
+
+func (x *SummaryTotals) GetMixedPeriods() int32 {
+	if x != nil {
+		return x.MixedPeriods
+	}
+	return 0
+}
+
+// 車両別月次データ
+type VehicleMonthlySummaries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Summaries     []*MonthlyFuelSummary  `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	Totals        *SummaryTotals         `protobuf:"bytes,3,opt,name=totals,proto3" json:"totals,omitempty"` // 期間全体の合計
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleMonthlySummaries) Reset() {
+	*x = VehicleMonthlySummaries{}
+	mi := &file_dtako_rows_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleMonthlySummaries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleMonthlySummaries) ProtoMessage() {}
+
+func (x *VehicleMonthlySummaries) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleMonthlySummaries.ProtoReflect.Descriptor instead.
+func (*VehicleMonthlySummaries) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VehicleMonthlySummaries) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *VehicleMonthlySummaries) GetSummaries() []*MonthlyFuelSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *VehicleMonthlySummaries) GetTotals() *SummaryTotals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+// ランキングの1件
+type RankedVehicle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"` // 順位（同値は同順位）
+	CarCc         string                 `protobuf:"bytes,2,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Value         float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankedVehicle) Reset() {
+	*x = RankedVehicle{}
+	mi := &file_dtako_rows_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedVehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedVehicle) ProtoMessage() {}
+
+func (x *RankedVehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedVehicle.ProtoReflect.Descriptor instead.
+func (*RankedVehicle) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RankedVehicle) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankedVehicle) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *RankedVehicle) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// 指標ごとの上位・下位の車両
+type VehicleRanking struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        string                 `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"` // total_distance / trip_count / avg_fuel_efficiency
+	Top           []*RankedVehicle       `protobuf:"bytes,2,rep,name=top,proto3" json:"top,omitempty"`       // 値の大きい順
+	Bottom        []*RankedVehicle       `protobuf:"bytes,3,rep,name=bottom,proto3" json:"bottom,omitempty"` // 値の小さい順
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleRanking) Reset() {
+	*x = VehicleRanking{}
+	mi := &file_dtako_rows_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleRanking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleRanking) ProtoMessage() {}
+
+func (x *VehicleRanking) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleRanking.ProtoReflect.Descriptor instead.
+func (*VehicleRanking) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VehicleRanking) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *VehicleRanking) GetTop() []*RankedVehicle {
+	if x != nil {
+		return x.Top
+	}
+	return nil
+}
+
+func (x *VehicleRanking) GetBottom() []*RankedVehicle {
+	if x != nil {
+		return x.Bottom
+	}
+	return nil
+}
+
+// 全車両月次サマリーレスポンス
+type VehicleMonthlySummaryResponse struct {
+	state            protoimpl.MessageState     `protogen:"open.v1"`
+	VehicleSummaries []*VehicleMonthlySummaries `protobuf:"bytes,1,rep,name=vehicle_summaries,json=vehicleSummaries,proto3" json:"vehicle_summaries,omitempty"` // sort_by の順
+	TotalVehicles    int32                      `protobuf:"varint,2,opt,name=total_vehicles,json=totalVehicles,proto3" json:"total_vehicles,omitempty"`
+	Period           string                     `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	FleetSummaries   []*MonthlyFuelSummary      `protobuf:"bytes,4,rep,name=fleet_summaries,json=fleetSummaries,proto3" json:"fleet_summaries,omitempty"` // 全車両の期間ごとの合計（期間順、car_cc は空）
+	FleetTotals      *SummaryTotals             `protobuf:"bytes,5,opt,name=fleet_totals,json=fleetTotals,proto3" json:"fleet_totals,omitempty"`          // 全車両の期間全体の合計
+	Rankings         []*VehicleRanking          `protobuf:"bytes,6,rep,name=rankings,proto3" json:"rankings,omitempty"`                                   // 走行距離・運行回数・燃費のランキング
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *VehicleMonthlySummaryResponse) Reset() {
 	*x = VehicleMonthlySummaryResponse{}
-	mi := &file_dtako_rows_proto_msgTypes[5]
+	mi := &file_dtako_rows_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +898,7 @@ func (x *VehicleMonthlySummaryResponse) String() string {
 func (*VehicleMonthlySummaryResponse) ProtoMessage() {}
 
 func (x *VehicleMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[5]
+	mi := &file_dtako_rows_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +911,7 @@ func (x *VehicleMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VehicleMonthlySummaryResponse.ProtoReflect.Descriptor instead.
 func (*VehicleMonthlySummaryResponse) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{5}
+	return file_dtako_rows_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *VehicleMonthlySummaryResponse) GetVehicleSummaries() []*VehicleMonthlySummaries {
@@ -395,31 +935,5202 @@ func (x *VehicleMonthlySummaryResponse) GetPeriod() string {
 	return ""
 }
 
+func (x *VehicleMonthlySummaryResponse) GetFleetSummaries() []*MonthlyFuelSummary {
+	if x != nil {
+		return x.FleetSummaries
+	}
+	return nil
+}
+
+func (x *VehicleMonthlySummaryResponse) GetFleetTotals() *SummaryTotals {
+	if x != nil {
+		return x.FleetTotals
+	}
+	return nil
+}
+
+func (x *VehicleMonthlySummaryResponse) GetRankings() []*VehicleRanking {
+	if x != nil {
+		return x.Rankings
+	}
+	return nil
+}
+
 // 日次サマリー取得リクエスト
 type GetDailySummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`             // 車輌CC（必須）
 	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
 	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                  // 集計期間の区切り方（省略時は日次）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailySummaryRequest) Reset() {
+	*x = GetDailySummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailySummaryRequest) ProtoMessage() {}
+
+func (x *GetDailySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailySummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetDailySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetDailySummaryRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *GetDailySummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetDailySummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetDailySummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 日次サマリー
+type DailySummary struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	CarCc                string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Date                 string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`                                                               // 集計期間のキー（日次の場合は日付 YYYY-MM-DD）
+	TotalDistance        float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`                      // 走行距離
+	TotalFuel            float64                `protobuf:"fixed64,4,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`                                  // 給油量
+	TripCount            int32                  `protobuf:"varint,5,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                                   // 運行回数
+	FuelEfficiency       float64                `protobuf:"fixed64,6,opt,name=fuel_efficiency,json=fuelEfficiency,proto3" json:"fuel_efficiency,omitempty"`                   // 給油量の推定に使用した燃費 (km/L)
+	FuelEfficiencySource string                 `protobuf:"bytes,7,opt,name=fuel_efficiency_source,json=fuelEfficiencySource,proto3" json:"fuel_efficiency_source,omitempty"` // 燃費の決定元 (override/car_class/max_load_weight/default)
+	FuelBasis            string                 `protobuf:"bytes,8,opt,name=fuel_basis,json=fuelBasis,proto3" json:"fuel_basis,omitempty"`                                    // total_fuel の根拠 (measured / mixed / estimated)
+	MeasuredFuel         float64                `protobuf:"fixed64,9,opt,name=measured_fuel,json=measuredFuel,proto3" json:"measured_fuel,omitempty"`                         // 実給油量の合計 (L)
+	EstimatedFuel        float64                `protobuf:"fixed64,10,opt,name=estimated_fuel,json=estimatedFuel,proto3" json:"estimated_fuel,omitempty"`                     // 推定給油量 (L)
+	RefuelCount          int32                  `protobuf:"varint,11,opt,name=refuel_count,json=refuelCount,proto3" json:"refuel_count,omitempty"`                            // 実給油データの件数
+	Bucket               *PeriodBucket          `protobuf:"bytes,12,opt,name=bucket,proto3" json:"bucket,omitempty"`                                                          // 集計期間
+	UncoveredDistance    float64                `protobuf:"fixed64,13,opt,name=uncovered_distance,json=uncoveredDistance,proto3" json:"uncovered_distance,omitempty"`         // 実給油データでカバーされない走行距離 (km)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DailySummary) Reset() {
+	*x = DailySummary{}
+	mi := &file_dtako_rows_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailySummary) ProtoMessage() {}
+
+func (x *DailySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailySummary.ProtoReflect.Descriptor instead.
+func (*DailySummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DailySummary) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *DailySummary) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailySummary) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *DailySummary) GetTotalFuel() float64 {
+	if x != nil {
+		return x.TotalFuel
+	}
+	return 0
+}
+
+func (x *DailySummary) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *DailySummary) GetFuelEfficiency() float64 {
+	if x != nil {
+		return x.FuelEfficiency
+	}
+	return 0
+}
+
+func (x *DailySummary) GetFuelEfficiencySource() string {
+	if x != nil {
+		return x.FuelEfficiencySource
+	}
+	return ""
+}
+
+func (x *DailySummary) GetFuelBasis() string {
+	if x != nil {
+		return x.FuelBasis
+	}
+	return ""
+}
+
+func (x *DailySummary) GetMeasuredFuel() float64 {
+	if x != nil {
+		return x.MeasuredFuel
+	}
+	return 0
+}
+
+func (x *DailySummary) GetEstimatedFuel() float64 {
+	if x != nil {
+		return x.EstimatedFuel
+	}
+	return 0
+}
+
+func (x *DailySummary) GetRefuelCount() int32 {
+	if x != nil {
+		return x.RefuelCount
+	}
+	return 0
+}
+
+func (x *DailySummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *DailySummary) GetUncoveredDistance() float64 {
+	if x != nil {
+		return x.UncoveredDistance
+	}
+	return 0
+}
+
+// 日次サマリーレスポンス
+type DailySummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summaries     []*DailySummary        `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	CarCc         string                 `protobuf:"bytes,2,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailySummaryResponse) Reset() {
+	*x = DailySummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailySummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailySummaryResponse) ProtoMessage() {}
+
+func (x *DailySummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailySummaryResponse.ProtoReflect.Descriptor instead.
+func (*DailySummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DailySummaryResponse) GetSummaries() []*DailySummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *DailySummaryResponse) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *DailySummaryResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+// CSVエクスポートレスポンス
+type ExportCSVResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CsvData       string                 `protobuf:"bytes,1,opt,name=csv_data,json=csvData,proto3" json:"csv_data,omitempty"`             // CSV形式のデータ（UTF-8 / UTF-8 BOM付きの場合のみ）
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // 推奨ファイル名
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                  // 指定した文字コードでエンコードしたCSV
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // MIMEタイプ（charset付き）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCSVResponse) Reset() {
+	*x = ExportCSVResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCSVResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCSVResponse) ProtoMessage() {}
+
+func (x *ExportCSVResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCSVResponse.ProtoReflect.Descriptor instead.
+func (*ExportCSVResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExportCSVResponse) GetCsvData() string {
+	if x != nil {
+		return x.CsvData
+	}
+	return ""
+}
+
+func (x *ExportCSVResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportCSVResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportCSVResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// 運行データ取得リクエスト
+type GetRowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 運行データID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRowRequest) Reset() {
+	*x = GetRowRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRowRequest) ProtoMessage() {}
+
+func (x *GetRowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRowRequest.ProtoReflect.Descriptor instead.
+func (*GetRowRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRowRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// 運行データレスポンス
+type RowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           *Row                   `protobuf:"bytes,1,opt,name=row,proto3" json:"row,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RowResponse) Reset() {
+	*x = RowResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowResponse) ProtoMessage() {}
+
+func (x *RowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowResponse.ProtoReflect.Descriptor instead.
+func (*RowResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RowResponse) GetRow() *Row {
+	if x != nil {
+		return x.Row
+	}
+	return nil
+}
+
+// 運行データ一覧取得リクエスト
+type ListRowsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Limit               int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                                          // 取得件数上限
+	Offset              int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                                                        // オフセット
+	OrderBy             *string                `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3,oneof" json:"order_by,omitempty"`                                  // ソート順
+	CarCc               *string                `protobuf:"bytes,4,opt,name=car_cc,json=carCc,proto3,oneof" json:"car_cc,omitempty"`                                        // 車輌CC（完全一致）
+	StartDate           string                 `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`                                  // 運行日の開始日 (YYYY-MM-DD、省略時は制限なし)
+	EndDate             string                 `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                                        // 運行日の終了日 (YYYY-MM-DD、省略時は制限なし)
+	MinDistance         *float64               `protobuf:"fixed64,7,opt,name=min_distance,json=minDistance,proto3,oneof" json:"min_distance,omitempty"`                    // 最小走行距離 (km)
+	OperationNos        []string               `protobuf:"bytes,8,rep,name=operation_nos,json=operationNos,proto3" json:"operation_nos,omitempty"`                         // 運行NO（複数指定可）
+	ExcludeZeroDistance bool                   `protobuf:"varint,9,opt,name=exclude_zero_distance,json=excludeZeroDistance,proto3" json:"exclude_zero_distance,omitempty"` // 走行距離0のデータを除外
+	DriverCode          *int32                 `protobuf:"varint,10,opt,name=driver_code,json=driverCode,proto3,oneof" json:"driver_code,omitempty"`                       // 乗務員CD1（完全一致）
+	PageToken           string                 `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                                 // 前回レスポンスの next_page_token（offset・order_by とは併用不可）
+	UseCursor           bool                   `protobuf:"varint,12,opt,name=use_cursor,json=useCursor,proto3" json:"use_cursor,omitempty"`                                // カーソルページングの1ページ目を要求（offset・order_by とは併用不可）
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListRowsRequest) Reset() {
+	*x = ListRowsRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRowsRequest) ProtoMessage() {}
+
+func (x *ListRowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRowsRequest.ProtoReflect.Descriptor instead.
+func (*ListRowsRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListRowsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRowsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRowsRequest) GetOrderBy() string {
+	if x != nil && x.OrderBy != nil {
+		return *x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListRowsRequest) GetCarCc() string {
+	if x != nil && x.CarCc != nil {
+		return *x.CarCc
+	}
+	return ""
+}
+
+func (x *ListRowsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ListRowsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ListRowsRequest) GetMinDistance() float64 {
+	if x != nil && x.MinDistance != nil {
+		return *x.MinDistance
+	}
+	return 0
+}
+
+func (x *ListRowsRequest) GetOperationNos() []string {
+	if x != nil {
+		return x.OperationNos
+	}
+	return nil
+}
+
+func (x *ListRowsRequest) GetExcludeZeroDistance() bool {
+	if x != nil {
+		return x.ExcludeZeroDistance
+	}
+	return false
+}
+
+func (x *ListRowsRequest) GetDriverCode() int32 {
+	if x != nil && x.DriverCode != nil {
+		return *x.DriverCode
+	}
+	return 0
+}
+
+func (x *ListRowsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRowsRequest) GetUseCursor() bool {
+	if x != nil {
+		return x.UseCursor
+	}
+	return false
+}
+
+// 運行データ一覧レスポンス
+type ListRowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*Row                 `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // フィルタ後の総件数
+	QueryPath     string                 `protobuf:"bytes,3,opt,name=query_path,json=queryPath,proto3" json:"query_path,omitempty"`               // 取得経路 (operation_no_lookup / date_range_seek / full_scan)
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 次のページのトークン（最終ページの場合は空）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRowsResponse) Reset() {
+	*x = ListRowsResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRowsResponse) ProtoMessage() {}
+
+func (x *ListRowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRowsResponse.ProtoReflect.Descriptor instead.
+func (*ListRowsResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRowsResponse) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *ListRowsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListRowsResponse) GetQueryPath() string {
+	if x != nil {
+		return x.QueryPath
+	}
+	return ""
+}
+
+func (x *ListRowsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// 運行データ
+type Row struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OperationNo          string                 `protobuf:"bytes,2,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"`
+	ReadDate             string                 `protobuf:"bytes,3,opt,name=read_date,json=readDate,proto3" json:"read_date,omitempty"`
+	OperationDate        string                 `protobuf:"bytes,4,opt,name=operation_date,json=operationDate,proto3" json:"operation_date,omitempty"`
+	CarCode              int32                  `protobuf:"varint,5,opt,name=car_code,json=carCode,proto3" json:"car_code,omitempty"`
+	CarCc                string                 `protobuf:"bytes,6,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	StartWorkDatetime    string                 `protobuf:"bytes,7,opt,name=start_work_datetime,json=startWorkDatetime,proto3" json:"start_work_datetime,omitempty"`
+	EndWorkDatetime      string                 `protobuf:"bytes,8,opt,name=end_work_datetime,json=endWorkDatetime,proto3" json:"end_work_datetime,omitempty"`
+	DepartureDatetime    string                 `protobuf:"bytes,9,opt,name=departure_datetime,json=departureDatetime,proto3" json:"departure_datetime,omitempty"`
+	ReturnDatetime       string                 `protobuf:"bytes,10,opt,name=return_datetime,json=returnDatetime,proto3" json:"return_datetime,omitempty"`
+	DepartureMeter       float64                `protobuf:"fixed64,11,opt,name=departure_meter,json=departureMeter,proto3" json:"departure_meter,omitempty"`
+	ReturnMeter          float64                `protobuf:"fixed64,12,opt,name=return_meter,json=returnMeter,proto3" json:"return_meter,omitempty"`
+	TotalDistance        float64                `protobuf:"fixed64,13,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`
+	DriverCode1          *int32                 `protobuf:"varint,14,opt,name=driver_code1,json=driverCode1,proto3,oneof" json:"driver_code1,omitempty"`
+	LoadedDistance       *float64               `protobuf:"fixed64,15,opt,name=loaded_distance,json=loadedDistance,proto3,oneof" json:"loaded_distance,omitempty"`
+	DestinationCityName  *string                `protobuf:"bytes,16,opt,name=destination_city_name,json=destinationCityName,proto3,oneof" json:"destination_city_name,omitempty"`
+	DestinationPlaceName *string                `protobuf:"bytes,17,opt,name=destination_place_name,json=destinationPlaceName,proto3,oneof" json:"destination_place_name,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	mi := &file_dtako_rows_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Row) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Row) GetOperationNo() string {
+	if x != nil {
+		return x.OperationNo
+	}
+	return ""
+}
+
+func (x *Row) GetReadDate() string {
+	if x != nil {
+		return x.ReadDate
+	}
+	return ""
+}
+
+func (x *Row) GetOperationDate() string {
+	if x != nil {
+		return x.OperationDate
+	}
+	return ""
+}
+
+func (x *Row) GetCarCode() int32 {
+	if x != nil {
+		return x.CarCode
+	}
+	return 0
+}
+
+func (x *Row) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *Row) GetStartWorkDatetime() string {
+	if x != nil {
+		return x.StartWorkDatetime
+	}
+	return ""
+}
+
+func (x *Row) GetEndWorkDatetime() string {
+	if x != nil {
+		return x.EndWorkDatetime
+	}
+	return ""
+}
+
+func (x *Row) GetDepartureDatetime() string {
+	if x != nil {
+		return x.DepartureDatetime
+	}
+	return ""
+}
+
+func (x *Row) GetReturnDatetime() string {
+	if x != nil {
+		return x.ReturnDatetime
+	}
+	return ""
+}
+
+func (x *Row) GetDepartureMeter() float64 {
+	if x != nil {
+		return x.DepartureMeter
+	}
+	return 0
+}
+
+func (x *Row) GetReturnMeter() float64 {
+	if x != nil {
+		return x.ReturnMeter
+	}
+	return 0
+}
+
+func (x *Row) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *Row) GetDriverCode1() int32 {
+	if x != nil && x.DriverCode1 != nil {
+		return *x.DriverCode1
+	}
+	return 0
+}
+
+func (x *Row) GetLoadedDistance() float64 {
+	if x != nil && x.LoadedDistance != nil {
+		return *x.LoadedDistance
+	}
+	return 0
+}
+
+func (x *Row) GetDestinationCityName() string {
+	if x != nil && x.DestinationCityName != nil {
+		return *x.DestinationCityName
+	}
+	return ""
+}
+
+func (x *Row) GetDestinationPlaceName() string {
+	if x != nil && x.DestinationPlaceName != nil {
+		return *x.DestinationPlaceName
+	}
+	return ""
+}
+
+// 運行データストリーミングリクエスト
+type StreamRowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         *string                `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3,oneof" json:"car_cc,omitempty"`       // 車輌CC（省略時は全車両）
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD、省略時は制限なし)
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD、省略時は制限なし)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRowsRequest) Reset() {
+	*x = StreamRowsRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRowsRequest) ProtoMessage() {}
+
+func (x *StreamRowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRowsRequest.ProtoReflect.Descriptor instead.
+func (*StreamRowsRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StreamRowsRequest) GetCarCc() string {
+	if x != nil && x.CarCc != nil {
+		return *x.CarCc
+	}
+	return ""
+}
+
+func (x *StreamRowsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *StreamRowsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+// 運行データのバッチ（db_serviceの1ページ分）
+type RowBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*Row                 `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	BatchIndex    int32                  `protobuf:"varint,2,opt,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"` // バッチ番号（0始まり）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RowBatch) Reset() {
+	*x = RowBatch{}
+	mi := &file_dtako_rows_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RowBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowBatch) ProtoMessage() {}
+
+func (x *RowBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowBatch.ProtoReflect.Descriptor instead.
+func (*RowBatch) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RowBatch) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *RowBatch) GetBatchIndex() int32 {
+	if x != nil {
+		return x.BatchIndex
+	}
+	return 0
+}
+
+// 乗務員別サマリー取得リクエスト
+type GetDriverSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`           // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                 // 終了日 (YYYY-MM-DD)
+	DriverCode    *int32                 `protobuf:"varint,3,opt,name=driver_code,json=driverCode,proto3,oneof" json:"driver_code,omitempty"` // 乗務員CD1（省略時は全乗務員）
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                            // 集計期間の区切り方（省略時は月次RPCは月、日次RPCは日）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverSummaryRequest) Reset() {
+	*x = GetDriverSummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverSummaryRequest) ProtoMessage() {}
+
+func (x *GetDriverSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDriverSummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetDriverSummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetDriverSummaryRequest) GetDriverCode() int32 {
+	if x != nil && x.DriverCode != nil {
+		return *x.DriverCode
+	}
+	return 0
+}
+
+func (x *GetDriverSummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 乗務員別の期間サマリー
+type DriverPeriodSummary struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	DriverCode           string                 `protobuf:"bytes,1,opt,name=driver_code,json=driverCode,proto3" json:"driver_code,omitempty"`                                    // 乗務員CD1（未設定の運行は "unassigned"）
+	Period               string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`                                                              // 集計期間のキー (月次: YYYY-MM, 日次: YYYY-MM-DD など)
+	TotalDistance        float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`                         // 総走行距離 (km)
+	LoadedDistance       float64                `protobuf:"fixed64,4,opt,name=loaded_distance,json=loadedDistance,proto3" json:"loaded_distance,omitempty"`                      // 実車走行距離 (km)
+	TripCount            int32                  `protobuf:"varint,5,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                                      // 運行回数
+	GeneralRoadDriveTime int32                  `protobuf:"varint,6,opt,name=general_road_drive_time,json=generalRoadDriveTime,proto3" json:"general_road_drive_time,omitempty"` // 一般道運転時間
+	HighwayDriveTime     int32                  `protobuf:"varint,7,opt,name=highway_drive_time,json=highwayDriveTime,proto3" json:"highway_drive_time,omitempty"`               // 高速道運転時間
+	BypassDriveTime      int32                  `protobuf:"varint,8,opt,name=bypass_drive_time,json=bypassDriveTime,proto3" json:"bypass_drive_time,omitempty"`                  // バイパス運転時間
+	Work1Time            int32                  `protobuf:"varint,9,opt,name=work1_time,json=work1Time,proto3" json:"work1_time,omitempty"`                                      // 作業１時間
+	Work2Time            int32                  `protobuf:"varint,10,opt,name=work2_time,json=work2Time,proto3" json:"work2_time,omitempty"`                                     // 作業２時間
+	Work3Time            int32                  `protobuf:"varint,11,opt,name=work3_time,json=work3Time,proto3" json:"work3_time,omitempty"`                                     // 作業３時間
+	Work4Time            int32                  `protobuf:"varint,12,opt,name=work4_time,json=work4Time,proto3" json:"work4_time,omitempty"`                                     // 作業４時間
+	Bucket               *PeriodBucket          `protobuf:"bytes,13,opt,name=bucket,proto3" json:"bucket,omitempty"`                                                             // 集計期間
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DriverPeriodSummary) Reset() {
+	*x = DriverPeriodSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverPeriodSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverPeriodSummary) ProtoMessage() {}
+
+func (x *DriverPeriodSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverPeriodSummary.ProtoReflect.Descriptor instead.
+func (*DriverPeriodSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DriverPeriodSummary) GetDriverCode() string {
+	if x != nil {
+		return x.DriverCode
+	}
+	return ""
+}
+
+func (x *DriverPeriodSummary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *DriverPeriodSummary) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetLoadedDistance() float64 {
+	if x != nil {
+		return x.LoadedDistance
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetGeneralRoadDriveTime() int32 {
+	if x != nil {
+		return x.GeneralRoadDriveTime
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetHighwayDriveTime() int32 {
+	if x != nil {
+		return x.HighwayDriveTime
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetBypassDriveTime() int32 {
+	if x != nil {
+		return x.BypassDriveTime
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetWork1Time() int32 {
+	if x != nil {
+		return x.Work1Time
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetWork2Time() int32 {
+	if x != nil {
+		return x.Work2Time
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetWork3Time() int32 {
+	if x != nil {
+		return x.Work3Time
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetWork4Time() int32 {
+	if x != nil {
+		return x.Work4Time
+	}
+	return 0
+}
+
+func (x *DriverPeriodSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// 乗務員別データ
+type DriverSummaries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverCode    string                 `protobuf:"bytes,1,opt,name=driver_code,json=driverCode,proto3" json:"driver_code,omitempty"`
+	Summaries     []*DriverPeriodSummary `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverSummaries) Reset() {
+	*x = DriverSummaries{}
+	mi := &file_dtako_rows_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverSummaries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverSummaries) ProtoMessage() {}
+
+func (x *DriverSummaries) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverSummaries.ProtoReflect.Descriptor instead.
+func (*DriverSummaries) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DriverSummaries) GetDriverCode() string {
+	if x != nil {
+		return x.DriverCode
+	}
+	return ""
+}
+
+func (x *DriverSummaries) GetSummaries() []*DriverPeriodSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+// 乗務員別サマリーレスポンス
+type DriverSummaryResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DriverSummaries []*DriverSummaries     `protobuf:"bytes,1,rep,name=driver_summaries,json=driverSummaries,proto3" json:"driver_summaries,omitempty"` // 乗務員CD1順（"unassigned" は末尾）
+	TotalDrivers    int32                  `protobuf:"varint,2,opt,name=total_drivers,json=totalDrivers,proto3" json:"total_drivers,omitempty"`
+	Period          string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DriverSummaryResponse) Reset() {
+	*x = DriverSummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverSummaryResponse) ProtoMessage() {}
+
+func (x *DriverSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverSummaryResponse.ProtoReflect.Descriptor instead.
+func (*DriverSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DriverSummaryResponse) GetDriverSummaries() []*DriverSummaries {
+	if x != nil {
+		return x.DriverSummaries
+	}
+	return nil
+}
+
+func (x *DriverSummaryResponse) GetTotalDrivers() int32 {
+	if x != nil {
+		return x.TotalDrivers
+	}
+	return 0
+}
+
+func (x *DriverSummaryResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+// 実車率集計リクエスト
+type GetLoadedRatioSummaryRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CarCc              string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                            // 車輌CC（省略時は全車両）
+	StartDate          string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`                                // 開始日 (YYYY-MM-DD)
+	EndDate            string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                                      // 終了日 (YYYY-MM-DD)
+	PoorRatioThreshold float64                `protobuf:"fixed64,4,opt,name=poor_ratio_threshold,json=poorRatioThreshold,proto3" json:"poor_ratio_threshold,omitempty"` // 低実車率とする実車率（距離）のしきい値（省略時0.5）
+	PoorMonthsStreak   int32                  `protobuf:"varint,5,opt,name=poor_months_streak,json=poorMonthsStreak,proto3" json:"poor_months_streak,omitempty"`        // 要注意とする低実車率の連続期間数（省略時3）
+	Bucketing          *Bucketing             `protobuf:"bytes,6,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                                                 // 集計期間の区切り方（省略時は月次）
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetLoadedRatioSummaryRequest) Reset() {
+	*x = GetLoadedRatioSummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoadedRatioSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoadedRatioSummaryRequest) ProtoMessage() {}
+
+func (x *GetLoadedRatioSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoadedRatioSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetLoadedRatioSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetLoadedRatioSummaryRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *GetLoadedRatioSummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetLoadedRatioSummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetLoadedRatioSummaryRequest) GetPoorRatioThreshold() float64 {
+	if x != nil {
+		return x.PoorRatioThreshold
+	}
+	return 0
+}
+
+func (x *GetLoadedRatioSummaryRequest) GetPoorMonthsStreak() int32 {
+	if x != nil {
+		return x.PoorMonthsStreak
+	}
+	return 0
+}
+
+func (x *GetLoadedRatioSummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 月次実車率サマリー
+type LoadedRatioSummary struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	CarCc               string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	YearMonth           string                 `protobuf:"bytes,2,opt,name=year_month,json=yearMonth,proto3" json:"year_month,omitempty"`                                   // 集計期間のキー（月次の場合は年月 YYYY-MM形式）
+	TotalDistance       float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`                     // 総走行距離 (km)
+	LoadedDistance      float64                `protobuf:"fixed64,4,opt,name=loaded_distance,json=loadedDistance,proto3" json:"loaded_distance,omitempty"`                  // 実車走行距離 (km)
+	EmptyDistance       float64                `protobuf:"fixed64,5,opt,name=empty_distance,json=emptyDistance,proto3" json:"empty_distance,omitempty"`                     // 空車走行距離 (km)
+	LoadedDriveTime     int32                  `protobuf:"varint,6,opt,name=loaded_drive_time,json=loadedDriveTime,proto3" json:"loaded_drive_time,omitempty"`              // 実車走行時間
+	EmptyDriveTime      int32                  `protobuf:"varint,7,opt,name=empty_drive_time,json=emptyDriveTime,proto3" json:"empty_drive_time,omitempty"`                 // 空車走行時間
+	TripCount           int32                  `protobuf:"varint,8,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                                  // 運行回数
+	LoadedDistanceRatio float64                `protobuf:"fixed64,9,opt,name=loaded_distance_ratio,json=loadedDistanceRatio,proto3" json:"loaded_distance_ratio,omitempty"` // 実車率（距離）
+	LoadedTimeRatio     float64                `protobuf:"fixed64,10,opt,name=loaded_time_ratio,json=loadedTimeRatio,proto3" json:"loaded_time_ratio,omitempty"`            // 実車率（時間）
+	Poor                bool                   `protobuf:"varint,11,opt,name=poor,proto3" json:"poor,omitempty"`                                                            // しきい値未満の期間
+	Bucket              *PeriodBucket          `protobuf:"bytes,12,opt,name=bucket,proto3" json:"bucket,omitempty"`                                                         // 集計期間
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LoadedRatioSummary) Reset() {
+	*x = LoadedRatioSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadedRatioSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadedRatioSummary) ProtoMessage() {}
+
+func (x *LoadedRatioSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadedRatioSummary.ProtoReflect.Descriptor instead.
+func (*LoadedRatioSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LoadedRatioSummary) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *LoadedRatioSummary) GetYearMonth() string {
+	if x != nil {
+		return x.YearMonth
+	}
+	return ""
+}
+
+func (x *LoadedRatioSummary) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetLoadedDistance() float64 {
+	if x != nil {
+		return x.LoadedDistance
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetEmptyDistance() float64 {
+	if x != nil {
+		return x.EmptyDistance
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetLoadedDriveTime() int32 {
+	if x != nil {
+		return x.LoadedDriveTime
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetEmptyDriveTime() int32 {
+	if x != nil {
+		return x.EmptyDriveTime
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetLoadedDistanceRatio() float64 {
+	if x != nil {
+		return x.LoadedDistanceRatio
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetLoadedTimeRatio() float64 {
+	if x != nil {
+		return x.LoadedTimeRatio
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummary) GetPoor() bool {
+	if x != nil {
+		return x.Poor
+	}
+	return false
+}
+
+func (x *LoadedRatioSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// 車両別実車率
+type VehicleLoadedRatio struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	CarCc               string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Summaries           []*LoadedRatioSummary  `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"`                                                    // 期間順
+	LoadedDistanceRatio float64                `protobuf:"fixed64,3,opt,name=loaded_distance_ratio,json=loadedDistanceRatio,proto3" json:"loaded_distance_ratio,omitempty"` // 期間全体の実車率（距離）
+	LoadedTimeRatio     float64                `protobuf:"fixed64,4,opt,name=loaded_time_ratio,json=loadedTimeRatio,proto3" json:"loaded_time_ratio,omitempty"`             // 期間全体の実車率（時間）
+	PoorMonths          int32                  `protobuf:"varint,5,opt,name=poor_months,json=poorMonths,proto3" json:"poor_months,omitempty"`                               // しきい値未満の期間数
+	Flagged             bool                   `protobuf:"varint,6,opt,name=flagged,proto3" json:"flagged,omitempty"`                                                       // 低実車率の期間が連続した車両
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *VehicleLoadedRatio) Reset() {
+	*x = VehicleLoadedRatio{}
+	mi := &file_dtako_rows_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleLoadedRatio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleLoadedRatio) ProtoMessage() {}
+
+func (x *VehicleLoadedRatio) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleLoadedRatio.ProtoReflect.Descriptor instead.
+func (*VehicleLoadedRatio) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VehicleLoadedRatio) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *VehicleLoadedRatio) GetSummaries() []*LoadedRatioSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *VehicleLoadedRatio) GetLoadedDistanceRatio() float64 {
+	if x != nil {
+		return x.LoadedDistanceRatio
+	}
+	return 0
+}
+
+func (x *VehicleLoadedRatio) GetLoadedTimeRatio() float64 {
+	if x != nil {
+		return x.LoadedTimeRatio
+	}
+	return 0
+}
+
+func (x *VehicleLoadedRatio) GetPoorMonths() int32 {
+	if x != nil {
+		return x.PoorMonths
+	}
+	return 0
+}
+
+func (x *VehicleLoadedRatio) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+// 実車率集計レスポンス
+type LoadedRatioSummaryResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles        []*VehicleLoadedRatio  `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`                                       // 車輌CC順
+	FlaggedVehicles int32                  `protobuf:"varint,2,opt,name=flagged_vehicles,json=flaggedVehicles,proto3" json:"flagged_vehicles,omitempty"` // 要注意車両数
+	Period          string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LoadedRatioSummaryResponse) Reset() {
+	*x = LoadedRatioSummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadedRatioSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadedRatioSummaryResponse) ProtoMessage() {}
+
+func (x *LoadedRatioSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadedRatioSummaryResponse.ProtoReflect.Descriptor instead.
+func (*LoadedRatioSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LoadedRatioSummaryResponse) GetVehicles() []*VehicleLoadedRatio {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *LoadedRatioSummaryResponse) GetFlaggedVehicles() int32 {
+	if x != nil {
+		return x.FlaggedVehicles
+	}
+	return 0
+}
+
+func (x *LoadedRatioSummaryResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+// 改善基準告示のしきい値（分、0の場合はデフォルト値）
+type ComplianceThresholds struct {
+	state                          protoimpl.MessageState `protogen:"open.v1"`
+	MaxDailyRestraintMinutes       int32                  `protobuf:"varint,1,opt,name=max_daily_restraint_minutes,json=maxDailyRestraintMinutes,proto3" json:"max_daily_restraint_minutes,omitempty"`                   // 1日の拘束時間・原則（デフォルト780 = 13時間）
+	ExtendedDailyRestraintMinutes  int32                  `protobuf:"varint,2,opt,name=extended_daily_restraint_minutes,json=extendedDailyRestraintMinutes,proto3" json:"extended_daily_restraint_minutes,omitempty"`    // 1日の拘束時間・最大（デフォルト900 = 15時間）
+	MinRestIntervalMinutes         int32                  `protobuf:"varint,3,opt,name=min_rest_interval_minutes,json=minRestIntervalMinutes,proto3" json:"min_rest_interval_minutes,omitempty"`                         // 休息期間・下限（デフォルト540 = 9時間）
+	RecommendedRestIntervalMinutes int32                  `protobuf:"varint,4,opt,name=recommended_rest_interval_minutes,json=recommendedRestIntervalMinutes,proto3" json:"recommended_rest_interval_minutes,omitempty"` // 休息期間・努力義務（デフォルト660 = 11時間）
+	MaxContinuousDrivingMinutes    int32                  `protobuf:"varint,5,opt,name=max_continuous_driving_minutes,json=maxContinuousDrivingMinutes,proto3" json:"max_continuous_driving_minutes,omitempty"`          // 連続運転時間（デフォルト240 = 4時間）
+	MinBreakMinutes                int32                  `protobuf:"varint,6,opt,name=min_break_minutes,json=minBreakMinutes,proto3" json:"min_break_minutes,omitempty"`                                                // 連続運転の中断に必要な休憩（デフォルト30分）
+	MaxTwoDayAvgDrivingMinutes     int32                  `protobuf:"varint,7,opt,name=max_two_day_avg_driving_minutes,json=maxTwoDayAvgDrivingMinutes,proto3" json:"max_two_day_avg_driving_minutes,omitempty"`         // 2日平均の運転時間（デフォルト540 = 9時間）
+	MaxMonthlyRestraintMinutes     int32                  `protobuf:"varint,8,opt,name=max_monthly_restraint_minutes,json=maxMonthlyRestraintMinutes,proto3" json:"max_monthly_restraint_minutes,omitempty"`             // 1か月の拘束時間（デフォルト17040 = 284時間）
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
+}
+
+func (x *ComplianceThresholds) Reset() {
+	*x = ComplianceThresholds{}
+	mi := &file_dtako_rows_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceThresholds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceThresholds) ProtoMessage() {}
+
+func (x *ComplianceThresholds) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceThresholds.ProtoReflect.Descriptor instead.
+func (*ComplianceThresholds) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ComplianceThresholds) GetMaxDailyRestraintMinutes() int32 {
+	if x != nil {
+		return x.MaxDailyRestraintMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetExtendedDailyRestraintMinutes() int32 {
+	if x != nil {
+		return x.ExtendedDailyRestraintMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetMinRestIntervalMinutes() int32 {
+	if x != nil {
+		return x.MinRestIntervalMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetRecommendedRestIntervalMinutes() int32 {
+	if x != nil {
+		return x.RecommendedRestIntervalMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetMaxContinuousDrivingMinutes() int32 {
+	if x != nil {
+		return x.MaxContinuousDrivingMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetMinBreakMinutes() int32 {
+	if x != nil {
+		return x.MinBreakMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetMaxTwoDayAvgDrivingMinutes() int32 {
+	if x != nil {
+		return x.MaxTwoDayAvgDrivingMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceThresholds) GetMaxMonthlyRestraintMinutes() int32 {
+	if x != nil {
+		return x.MaxMonthlyRestraintMinutes
+	}
+	return 0
+}
+
+// 改善基準告示チェックリクエスト
+type CheckDriverComplianceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`           // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                 // 終了日 (YYYY-MM-DD)
+	DriverCode    *int32                 `protobuf:"varint,3,opt,name=driver_code,json=driverCode,proto3,oneof" json:"driver_code,omitempty"` // 乗務員CD1（省略時は全乗務員）
+	Thresholds    *ComplianceThresholds  `protobuf:"bytes,4,opt,name=thresholds,proto3" json:"thresholds,omitempty"`                          // しきい値（省略時はデフォルト）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDriverComplianceRequest) Reset() {
+	*x = CheckDriverComplianceRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDriverComplianceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDriverComplianceRequest) ProtoMessage() {}
+
+func (x *CheckDriverComplianceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDriverComplianceRequest.ProtoReflect.Descriptor instead.
+func (*CheckDriverComplianceRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CheckDriverComplianceRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *CheckDriverComplianceRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *CheckDriverComplianceRequest) GetDriverCode() int32 {
+	if x != nil && x.DriverCode != nil {
+		return *x.DriverCode
+	}
+	return 0
+}
+
+func (x *CheckDriverComplianceRequest) GetThresholds() *ComplianceThresholds {
+	if x != nil {
+		return x.Thresholds
+	}
+	return nil
+}
+
+// 違反・警告
+type ComplianceViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverCode    string                 `protobuf:"bytes,1,opt,name=driver_code,json=driverCode,proto3" json:"driver_code,omitempty"`           // 乗務員CD1
+	Rule          string                 `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`                                         // daily_restraint / rest_interval / continuous_driving / two_day_driving / monthly_restraint
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`                                 // violation（上限超過） / warning（原則値超過）
+	Date          string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`                                         // 対象日 (YYYY-MM-DD) または対象月 (YYYY-MM)
+	ActualMinutes int32                  `protobuf:"varint,5,opt,name=actual_minutes,json=actualMinutes,proto3" json:"actual_minutes,omitempty"` // 実績値（分）
+	LimitMinutes  int32                  `protobuf:"varint,6,opt,name=limit_minutes,json=limitMinutes,proto3" json:"limit_minutes,omitempty"`    // しきい値（分）
+	RowIds        []string               `protobuf:"bytes,7,rep,name=row_ids,json=rowIds,proto3" json:"row_ids,omitempty"`                       // 対象の運行データID
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`                                   // 説明
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComplianceViolation) Reset() {
+	*x = ComplianceViolation{}
+	mi := &file_dtako_rows_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceViolation) ProtoMessage() {}
+
+func (x *ComplianceViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceViolation.ProtoReflect.Descriptor instead.
+func (*ComplianceViolation) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ComplianceViolation) GetDriverCode() string {
+	if x != nil {
+		return x.DriverCode
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetActualMinutes() int32 {
+	if x != nil {
+		return x.ActualMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceViolation) GetLimitMinutes() int32 {
+	if x != nil {
+		return x.LimitMinutes
+	}
+	return 0
+}
+
+func (x *ComplianceViolation) GetRowIds() []string {
+	if x != nil {
+		return x.RowIds
+	}
+	return nil
+}
+
+func (x *ComplianceViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 乗務員の月次実績
+type DriverMonthlyCompliance struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	YearMonth        string                 `protobuf:"bytes,1,opt,name=year_month,json=yearMonth,proto3" json:"year_month,omitempty"`                       // 年月 (YYYY-MM形式)
+	RestraintMinutes int32                  `protobuf:"varint,2,opt,name=restraint_minutes,json=restraintMinutes,proto3" json:"restraint_minutes,omitempty"` // 拘束時間合計（分）
+	DrivingMinutes   int32                  `protobuf:"varint,3,opt,name=driving_minutes,json=drivingMinutes,proto3" json:"driving_minutes,omitempty"`       // 運転時間合計（分）
+	ShiftCount       int32                  `protobuf:"varint,4,opt,name=shift_count,json=shiftCount,proto3" json:"shift_count,omitempty"`                   // 勤務回数
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DriverMonthlyCompliance) Reset() {
+	*x = DriverMonthlyCompliance{}
+	mi := &file_dtako_rows_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverMonthlyCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverMonthlyCompliance) ProtoMessage() {}
+
+func (x *DriverMonthlyCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverMonthlyCompliance.ProtoReflect.Descriptor instead.
+func (*DriverMonthlyCompliance) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DriverMonthlyCompliance) GetYearMonth() string {
+	if x != nil {
+		return x.YearMonth
+	}
+	return ""
+}
+
+func (x *DriverMonthlyCompliance) GetRestraintMinutes() int32 {
+	if x != nil {
+		return x.RestraintMinutes
+	}
+	return 0
+}
+
+func (x *DriverMonthlyCompliance) GetDrivingMinutes() int32 {
+	if x != nil {
+		return x.DrivingMinutes
+	}
+	return 0
+}
+
+func (x *DriverMonthlyCompliance) GetShiftCount() int32 {
+	if x != nil {
+		return x.ShiftCount
+	}
+	return 0
+}
+
+// 乗務員別のチェック結果
+type DriverCompliance struct {
+	state                    protoimpl.MessageState     `protogen:"open.v1"`
+	DriverCode               string                     `protobuf:"bytes,1,opt,name=driver_code,json=driverCode,proto3" json:"driver_code,omitempty"`
+	Monthly                  []*DriverMonthlyCompliance `protobuf:"bytes,2,rep,name=monthly,proto3" json:"monthly,omitempty"`                                                                        // 年月順
+	MaxDailyRestraintMinutes int32                      `protobuf:"varint,3,opt,name=max_daily_restraint_minutes,json=maxDailyRestraintMinutes,proto3" json:"max_daily_restraint_minutes,omitempty"` // 最大拘束時間（1勤務、分）
+	MinRestIntervalMinutes   int32                      `protobuf:"varint,4,opt,name=min_rest_interval_minutes,json=minRestIntervalMinutes,proto3" json:"min_rest_interval_minutes,omitempty"`       // 最短休息期間（分）
+	ViolationCount           int32                      `protobuf:"varint,5,opt,name=violation_count,json=violationCount,proto3" json:"violation_count,omitempty"`
+	WarningCount             int32                      `protobuf:"varint,6,opt,name=warning_count,json=warningCount,proto3" json:"warning_count,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *DriverCompliance) Reset() {
+	*x = DriverCompliance{}
+	mi := &file_dtako_rows_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverCompliance) ProtoMessage() {}
+
+func (x *DriverCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverCompliance.ProtoReflect.Descriptor instead.
+func (*DriverCompliance) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DriverCompliance) GetDriverCode() string {
+	if x != nil {
+		return x.DriverCode
+	}
+	return ""
+}
+
+func (x *DriverCompliance) GetMonthly() []*DriverMonthlyCompliance {
+	if x != nil {
+		return x.Monthly
+	}
+	return nil
+}
+
+func (x *DriverCompliance) GetMaxDailyRestraintMinutes() int32 {
+	if x != nil {
+		return x.MaxDailyRestraintMinutes
+	}
+	return 0
+}
+
+func (x *DriverCompliance) GetMinRestIntervalMinutes() int32 {
+	if x != nil {
+		return x.MinRestIntervalMinutes
+	}
+	return 0
+}
+
+func (x *DriverCompliance) GetViolationCount() int32 {
+	if x != nil {
+		return x.ViolationCount
+	}
+	return 0
+}
+
+func (x *DriverCompliance) GetWarningCount() int32 {
+	if x != nil {
+		return x.WarningCount
+	}
+	return 0
+}
+
+// 改善基準告示チェックレスポンス
+type DriverComplianceResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Drivers           []*DriverCompliance    `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty"` // 乗務員CD1順
+	Violations        []*ComplianceViolation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	AppliedThresholds *ComplianceThresholds  `protobuf:"bytes,3,opt,name=applied_thresholds,json=appliedThresholds,proto3" json:"applied_thresholds,omitempty"` // 適用したしきい値
+	Period            string                 `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DriverComplianceResponse) Reset() {
+	*x = DriverComplianceResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverComplianceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverComplianceResponse) ProtoMessage() {}
+
+func (x *DriverComplianceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverComplianceResponse.ProtoReflect.Descriptor instead.
+func (*DriverComplianceResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DriverComplianceResponse) GetDrivers() []*DriverCompliance {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
+func (x *DriverComplianceResponse) GetViolations() []*ComplianceViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *DriverComplianceResponse) GetAppliedThresholds() *ComplianceThresholds {
+	if x != nil {
+		return x.AppliedThresholds
+	}
+	return nil
+}
+
+func (x *DriverComplianceResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+// データ品質チェックリクエスト
+type ValidateRowsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	CarCc               string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                               // 車輌CC（省略時は全車両）
+	StartDate           string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`                                   // 開始日 (YYYY-MM-DD)
+	EndDate             string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                                         // 終了日 (YYYY-MM-DD)
+	MeterToleranceKm    float64                `protobuf:"fixed64,4,opt,name=meter_tolerance_km,json=meterToleranceKm,proto3" json:"meter_tolerance_km,omitempty"`          // 出庫メーターと前回帰庫メーターの許容差（省略時1.0）
+	DistanceToleranceKm float64                `protobuf:"fixed64,5,opt,name=distance_tolerance_km,json=distanceToleranceKm,proto3" json:"distance_tolerance_km,omitempty"` // 総走行距離とメーター差の許容差（省略時1.0）
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ValidateRowsRequest) Reset() {
+	*x = ValidateRowsRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRowsRequest) ProtoMessage() {}
+
+func (x *ValidateRowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRowsRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRowsRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ValidateRowsRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *ValidateRowsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ValidateRowsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ValidateRowsRequest) GetMeterToleranceKm() float64 {
+	if x != nil {
+		return x.MeterToleranceKm
+	}
+	return 0
+}
+
+func (x *ValidateRowsRequest) GetDistanceToleranceKm() float64 {
+	if x != nil {
+		return x.DistanceToleranceKm
+	}
+	return 0
+}
+
+// データ品質の問題
+type ValidationIssue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                   // invalid_date / return_before_departure / overlapping_trip / meter_discontinuity / distance_mismatch
+	Severity      string                 `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`           // error / warning
+	CarCc         string                 `protobuf:"bytes,3,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`    // 車輌CC
+	RowIds        []string               `protobuf:"bytes,4,rep,name=row_ids,json=rowIds,proto3" json:"row_ids,omitempty"` // 該当する運行データID
+	Field         string                 `protobuf:"bytes,5,opt,name=field,proto3" json:"field,omitempty"`                 // 該当フィールド
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`             // 説明
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationIssue) Reset() {
+	*x = ValidationIssue{}
+	mi := &file_dtako_rows_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationIssue) ProtoMessage() {}
+
+func (x *ValidationIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationIssue.ProtoReflect.Descriptor instead.
+func (*ValidationIssue) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ValidationIssue) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidationIssue) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ValidationIssue) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *ValidationIssue) GetRowIds() []string {
+	if x != nil {
+		return x.RowIds
+	}
+	return nil
+}
+
+func (x *ValidationIssue) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationIssue) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// データ品質チェックレスポンス
+type ValidationReport struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Issues          []*ValidationIssue     `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`                                           // 車輌CC順
+	RowsChecked     int32                  `protobuf:"varint,2,opt,name=rows_checked,json=rowsChecked,proto3" json:"rows_checked,omitempty"`             // チェックした行数
+	VehiclesChecked int32                  `protobuf:"varint,3,opt,name=vehicles_checked,json=vehiclesChecked,proto3" json:"vehicles_checked,omitempty"` // チェックした車両数
+	ErrorCount      int32                  `protobuf:"varint,4,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	WarningCount    int32                  `protobuf:"varint,5,opt,name=warning_count,json=warningCount,proto3" json:"warning_count,omitempty"`
+	Period          string                 `protobuf:"bytes,6,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ValidationReport) Reset() {
+	*x = ValidationReport{}
+	mi := &file_dtako_rows_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationReport) ProtoMessage() {}
+
+func (x *ValidationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationReport.ProtoReflect.Descriptor instead.
+func (*ValidationReport) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ValidationReport) GetIssues() []*ValidationIssue {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+func (x *ValidationReport) GetRowsChecked() int32 {
+	if x != nil {
+		return x.RowsChecked
+	}
+	return 0
+}
+
+func (x *ValidationReport) GetVehiclesChecked() int32 {
+	if x != nil {
+		return x.VehiclesChecked
+	}
+	return 0
+}
+
+func (x *ValidationReport) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *ValidationReport) GetWarningCount() int32 {
+	if x != nil {
+		return x.WarningCount
+	}
+	return 0
+}
+
+func (x *ValidationReport) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+// 期間
+type DateRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateRange) Reset() {
+	*x = DateRange{}
+	mi := &file_dtako_rows_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateRange) ProtoMessage() {}
+
+func (x *DateRange) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateRange.ProtoReflect.Descriptor instead.
+func (*DateRange) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DateRange) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *DateRange) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+// 期間比較リクエスト
+type CompareVehiclePeriodsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       *DateRange             `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`          // 今期（必須）
+	Baseline      *DateRange             `protobuf:"bytes,2,opt,name=baseline,proto3" json:"baseline,omitempty"`        // 基準期間（省略時は今期の前年同期）
+	CarCc         string                 `protobuf:"bytes,3,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"` // 車輌CC（省略時は全車両）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareVehiclePeriodsRequest) Reset() {
+	*x = CompareVehiclePeriodsRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareVehiclePeriodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareVehiclePeriodsRequest) ProtoMessage() {}
+
+func (x *CompareVehiclePeriodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareVehiclePeriodsRequest.ProtoReflect.Descriptor instead.
+func (*CompareVehiclePeriodsRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CompareVehiclePeriodsRequest) GetCurrent() *DateRange {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *CompareVehiclePeriodsRequest) GetBaseline() *DateRange {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *CompareVehiclePeriodsRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+// 1期間分の合計
+type PeriodTotals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalDistance float64                `protobuf:"fixed64,1,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"` // 総走行距離 (km)
+	TotalFuel     float64                `protobuf:"fixed64,2,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`             // 総給油量 (L)
+	TripCount     int32                  `protobuf:"varint,3,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`              // 運行回数
+	Present       bool                   `protobuf:"varint,4,opt,name=present,proto3" json:"present,omitempty"`                                   // 期間内に運行（または給油）があるか
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodTotals) Reset() {
+	*x = PeriodTotals{}
+	mi := &file_dtako_rows_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodTotals) ProtoMessage() {}
+
+func (x *PeriodTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodTotals.ProtoReflect.Descriptor instead.
+func (*PeriodTotals) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PeriodTotals) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *PeriodTotals) GetTotalFuel() float64 {
+	if x != nil {
+		return x.TotalFuel
+	}
+	return 0
+}
+
+func (x *PeriodTotals) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *PeriodTotals) GetPresent() bool {
+	if x != nil {
+		return x.Present
+	}
+	return false
+}
+
+// 差分（今期 - 基準期間）
+type PeriodDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Absolute      float64                `protobuf:"fixed64,1,opt,name=absolute,proto3" json:"absolute,omitempty"`     // 差分
+	Percent       *float64               `protobuf:"fixed64,2,opt,name=percent,proto3,oneof" json:"percent,omitempty"` // 増減率 (%)（基準期間が0の場合は省略）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodDelta) Reset() {
+	*x = PeriodDelta{}
+	mi := &file_dtako_rows_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodDelta) ProtoMessage() {}
+
+func (x *PeriodDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodDelta.ProtoReflect.Descriptor instead.
+func (*PeriodDelta) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PeriodDelta) GetAbsolute() float64 {
+	if x != nil {
+		return x.Absolute
+	}
+	return 0
+}
+
+func (x *PeriodDelta) GetPercent() float64 {
+	if x != nil && x.Percent != nil {
+		return *x.Percent
+	}
+	return 0
+}
+
+// 車両ごとの期間比較
+type VehiclePeriodComparison struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"` // 車輌CC（全体合計の場合は空）
+	Presence      string                 `protobuf:"bytes,2,opt,name=presence,proto3" json:"presence,omitempty"`        // both / current_only / baseline_only
+	Current       *PeriodTotals          `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"`
+	Baseline      *PeriodTotals          `protobuf:"bytes,4,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Distance      *PeriodDelta           `protobuf:"bytes,5,opt,name=distance,proto3" json:"distance,omitempty"`
+	Fuel          *PeriodDelta           `protobuf:"bytes,6,opt,name=fuel,proto3" json:"fuel,omitempty"`
+	Trips         *PeriodDelta           `protobuf:"bytes,7,opt,name=trips,proto3" json:"trips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehiclePeriodComparison) Reset() {
+	*x = VehiclePeriodComparison{}
+	mi := &file_dtako_rows_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehiclePeriodComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehiclePeriodComparison) ProtoMessage() {}
+
+func (x *VehiclePeriodComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehiclePeriodComparison.ProtoReflect.Descriptor instead.
+func (*VehiclePeriodComparison) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *VehiclePeriodComparison) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *VehiclePeriodComparison) GetPresence() string {
+	if x != nil {
+		return x.Presence
+	}
+	return ""
+}
+
+func (x *VehiclePeriodComparison) GetCurrent() *PeriodTotals {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *VehiclePeriodComparison) GetBaseline() *PeriodTotals {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *VehiclePeriodComparison) GetDistance() *PeriodDelta {
+	if x != nil {
+		return x.Distance
+	}
+	return nil
+}
+
+func (x *VehiclePeriodComparison) GetFuel() *PeriodDelta {
+	if x != nil {
+		return x.Fuel
+	}
+	return nil
+}
+
+func (x *VehiclePeriodComparison) GetTrips() *PeriodDelta {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+// 期間比較レスポンス
+type CompareVehiclePeriodsResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Vehicles      []*VehiclePeriodComparison `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"` // 車輌CC順
+	Fleet         *VehiclePeriodComparison   `protobuf:"bytes,2,opt,name=fleet,proto3" json:"fleet,omitempty"`       // 全体合計
+	Current       *DateRange                 `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"`
+	Baseline      *DateRange                 `protobuf:"bytes,4,opt,name=baseline,proto3" json:"baseline,omitempty"` // 適用した基準期間
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareVehiclePeriodsResponse) Reset() {
+	*x = CompareVehiclePeriodsResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareVehiclePeriodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareVehiclePeriodsResponse) ProtoMessage() {}
+
+func (x *CompareVehiclePeriodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareVehiclePeriodsResponse.ProtoReflect.Descriptor instead.
+func (*CompareVehiclePeriodsResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CompareVehiclePeriodsResponse) GetVehicles() []*VehiclePeriodComparison {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *CompareVehiclePeriodsResponse) GetFleet() *VehiclePeriodComparison {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+func (x *CompareVehiclePeriodsResponse) GetCurrent() *DateRange {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *CompareVehiclePeriodsResponse) GetBaseline() *DateRange {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+// 事業所別サマリー取得リクエスト
+type GetOfficeMonthlySummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`           // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                 // 終了日 (YYYY-MM-DD)
+	OfficeCode    *int32                 `protobuf:"varint,3,opt,name=office_code,json=officeCode,proto3,oneof" json:"office_code,omitempty"` // 所属事業所C（省略時は全事業所）
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                            // 集計期間の区切り方（省略時は月次）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOfficeMonthlySummaryRequest) Reset() {
+	*x = GetOfficeMonthlySummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOfficeMonthlySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOfficeMonthlySummaryRequest) ProtoMessage() {}
+
+func (x *GetOfficeMonthlySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOfficeMonthlySummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetOfficeMonthlySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetOfficeMonthlySummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetOfficeMonthlySummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetOfficeMonthlySummaryRequest) GetOfficeCode() int32 {
+	if x != nil && x.OfficeCode != nil {
+		return *x.OfficeCode
+	}
+	return 0
+}
+
+func (x *GetOfficeMonthlySummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 事業所の期間サマリー
+type OfficePeriodSummary struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	OfficeCode            string                 `protobuf:"bytes,1,opt,name=office_code,json=officeCode,proto3" json:"office_code,omitempty"`                                        // 所属事業所C（車両マスタにない車両は "unassigned"）
+	Period                string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`                                                                  // 集計期間のキー（期間全体の合計の場合は空）
+	TotalDistance         float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`                             // 総走行距離 (km)
+	TotalFuel             float64                `protobuf:"fixed64,4,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`                                         // 総給油量 (L)
+	MeasuredFuel          float64                `protobuf:"fixed64,5,opt,name=measured_fuel,json=measuredFuel,proto3" json:"measured_fuel,omitempty"`                                // 実給油量の合計 (L)
+	EstimatedFuel         float64                `protobuf:"fixed64,6,opt,name=estimated_fuel,json=estimatedFuel,proto3" json:"estimated_fuel,omitempty"`                             // 推定給油量の合計 (L)
+	TripCount             int32                  `protobuf:"varint,7,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                                          // 運行回数
+	ActiveVehicles        int32                  `protobuf:"varint,8,opt,name=active_vehicles,json=activeVehicles,proto3" json:"active_vehicles,omitempty"`                           // 運行のあった車両数
+	AvgDistancePerVehicle float64                `protobuf:"fixed64,9,opt,name=avg_distance_per_vehicle,json=avgDistancePerVehicle,proto3" json:"avg_distance_per_vehicle,omitempty"` // 1台あたりの走行距離 (km)
+	AvgFuelPerVehicle     float64                `protobuf:"fixed64,10,opt,name=avg_fuel_per_vehicle,json=avgFuelPerVehicle,proto3" json:"avg_fuel_per_vehicle,omitempty"`            // 1台あたりの給油量 (L)
+	AvgTripsPerVehicle    float64                `protobuf:"fixed64,11,opt,name=avg_trips_per_vehicle,json=avgTripsPerVehicle,proto3" json:"avg_trips_per_vehicle,omitempty"`         // 1台あたりの運行回数
+	AvgFuelEfficiency     float64                `protobuf:"fixed64,12,opt,name=avg_fuel_efficiency,json=avgFuelEfficiency,proto3" json:"avg_fuel_efficiency,omitempty"`              // 平均燃費 (km/L)
+	Bucket                *PeriodBucket          `protobuf:"bytes,13,opt,name=bucket,proto3" json:"bucket,omitempty"`                                                                 // 集計期間（期間全体の合計の場合は省略）
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *OfficePeriodSummary) Reset() {
+	*x = OfficePeriodSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfficePeriodSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfficePeriodSummary) ProtoMessage() {}
+
+func (x *OfficePeriodSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfficePeriodSummary.ProtoReflect.Descriptor instead.
+func (*OfficePeriodSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *OfficePeriodSummary) GetOfficeCode() string {
+	if x != nil {
+		return x.OfficeCode
+	}
+	return ""
+}
+
+func (x *OfficePeriodSummary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *OfficePeriodSummary) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetTotalFuel() float64 {
+	if x != nil {
+		return x.TotalFuel
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetMeasuredFuel() float64 {
+	if x != nil {
+		return x.MeasuredFuel
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetEstimatedFuel() float64 {
+	if x != nil {
+		return x.EstimatedFuel
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetActiveVehicles() int32 {
+	if x != nil {
+		return x.ActiveVehicles
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetAvgDistancePerVehicle() float64 {
+	if x != nil {
+		return x.AvgDistancePerVehicle
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetAvgFuelPerVehicle() float64 {
+	if x != nil {
+		return x.AvgFuelPerVehicle
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetAvgTripsPerVehicle() float64 {
+	if x != nil {
+		return x.AvgTripsPerVehicle
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetAvgFuelEfficiency() float64 {
+	if x != nil {
+		return x.AvgFuelEfficiency
+	}
+	return 0
+}
+
+func (x *OfficePeriodSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// 事業所別データ
+type OfficeSummaries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfficeCode    string                 `protobuf:"bytes,1,opt,name=office_code,json=officeCode,proto3" json:"office_code,omitempty"`
+	Summaries     []*OfficePeriodSummary `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"`         // 期間順
+	Total         *OfficePeriodSummary   `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`                 // 期間全体の合計
+	CarCcs        []string               `protobuf:"bytes,4,rep,name=car_ccs,json=carCcs,proto3" json:"car_ccs,omitempty"` // 集計対象の車輌CC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfficeSummaries) Reset() {
+	*x = OfficeSummaries{}
+	mi := &file_dtako_rows_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfficeSummaries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfficeSummaries) ProtoMessage() {}
+
+func (x *OfficeSummaries) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfficeSummaries.ProtoReflect.Descriptor instead.
+func (*OfficeSummaries) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *OfficeSummaries) GetOfficeCode() string {
+	if x != nil {
+		return x.OfficeCode
+	}
+	return ""
+}
+
+func (x *OfficeSummaries) GetSummaries() []*OfficePeriodSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *OfficeSummaries) GetTotal() *OfficePeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *OfficeSummaries) GetCarCcs() []string {
+	if x != nil {
+		return x.CarCcs
+	}
+	return nil
+}
+
+// 事業所別サマリーレスポンス
+type OfficeMonthlySummaryResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Offices            []*OfficeSummaries     `protobuf:"bytes,1,rep,name=offices,proto3" json:"offices,omitempty"` // 所属事業所C順（"unassigned" は末尾）
+	TotalOffices       int32                  `protobuf:"varint,2,opt,name=total_offices,json=totalOffices,proto3" json:"total_offices,omitempty"`
+	Period             string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	CarMasterAvailable bool                   `protobuf:"varint,4,opt,name=car_master_available,json=carMasterAvailable,proto3" json:"car_master_available,omitempty"` // 車両マスタを参照できたか（false の場合はすべて "unassigned"）
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *OfficeMonthlySummaryResponse) Reset() {
+	*x = OfficeMonthlySummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfficeMonthlySummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfficeMonthlySummaryResponse) ProtoMessage() {}
+
+func (x *OfficeMonthlySummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfficeMonthlySummaryResponse.ProtoReflect.Descriptor instead.
+func (*OfficeMonthlySummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *OfficeMonthlySummaryResponse) GetOffices() []*OfficeSummaries {
+	if x != nil {
+		return x.Offices
+	}
+	return nil
+}
+
+func (x *OfficeMonthlySummaryResponse) GetTotalOffices() int32 {
+	if x != nil {
+		return x.TotalOffices
+	}
+	return 0
+}
+
+func (x *OfficeMonthlySummaryResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *OfficeMonthlySummaryResponse) GetCarMasterAvailable() bool {
+	if x != nil {
+		return x.CarMasterAvailable
+	}
+	return false
+}
+
+// 通行料金集計リクエスト
+type GetTollSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`             // 車輌CC（省略時は全車両）
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                  // 集計期間の区切り方（省略時は月次）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTollSummaryRequest) Reset() {
+	*x = GetTollSummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTollSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTollSummaryRequest) ProtoMessage() {}
+
+func (x *GetTollSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTollSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetTollSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetTollSummaryRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *GetTollSummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetTollSummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetTollSummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 運行ごとの通行料金
+type TripToll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RowId          string                 `protobuf:"bytes,1,opt,name=row_id,json=rowId,proto3" json:"row_id,omitempty"`                   // 運行データID
+	OperationNo    string                 `protobuf:"bytes,2,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"` // 運行NO
+	CarCc          string                 `protobuf:"bytes,3,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	OperationDate  string                 `protobuf:"bytes,4,opt,name=operation_date,json=operationDate,proto3" json:"operation_date,omitempty"`     // 運行日 (YYYY-MM-DD)
+	TotalDistance  float64                `protobuf:"fixed64,5,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`   // 走行距離 (km)
+	TollAmount     int64                  `protobuf:"varint,6,opt,name=toll_amount,json=tollAmount,proto3" json:"toll_amount,omitempty"`             // 通行料金の合計（円、割引後）
+	TollCount      int32                  `protobuf:"varint,7,opt,name=toll_count,json=tollCount,proto3" json:"toll_count,omitempty"`                // ETC明細の件数
+	EstimatedCount int32                  `protobuf:"varint,8,opt,name=estimated_count,json=estimatedCount,proto3" json:"estimated_count,omitempty"` // うち利用時刻から推定して対応付けた件数
+	Bucket         *PeriodBucket          `protobuf:"bytes,9,opt,name=bucket,proto3" json:"bucket,omitempty"`                                        // 運行日の集計期間
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TripToll) Reset() {
+	*x = TripToll{}
+	mi := &file_dtako_rows_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripToll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripToll) ProtoMessage() {}
+
+func (x *TripToll) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripToll.ProtoReflect.Descriptor instead.
+func (*TripToll) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TripToll) GetRowId() string {
+	if x != nil {
+		return x.RowId
+	}
+	return ""
+}
+
+func (x *TripToll) GetOperationNo() string {
+	if x != nil {
+		return x.OperationNo
+	}
+	return ""
+}
+
+func (x *TripToll) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *TripToll) GetOperationDate() string {
+	if x != nil {
+		return x.OperationDate
+	}
+	return ""
+}
+
+func (x *TripToll) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *TripToll) GetTollAmount() int64 {
+	if x != nil {
+		return x.TollAmount
+	}
+	return 0
+}
+
+func (x *TripToll) GetTollCount() int32 {
+	if x != nil {
+		return x.TollCount
+	}
+	return 0
+}
+
+func (x *TripToll) GetEstimatedCount() int32 {
+	if x != nil {
+		return x.EstimatedCount
+	}
+	return 0
+}
+
+func (x *TripToll) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// 期間ごとの通行料金
+type TollPeriodSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CarCc           string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                  // 車輌CC（全車両の合計の場合は空）
+	Period          string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`                                             // 集計期間のキー（期間全体の合計の場合は空）
+	TotalDistance   float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`        // 総走行距離 (km、通行料金のない運行を含む)
+	TollAmount      int64                  `protobuf:"varint,4,opt,name=toll_amount,json=tollAmount,proto3" json:"toll_amount,omitempty"`                  // 通行料金の合計（円）
+	TollCount       int32                  `protobuf:"varint,5,opt,name=toll_count,json=tollCount,proto3" json:"toll_count,omitempty"`                     // ETC明細の件数
+	TripCount       int32                  `protobuf:"varint,6,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                     // 運行回数
+	TolledTripCount int32                  `protobuf:"varint,7,opt,name=tolled_trip_count,json=tolledTripCount,proto3" json:"tolled_trip_count,omitempty"` // 通行料金のある運行の回数
+	TollPerKm       float64                `protobuf:"fixed64,8,opt,name=toll_per_km,json=tollPerKm,proto3" json:"toll_per_km,omitempty"`                  // 走行距離1kmあたりの通行料金（円）
+	Bucket          *PeriodBucket          `protobuf:"bytes,9,opt,name=bucket,proto3" json:"bucket,omitempty"`                                             // 集計期間（期間全体の合計の場合は省略）
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TollPeriodSummary) Reset() {
+	*x = TollPeriodSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TollPeriodSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TollPeriodSummary) ProtoMessage() {}
+
+func (x *TollPeriodSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TollPeriodSummary.ProtoReflect.Descriptor instead.
+func (*TollPeriodSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TollPeriodSummary) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *TollPeriodSummary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *TollPeriodSummary) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *TollPeriodSummary) GetTollAmount() int64 {
+	if x != nil {
+		return x.TollAmount
+	}
+	return 0
+}
+
+func (x *TollPeriodSummary) GetTollCount() int32 {
+	if x != nil {
+		return x.TollCount
+	}
+	return 0
+}
+
+func (x *TollPeriodSummary) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *TollPeriodSummary) GetTolledTripCount() int32 {
+	if x != nil {
+		return x.TolledTripCount
+	}
+	return 0
+}
+
+func (x *TollPeriodSummary) GetTollPerKm() float64 {
+	if x != nil {
+		return x.TollPerKm
+	}
+	return 0
+}
+
+func (x *TollPeriodSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// 車両別の通行料金
+type VehicleTolls struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Summaries     []*TollPeriodSummary   `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"` // 期間順
+	Total         *TollPeriodSummary     `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`         // 期間全体の合計
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleTolls) Reset() {
+	*x = VehicleTolls{}
+	mi := &file_dtako_rows_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleTolls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleTolls) ProtoMessage() {}
+
+func (x *VehicleTolls) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleTolls.ProtoReflect.Descriptor instead.
+func (*VehicleTolls) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *VehicleTolls) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *VehicleTolls) GetSummaries() []*TollPeriodSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *VehicleTolls) GetTotal() *TollPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+// 運行に対応付けられなかったETC明細
+type UnmatchedETCRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`                                 // ETC明細のハッシュ
+	UsedAt        string                 `protobuf:"bytes,2,opt,name=used_at,json=usedAt,proto3" json:"used_at,omitempty"`               // 利用日時（出口、RFC3339形式。パースできない場合は元の値）
+	EntryIc       string                 `protobuf:"bytes,3,opt,name=entry_ic,json=entryIc,proto3" json:"entry_ic,omitempty"`            // 入口IC
+	ExitIc        string                 `protobuf:"bytes,4,opt,name=exit_ic,json=exitIc,proto3" json:"exit_ic,omitempty"`               // 出口IC
+	Price         int32                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`                              // 料金（円、割引後）
+	EtcCardNum    string                 `protobuf:"bytes,6,opt,name=etc_card_num,json=etcCardNum,proto3" json:"etc_card_num,omitempty"` // ETCカード番号
+	CarCc         string                 `protobuf:"bytes,7,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                  // ETCカードから推定した車輌CC（不明な場合は空）
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`                             // invalid_date / unknown_card / no_trip
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmatchedETCRecord) Reset() {
+	*x = UnmatchedETCRecord{}
+	mi := &file_dtako_rows_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchedETCRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchedETCRecord) ProtoMessage() {}
+
+func (x *UnmatchedETCRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchedETCRecord.ProtoReflect.Descriptor instead.
+func (*UnmatchedETCRecord) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UnmatchedETCRecord) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *UnmatchedETCRecord) GetUsedAt() string {
+	if x != nil {
+		return x.UsedAt
+	}
+	return ""
+}
+
+func (x *UnmatchedETCRecord) GetEntryIc() string {
+	if x != nil {
+		return x.EntryIc
+	}
+	return ""
+}
+
+func (x *UnmatchedETCRecord) GetExitIc() string {
+	if x != nil {
+		return x.ExitIc
+	}
+	return ""
+}
+
+func (x *UnmatchedETCRecord) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UnmatchedETCRecord) GetEtcCardNum() string {
+	if x != nil {
+		return x.EtcCardNum
+	}
+	return ""
+}
+
+func (x *UnmatchedETCRecord) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *UnmatchedETCRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 通行料金集計レスポンス
+type TollSummaryResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Trips           []*TripToll            `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`                                             // 通行料金のある運行（運行日順）
+	Vehicles        []*VehicleTolls        `protobuf:"bytes,2,rep,name=vehicles,proto3" json:"vehicles,omitempty"`                                       // 車輌CC順
+	Periods         []*TollPeriodSummary   `protobuf:"bytes,3,rep,name=periods,proto3" json:"periods,omitempty"`                                         // 全車両の期間ごとの合計（期間順）
+	Total           *TollPeriodSummary     `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`                                             // 全車両の期間全体の合計
+	Unmatched       []*UnmatchedETCRecord  `protobuf:"bytes,5,rep,name=unmatched,proto3" json:"unmatched,omitempty"`                                     // 利用日時順
+	UnmatchedAmount int64                  `protobuf:"varint,6,opt,name=unmatched_amount,json=unmatchedAmount,proto3" json:"unmatched_amount,omitempty"` // 対応付けられなかった料金の合計（円）
+	Period          string                 `protobuf:"bytes,7,opt,name=period,proto3" json:"period,omitempty"`
+	EtcAvailable    bool                   `protobuf:"varint,8,opt,name=etc_available,json=etcAvailable,proto3" json:"etc_available,omitempty"` // ETC明細を参照できたか
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TollSummaryResponse) Reset() {
+	*x = TollSummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TollSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TollSummaryResponse) ProtoMessage() {}
+
+func (x *TollSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TollSummaryResponse.ProtoReflect.Descriptor instead.
+func (*TollSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TollSummaryResponse) GetTrips() []*TripToll {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+func (x *TollSummaryResponse) GetVehicles() []*VehicleTolls {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *TollSummaryResponse) GetPeriods() []*TollPeriodSummary {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *TollSummaryResponse) GetTotal() *TollPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *TollSummaryResponse) GetUnmatched() []*UnmatchedETCRecord {
+	if x != nil {
+		return x.Unmatched
+	}
+	return nil
+}
+
+func (x *TollSummaryResponse) GetUnmatchedAmount() int64 {
+	if x != nil {
+		return x.UnmatchedAmount
+	}
+	return 0
+}
+
+func (x *TollSummaryResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *TollSummaryResponse) GetEtcAvailable() bool {
+	if x != nil {
+		return x.EtcAvailable
+	}
+	return false
+}
+
+// フェリー利用集計リクエスト
+type GetFerrySummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`             // 車輌CC（省略時は全車両）
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                  // 集計期間の区切り方（省略時は月次）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFerrySummaryRequest) Reset() {
+	*x = GetFerrySummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFerrySummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFerrySummaryRequest) ProtoMessage() {}
+
+func (x *GetFerrySummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFerrySummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetFerrySummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetFerrySummaryRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *GetFerrySummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetFerrySummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetFerrySummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 期間ごとのフェリー利用
+type FerryPeriodSummary struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CarCc             string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                         // 車輌CC（全車両の合計の場合は空）
+	Period            string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`                                                    // 集計期間のキー（期間全体の合計の場合は空）
+	Crossings         int32                  `protobuf:"varint,3,opt,name=crossings,proto3" json:"crossings,omitempty"`                                             // 乗船回数
+	FerryCost         int64                  `protobuf:"varint,4,opt,name=ferry_cost,json=ferryCost,proto3" json:"ferry_cost,omitempty"`                            // フェリー料金（円、契約料金。未設定の乗船は標準料金）
+	StandardFare      int64                  `protobuf:"varint,5,opt,name=standard_fare,json=standardFare,proto3" json:"standard_fare,omitempty"`                   // 標準料金の合計（円）
+	DeemedDistance    float64                `protobuf:"fixed64,6,opt,name=deemed_distance,json=deemedDistance,proto3" json:"deemed_distance,omitempty"`            // 見なし距離 (km)
+	TotalDistance     float64                `protobuf:"fixed64,7,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`               // 走行距離 (km、フェリー利用のない運行を含む)
+	DistanceWithFerry float64                `protobuf:"fixed64,8,opt,name=distance_with_ferry,json=distanceWithFerry,proto3" json:"distance_with_ferry,omitempty"` // 見なし距離を含む距離 (km)
+	TripCount         int32                  `protobuf:"varint,9,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`                            // 運行回数
+	FerryTripCount    int32                  `protobuf:"varint,10,opt,name=ferry_trip_count,json=ferryTripCount,proto3" json:"ferry_trip_count,omitempty"`          // フェリーを利用した運行の回数
+	Bucket            *PeriodBucket          `protobuf:"bytes,11,opt,name=bucket,proto3" json:"bucket,omitempty"`                                                   // 集計期間（期間全体の合計の場合は省略）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FerryPeriodSummary) Reset() {
+	*x = FerryPeriodSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FerryPeriodSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FerryPeriodSummary) ProtoMessage() {}
+
+func (x *FerryPeriodSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FerryPeriodSummary.ProtoReflect.Descriptor instead.
+func (*FerryPeriodSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *FerryPeriodSummary) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *FerryPeriodSummary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *FerryPeriodSummary) GetCrossings() int32 {
+	if x != nil {
+		return x.Crossings
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetFerryCost() int64 {
+	if x != nil {
+		return x.FerryCost
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetStandardFare() int64 {
+	if x != nil {
+		return x.StandardFare
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetDeemedDistance() float64 {
+	if x != nil {
+		return x.DeemedDistance
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetDistanceWithFerry() float64 {
+	if x != nil {
+		return x.DistanceWithFerry
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetFerryTripCount() int32 {
+	if x != nil {
+		return x.FerryTripCount
+	}
+	return 0
+}
+
+func (x *FerryPeriodSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// 車両別のフェリー利用
+type VehicleFerries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Summaries     []*FerryPeriodSummary  `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"` // 期間順
+	Total         *FerryPeriodSummary    `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`         // 期間全体の合計
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleFerries) Reset() {
+	*x = VehicleFerries{}
+	mi := &file_dtako_rows_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleFerries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleFerries) ProtoMessage() {}
+
+func (x *VehicleFerries) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleFerries.ProtoReflect.Descriptor instead.
+func (*VehicleFerries) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *VehicleFerries) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *VehicleFerries) GetSummaries() []*FerryPeriodSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *VehicleFerries) GetTotal() *FerryPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+// フェリー利用集計レスポンス
+type FerrySummaryResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles       []*VehicleFerries      `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"` // 車輌CC順
+	Periods        []*FerryPeriodSummary  `protobuf:"bytes,2,rep,name=periods,proto3" json:"periods,omitempty"`   // 全車両の期間ごとの合計（期間順）
+	Total          *FerryPeriodSummary    `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`       // 全車両の期間全体の合計
+	Period         string                 `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	FerryAvailable bool                   `protobuf:"varint,5,opt,name=ferry_available,json=ferryAvailable,proto3" json:"ferry_available,omitempty"` // フェリー運行データを参照できたか
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FerrySummaryResponse) Reset() {
+	*x = FerrySummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FerrySummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FerrySummaryResponse) ProtoMessage() {}
+
+func (x *FerrySummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FerrySummaryResponse.ProtoReflect.Descriptor instead.
+func (*FerrySummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *FerrySummaryResponse) GetVehicles() []*VehicleFerries {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *FerrySummaryResponse) GetPeriods() []*FerryPeriodSummary {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *FerrySummaryResponse) GetTotal() *FerryPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *FerrySummaryResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *FerrySummaryResponse) GetFerryAvailable() bool {
+	if x != nil {
+		return x.FerryAvailable
+	}
+	return false
+}
+
+// 採算集計リクエスト
+type GetTripProfitabilityRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CarCc             string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`                                                 // 車輌CC（省略時は全車両）
+	StartDate         string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`                                     // 開始日 (YYYY-MM-DD)
+	EndDate           string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`                                           // 終了日 (YYYY-MM-DD)
+	Bucketing         *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                                                      // 集計期間の区切り方（省略時は月次）
+	FuelPricePerLiter *float64               `protobuf:"fixed64,5,opt,name=fuel_price_per_liter,json=fuelPricePerLiter,proto3,oneof" json:"fuel_price_per_liter,omitempty"` // 燃料単価（円/L、省略時は環境変数 FUEL_PRICE_PER_LITER）
+	RevenueKeihiCodes []int32                `protobuf:"varint,6,rep,packed,name=revenue_keihi_codes,json=revenueKeihiCodes,proto3" json:"revenue_keihi_codes,omitempty"`   // 売上とする経費C（省略時は環境変数 REVENUE_KEIHI_CODES）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetTripProfitabilityRequest) Reset() {
+	*x = GetTripProfitabilityRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripProfitabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripProfitabilityRequest) ProtoMessage() {}
+
+func (x *GetTripProfitabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripProfitabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetTripProfitabilityRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetTripProfitabilityRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *GetTripProfitabilityRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetTripProfitabilityRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetTripProfitabilityRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+func (x *GetTripProfitabilityRequest) GetFuelPricePerLiter() float64 {
+	if x != nil && x.FuelPricePerLiter != nil {
+		return *x.FuelPricePerLiter
+	}
+	return 0
+}
+
+func (x *GetTripProfitabilityRequest) GetRevenueKeihiCodes() []int32 {
+	if x != nil {
+		return x.RevenueKeihiCodes
+	}
+	return nil
+}
+
+// 売上・費用の合計
+type ProfitTotals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalDistance float64                `protobuf:"fixed64,1,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"` // 走行距離 (km)
+	TripCount     int32                  `protobuf:"varint,2,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`              // 運行回数
+	Revenue       float64                `protobuf:"fixed64,3,opt,name=revenue,proto3" json:"revenue,omitempty"`                                  // 売上（円）
+	Expenses      float64                `protobuf:"fixed64,4,opt,name=expenses,proto3" json:"expenses,omitempty"`                                // 経費（円、売上以外の経費C）
+	FuelLiters    float64                `protobuf:"fixed64,5,opt,name=fuel_liters,json=fuelLiters,proto3" json:"fuel_liters,omitempty"`          // 推定燃料使用量 (L)
+	FuelCost      float64                `protobuf:"fixed64,6,opt,name=fuel_cost,json=fuelCost,proto3" json:"fuel_cost,omitempty"`                // 推定燃料費（円）
+	TollCost      float64                `protobuf:"fixed64,7,opt,name=toll_cost,json=tollCost,proto3" json:"toll_cost,omitempty"`                // 通行料金（円）
+	FerryCost     float64                `protobuf:"fixed64,8,opt,name=ferry_cost,json=ferryCost,proto3" json:"ferry_cost,omitempty"`             // フェリー料金（円）
+	TotalCost     float64                `protobuf:"fixed64,9,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"`             // 費用の合計（円）
+	GrossMargin   float64                `protobuf:"fixed64,10,opt,name=gross_margin,json=grossMargin,proto3" json:"gross_margin,omitempty"`      // 粗利（円、売上 - 費用の合計）
+	MarginRatio   float64                `protobuf:"fixed64,11,opt,name=margin_ratio,json=marginRatio,proto3" json:"margin_ratio,omitempty"`      // 粗利率（売上が0の場合は0）
+	RevenuePerKm  float64                `protobuf:"fixed64,12,opt,name=revenue_per_km,json=revenuePerKm,proto3" json:"revenue_per_km,omitempty"` // 走行距離1kmあたりの売上（円/km）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfitTotals) Reset() {
+	*x = ProfitTotals{}
+	mi := &file_dtako_rows_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfitTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfitTotals) ProtoMessage() {}
+
+func (x *ProfitTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfitTotals.ProtoReflect.Descriptor instead.
+func (*ProfitTotals) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ProfitTotals) GetTotalDistance() float64 {
+	if x != nil {
+		return x.TotalDistance
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetExpenses() float64 {
+	if x != nil {
+		return x.Expenses
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetFuelLiters() float64 {
+	if x != nil {
+		return x.FuelLiters
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetFuelCost() float64 {
+	if x != nil {
+		return x.FuelCost
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetTollCost() float64 {
+	if x != nil {
+		return x.TollCost
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetFerryCost() float64 {
+	if x != nil {
+		return x.FerryCost
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetTotalCost() float64 {
+	if x != nil {
+		return x.TotalCost
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetGrossMargin() float64 {
+	if x != nil {
+		return x.GrossMargin
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetMarginRatio() float64 {
+	if x != nil {
+		return x.MarginRatio
+	}
+	return 0
+}
+
+func (x *ProfitTotals) GetRevenuePerKm() float64 {
+	if x != nil {
+		return x.RevenuePerKm
+	}
+	return 0
+}
+
+// 経費Cごとの金額
+type KeihiAmount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeihiC        int32                  `protobuf:"varint,1,opt,name=keihi_c,json=keihiC,proto3" json:"keihi_c,omitempty"` // 経費C
+	Revenue       bool                   `protobuf:"varint,2,opt,name=revenue,proto3" json:"revenue,omitempty"`             // 売上として計上した
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`              // 金額の合計（円）
+	Km            float64                `protobuf:"fixed64,4,opt,name=km,proto3" json:"km,omitempty"`                      // 距離の合計 (km、売上経費データに記録された距離)
+	Count         int32                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`                 // 件数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeihiAmount) Reset() {
+	*x = KeihiAmount{}
+	mi := &file_dtako_rows_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeihiAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeihiAmount) ProtoMessage() {}
+
+func (x *KeihiAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeihiAmount.ProtoReflect.Descriptor instead.
+func (*KeihiAmount) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *KeihiAmount) GetKeihiC() int32 {
+	if x != nil {
+		return x.KeihiC
+	}
+	return 0
+}
+
+func (x *KeihiAmount) GetRevenue() bool {
+	if x != nil {
+		return x.Revenue
+	}
+	return false
+}
+
+func (x *KeihiAmount) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *KeihiAmount) GetKm() float64 {
+	if x != nil {
+		return x.Km
+	}
+	return 0
+}
+
+func (x *KeihiAmount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 運行ごとの採算
+type TripProfit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RowId         string                 `protobuf:"bytes,1,opt,name=row_id,json=rowId,proto3" json:"row_id,omitempty"`                   // 運行データID
+	OperationNo   string                 `protobuf:"bytes,2,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"` // 運行NO
+	CarCc         string                 `protobuf:"bytes,3,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	OperationDate string                 `protobuf:"bytes,4,opt,name=operation_date,json=operationDate,proto3" json:"operation_date,omitempty"` // 運行日 (YYYY-MM-DD)
+	Bucket        *PeriodBucket          `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`                                    // 運行日の集計期間
+	Totals        *ProfitTotals          `protobuf:"bytes,6,opt,name=totals,proto3" json:"totals,omitempty"`
+	Lines         []*KeihiAmount         `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"` // 経費C順
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripProfit) Reset() {
+	*x = TripProfit{}
+	mi := &file_dtako_rows_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripProfit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripProfit) ProtoMessage() {}
+
+func (x *TripProfit) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripProfit.ProtoReflect.Descriptor instead.
+func (*TripProfit) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TripProfit) GetRowId() string {
+	if x != nil {
+		return x.RowId
+	}
+	return ""
+}
+
+func (x *TripProfit) GetOperationNo() string {
+	if x != nil {
+		return x.OperationNo
+	}
+	return ""
+}
+
+func (x *TripProfit) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *TripProfit) GetOperationDate() string {
+	if x != nil {
+		return x.OperationDate
+	}
+	return ""
+}
+
+func (x *TripProfit) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *TripProfit) GetTotals() *ProfitTotals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *TripProfit) GetLines() []*KeihiAmount {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// 期間ごとの採算
+type ProfitPeriodSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"` // 車輌CC（全車両の合計の場合は空）
+	Period        string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`            // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket        *PeriodBucket          `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`            // 集計期間（期間全体の合計の場合は省略）
+	Totals        *ProfitTotals          `protobuf:"bytes,4,opt,name=totals,proto3" json:"totals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfitPeriodSummary) Reset() {
+	*x = ProfitPeriodSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfitPeriodSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfitPeriodSummary) ProtoMessage() {}
+
+func (x *ProfitPeriodSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfitPeriodSummary.ProtoReflect.Descriptor instead.
+func (*ProfitPeriodSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ProfitPeriodSummary) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *ProfitPeriodSummary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *ProfitPeriodSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *ProfitPeriodSummary) GetTotals() *ProfitTotals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+// 車両別の採算
+type VehicleProfit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Summaries     []*ProfitPeriodSummary `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"` // 期間順
+	Total         *ProfitPeriodSummary   `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`         // 期間全体の合計
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleProfit) Reset() {
+	*x = VehicleProfit{}
+	mi := &file_dtako_rows_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleProfit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleProfit) ProtoMessage() {}
+
+func (x *VehicleProfit) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleProfit.ProtoReflect.Descriptor instead.
+func (*VehicleProfit) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *VehicleProfit) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *VehicleProfit) GetSummaries() []*ProfitPeriodSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+func (x *VehicleProfit) GetTotal() *ProfitPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+// 採算集計レスポンス
+type TripProfitabilityResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Trips              []*TripProfit          `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`       // 運行日・運行NO順
+	Vehicles           []*VehicleProfit       `protobuf:"bytes,2,rep,name=vehicles,proto3" json:"vehicles,omitempty"` // 車輌CC順
+	Periods            []*ProfitPeriodSummary `protobuf:"bytes,3,rep,name=periods,proto3" json:"periods,omitempty"`   // 全車両の期間ごとの合計（期間順）
+	Total              *ProfitPeriodSummary   `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`       // 全車両の期間全体の合計
+	Period             string                 `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	FuelPricePerLiter  float64                `protobuf:"fixed64,6,opt,name=fuel_price_per_liter,json=fuelPricePerLiter,proto3" json:"fuel_price_per_liter,omitempty"`     // 適用した燃料単価（円/L）
+	RevenueKeihiCodes  []int32                `protobuf:"varint,7,rep,packed,name=revenue_keihi_codes,json=revenueKeihiCodes,proto3" json:"revenue_keihi_codes,omitempty"` // 適用した売上とする経費C
+	UnlinkedKeihiCount int32                  `protobuf:"varint,8,opt,name=unlinked_keihi_count,json=unlinkedKeihiCount,proto3" json:"unlinked_keihi_count,omitempty"`     // 運行に対応付けられなかった売上経費データの件数
+	KeihiAvailable     bool                   `protobuf:"varint,9,opt,name=keihi_available,json=keihiAvailable,proto3" json:"keihi_available,omitempty"`                   // 売上経費データを参照できたか
+	EtcAvailable       bool                   `protobuf:"varint,10,opt,name=etc_available,json=etcAvailable,proto3" json:"etc_available,omitempty"`                        // ETC明細を参照できたか
+	FerryAvailable     bool                   `protobuf:"varint,11,opt,name=ferry_available,json=ferryAvailable,proto3" json:"ferry_available,omitempty"`                  // フェリー運行データを参照できたか
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TripProfitabilityResponse) Reset() {
+	*x = TripProfitabilityResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripProfitabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripProfitabilityResponse) ProtoMessage() {}
+
+func (x *TripProfitabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripProfitabilityResponse.ProtoReflect.Descriptor instead.
+func (*TripProfitabilityResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *TripProfitabilityResponse) GetTrips() []*TripProfit {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+func (x *TripProfitabilityResponse) GetVehicles() []*VehicleProfit {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *TripProfitabilityResponse) GetPeriods() []*ProfitPeriodSummary {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *TripProfitabilityResponse) GetTotal() *ProfitPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *TripProfitabilityResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *TripProfitabilityResponse) GetFuelPricePerLiter() float64 {
+	if x != nil {
+		return x.FuelPricePerLiter
+	}
+	return 0
+}
+
+func (x *TripProfitabilityResponse) GetRevenueKeihiCodes() []int32 {
+	if x != nil {
+		return x.RevenueKeihiCodes
+	}
+	return nil
+}
+
+func (x *TripProfitabilityResponse) GetUnlinkedKeihiCount() int32 {
+	if x != nil {
+		return x.UnlinkedKeihiCount
+	}
+	return 0
+}
+
+func (x *TripProfitabilityResponse) GetKeihiAvailable() bool {
+	if x != nil {
+		return x.KeihiAvailable
+	}
+	return false
+}
+
+func (x *TripProfitabilityResponse) GetEtcAvailable() bool {
+	if x != nil {
+		return x.EtcAvailable
+	}
+	return false
+}
+
+func (x *TripProfitabilityResponse) GetFerryAvailable() bool {
+	if x != nil {
+		return x.FerryAvailable
+	}
+	return false
+}
+
+// イベントCDごとの合計
+type EventCodeTotals struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EventCode       int32                  `protobuf:"varint,1,opt,name=event_code,json=eventCode,proto3" json:"event_code,omitempty"`                   // イベントCD（未設定の場合は-1）
+	EventName       string                 `protobuf:"bytes,2,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`                    // イベント名
+	Category        string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`                                       // idling / rest / loading / speeding / other
+	Count           int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`                                            // 回数
+	DurationSeconds int64                  `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // 開始日時〜終了日時の合計（秒）
+	SectionTime     int64                  `protobuf:"varint,6,opt,name=section_time,json=sectionTime,proto3" json:"section_time,omitempty"`             // 区間時間の合計（記録値のまま）
+	Distance        float64                `protobuf:"fixed64,7,opt,name=distance,proto3" json:"distance,omitempty"`                                     // 区間距離の合計 (km)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventCodeTotals) Reset() {
+	*x = EventCodeTotals{}
+	mi := &file_dtako_rows_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventCodeTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventCodeTotals) ProtoMessage() {}
+
+func (x *EventCodeTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventCodeTotals.ProtoReflect.Descriptor instead.
+func (*EventCodeTotals) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *EventCodeTotals) GetEventCode() int32 {
+	if x != nil {
+		return x.EventCode
+	}
+	return 0
+}
+
+func (x *EventCodeTotals) GetEventName() string {
+	if x != nil {
+		return x.EventName
+	}
+	return ""
+}
+
+func (x *EventCodeTotals) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *EventCodeTotals) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *EventCodeTotals) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *EventCodeTotals) GetSectionTime() int64 {
+	if x != nil {
+		return x.SectionTime
+	}
+	return 0
+}
+
+func (x *EventCodeTotals) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+// 分類ごとの合計
+type EventCategoryTotals struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Category        string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`                                       // idling / rest / loading / speeding / other
+	Count           int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                                            // 回数
+	DurationSeconds int64                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // 時間の合計（秒）
+	Distance        float64                `protobuf:"fixed64,4,opt,name=distance,proto3" json:"distance,omitempty"`                                     // 区間距離の合計 (km)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EventCategoryTotals) Reset() {
+	*x = EventCategoryTotals{}
+	mi := &file_dtako_rows_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventCategoryTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventCategoryTotals) ProtoMessage() {}
+
+func (x *EventCategoryTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventCategoryTotals.ProtoReflect.Descriptor instead.
+func (*EventCategoryTotals) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *EventCategoryTotals) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *EventCategoryTotals) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *EventCategoryTotals) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *EventCategoryTotals) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+// イベントCD別の集計
+type EventBreakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripCount     int32                  `protobuf:"varint,1,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`             // 運行回数（イベントのない運行を含む）
+	EventCount    int32                  `protobuf:"varint,2,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"`          // イベントの件数
+	Codes         []*EventCodeTotals     `protobuf:"bytes,3,rep,name=codes,proto3" json:"codes,omitempty"`                                       // イベントCD順
+	Categories    []*EventCategoryTotals `protobuf:"bytes,4,rep,name=categories,proto3" json:"categories,omitempty"`                             // idling, rest, loading, speeding, other の順（イベントのある分類のみ）
+	IdlingSeconds int64                  `protobuf:"varint,5,opt,name=idling_seconds,json=idlingSeconds,proto3" json:"idling_seconds,omitempty"` // アイドリング時間の合計（秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventBreakdown) Reset() {
+	*x = EventBreakdown{}
+	mi := &file_dtako_rows_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventBreakdown) ProtoMessage() {}
+
+func (x *EventBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventBreakdown.ProtoReflect.Descriptor instead.
+func (*EventBreakdown) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *EventBreakdown) GetTripCount() int32 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *EventBreakdown) GetEventCount() int32 {
+	if x != nil {
+		return x.EventCount
+	}
+	return 0
+}
+
+func (x *EventBreakdown) GetCodes() []*EventCodeTotals {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *EventBreakdown) GetCategories() []*EventCategoryTotals {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *EventBreakdown) GetIdlingSeconds() int64 {
+	if x != nil {
+		return x.IdlingSeconds
+	}
+	return 0
+}
+
+// 運行のイベント集計リクエスト
+type GetTripEventSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationNo   string                 `protobuf:"bytes,1,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"` // 運行NO（必須）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripEventSummaryRequest) Reset() {
+	*x = GetTripEventSummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripEventSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripEventSummaryRequest) ProtoMessage() {}
+
+func (x *GetTripEventSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripEventSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetTripEventSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetTripEventSummaryRequest) GetOperationNo() string {
+	if x != nil {
+		return x.OperationNo
+	}
+	return ""
+}
+
+// 運行のイベント集計レスポンス
+type TripEventSummaryResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OperationNo     string                 `protobuf:"bytes,1,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"`
+	CarCc           string                 `protobuf:"bytes,2,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	DriverCodes     []int32                `protobuf:"varint,3,rep,packed,name=driver_codes,json=driverCodes,proto3" json:"driver_codes,omitempty"` // イベントの乗務員CD（昇順）
+	FirstStart      string                 `protobuf:"bytes,4,opt,name=first_start,json=firstStart,proto3" json:"first_start,omitempty"`            // 最初のイベントの開始日時（RFC3339形式、イベントがない場合は空）
+	LastEnd         string                 `protobuf:"bytes,5,opt,name=last_end,json=lastEnd,proto3" json:"last_end,omitempty"`                     // 最後のイベントの終了日時（RFC3339形式、イベントがない場合は空）
+	SpanSeconds     int64                  `protobuf:"varint,6,opt,name=span_seconds,json=spanSeconds,proto3" json:"span_seconds,omitempty"`        // 最初の開始から最後の終了までの時間（秒）
+	IdlingRatio     float64                `protobuf:"fixed64,7,opt,name=idling_ratio,json=idlingRatio,proto3" json:"idling_ratio,omitempty"`       // span_seconds に占めるアイドリング時間の割合
+	Breakdown       *EventBreakdown        `protobuf:"bytes,8,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	EventsAvailable bool                   `protobuf:"varint,9,opt,name=events_available,json=eventsAvailable,proto3" json:"events_available,omitempty"` // イベントデータを参照できたか
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TripEventSummaryResponse) Reset() {
+	*x = TripEventSummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripEventSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripEventSummaryResponse) ProtoMessage() {}
+
+func (x *TripEventSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripEventSummaryResponse.ProtoReflect.Descriptor instead.
+func (*TripEventSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *TripEventSummaryResponse) GetOperationNo() string {
+	if x != nil {
+		return x.OperationNo
+	}
+	return ""
+}
+
+func (x *TripEventSummaryResponse) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *TripEventSummaryResponse) GetDriverCodes() []int32 {
+	if x != nil {
+		return x.DriverCodes
+	}
+	return nil
+}
+
+func (x *TripEventSummaryResponse) GetFirstStart() string {
+	if x != nil {
+		return x.FirstStart
+	}
+	return ""
+}
+
+func (x *TripEventSummaryResponse) GetLastEnd() string {
+	if x != nil {
+		return x.LastEnd
+	}
+	return ""
+}
+
+func (x *TripEventSummaryResponse) GetSpanSeconds() int64 {
+	if x != nil {
+		return x.SpanSeconds
+	}
+	return 0
+}
+
+func (x *TripEventSummaryResponse) GetIdlingRatio() float64 {
+	if x != nil {
+		return x.IdlingRatio
+	}
+	return 0
+}
+
+func (x *TripEventSummaryResponse) GetBreakdown() *EventBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *TripEventSummaryResponse) GetEventsAvailable() bool {
+	if x != nil {
+		return x.EventsAvailable
+	}
+	return false
+}
+
+// イベント期間集計リクエスト
+type GetEventSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`             // 車輌CC（省略時は全車両）
+	StartDate     string                 `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // 開始日 (YYYY-MM-DD)
+	EndDate       string                 `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // 終了日 (YYYY-MM-DD)
+	Bucketing     *Bucketing             `protobuf:"bytes,4,opt,name=bucketing,proto3" json:"bucketing,omitempty"`                  // 集計期間の区切り方（省略時は月次）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventSummaryRequest) Reset() {
+	*x = GetEventSummaryRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventSummaryRequest) ProtoMessage() {}
+
+func (x *GetEventSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetEventSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetEventSummaryRequest) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *GetEventSummaryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetEventSummaryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *GetEventSummaryRequest) GetBucketing() *Bucketing {
+	if x != nil {
+		return x.Bucketing
+	}
+	return nil
+}
+
+// 期間ごとのイベント集計
+type EventPeriodSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"` // 車輌CC（全車両の合計の場合は空）
+	Period        string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`            // 集計期間のキー（期間全体の合計の場合は空）
+	Bucket        *PeriodBucket          `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`            // 集計期間（期間全体の合計の場合は省略）
+	Breakdown     *EventBreakdown        `protobuf:"bytes,4,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventPeriodSummary) Reset() {
+	*x = EventPeriodSummary{}
+	mi := &file_dtako_rows_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventPeriodSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventPeriodSummary) ProtoMessage() {}
+
+func (x *EventPeriodSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventPeriodSummary.ProtoReflect.Descriptor instead.
+func (*EventPeriodSummary) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *EventPeriodSummary) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *EventPeriodSummary) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *EventPeriodSummary) GetBucket() *PeriodBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *EventPeriodSummary) GetBreakdown() *EventBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+// 車両別のイベント集計
+type VehicleEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Summaries     []*EventPeriodSummary  `protobuf:"bytes,2,rep,name=summaries,proto3" json:"summaries,omitempty"` // 期間順
+	Total         *EventPeriodSummary    `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`         // 期間全体の合計
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDailySummaryRequest) Reset() {
-	*x = GetDailySummaryRequest{}
-	mi := &file_dtako_rows_proto_msgTypes[6]
+func (x *VehicleEvents) Reset() {
+	*x = VehicleEvents{}
+	mi := &file_dtako_rows_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDailySummaryRequest) String() string {
+func (x *VehicleEvents) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDailySummaryRequest) ProtoMessage() {}
+func (*VehicleEvents) ProtoMessage() {}
 
-func (x *GetDailySummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[6]
+func (x *VehicleEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,59 +6141,56 @@ func (x *GetDailySummaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDailySummaryRequest.ProtoReflect.Descriptor instead.
-func (*GetDailySummaryRequest) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use VehicleEvents.ProtoReflect.Descriptor instead.
+func (*VehicleEvents) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *GetDailySummaryRequest) GetCarCc() string {
+func (x *VehicleEvents) GetCarCc() string {
 	if x != nil {
 		return x.CarCc
 	}
 	return ""
 }
 
-func (x *GetDailySummaryRequest) GetStartDate() string {
+func (x *VehicleEvents) GetSummaries() []*EventPeriodSummary {
 	if x != nil {
-		return x.StartDate
+		return x.Summaries
 	}
-	return ""
+	return nil
 }
 
-func (x *GetDailySummaryRequest) GetEndDate() string {
+func (x *VehicleEvents) GetTotal() *EventPeriodSummary {
 	if x != nil {
-		return x.EndDate
+		return x.Total
 	}
-	return ""
+	return nil
 }
 
-// 日次サマリー
-type DailySummary struct {
+// 乗務員別のイベント集計
+type DriverEvents struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CarCc         string                 `protobuf:"bytes,1,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
-	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`                                          // 日付 (YYYY-MM-DD)
-	TotalDistance float64                `protobuf:"fixed64,3,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"` // 走行距離
-	TotalFuel     float64                `protobuf:"fixed64,4,opt,name=total_fuel,json=totalFuel,proto3" json:"total_fuel,omitempty"`             // 給油量
-	TripCount     int32                  `protobuf:"varint,5,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`              // 運行回数
+	DriverCode    int32                  `protobuf:"varint,1,opt,name=driver_code,json=driverCode,proto3" json:"driver_code,omitempty"` // 乗務員CD
+	Breakdown     *EventBreakdown        `protobuf:"bytes,2,opt,name=breakdown,proto3" json:"breakdown,omitempty"`                      // 期間全体の合計
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DailySummary) Reset() {
-	*x = DailySummary{}
-	mi := &file_dtako_rows_proto_msgTypes[7]
+func (x *DriverEvents) Reset() {
+	*x = DriverEvents{}
+	mi := &file_dtako_rows_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DailySummary) String() string {
+func (x *DriverEvents) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DailySummary) ProtoMessage() {}
+func (*DriverEvents) ProtoMessage() {}
 
-func (x *DailySummary) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[7]
+func (x *DriverEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,71 +6201,53 @@ func (x *DailySummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DailySummary.ProtoReflect.Descriptor instead.
-func (*DailySummary) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *DailySummary) GetCarCc() string {
-	if x != nil {
-		return x.CarCc
-	}
-	return ""
-}
-
-func (x *DailySummary) GetDate() string {
-	if x != nil {
-		return x.Date
-	}
-	return ""
-}
-
-func (x *DailySummary) GetTotalDistance() float64 {
-	if x != nil {
-		return x.TotalDistance
-	}
-	return 0
+// Deprecated: Use DriverEvents.ProtoReflect.Descriptor instead.
+func (*DriverEvents) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *DailySummary) GetTotalFuel() float64 {
+func (x *DriverEvents) GetDriverCode() int32 {
 	if x != nil {
-		return x.TotalFuel
+		return x.DriverCode
 	}
 	return 0
 }
 
-func (x *DailySummary) GetTripCount() int32 {
+func (x *DriverEvents) GetBreakdown() *EventBreakdown {
 	if x != nil {
-		return x.TripCount
+		return x.Breakdown
 	}
-	return 0
+	return nil
 }
 
-// 日次サマリーレスポンス
-type DailySummaryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Summaries     []*DailySummary        `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
-	CarCc         string                 `protobuf:"bytes,2,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
-	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// イベント期間集計レスポンス
+type EventSummaryResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles        []*VehicleEvents       `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"` // 車輌CC順
+	Drivers         []*DriverEvents        `protobuf:"bytes,2,rep,name=drivers,proto3" json:"drivers,omitempty"`   // 乗務員CD順
+	Periods         []*EventPeriodSummary  `protobuf:"bytes,3,rep,name=periods,proto3" json:"periods,omitempty"`   // 全車両の期間ごとの合計（期間順）
+	Total           *EventPeriodSummary    `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`       // 全車両の期間全体の合計
+	Period          string                 `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	EventsAvailable bool                   `protobuf:"varint,6,opt,name=events_available,json=eventsAvailable,proto3" json:"events_available,omitempty"` // イベントデータを参照できたか
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *DailySummaryResponse) Reset() {
-	*x = DailySummaryResponse{}
-	mi := &file_dtako_rows_proto_msgTypes[8]
+func (x *EventSummaryResponse) Reset() {
+	*x = EventSummaryResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DailySummaryResponse) String() string {
+func (x *EventSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DailySummaryResponse) ProtoMessage() {}
+func (*EventSummaryResponse) ProtoMessage() {}
 
-func (x *DailySummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[8]
+func (x *EventSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,56 +6258,76 @@ func (x *DailySummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DailySummaryResponse.ProtoReflect.Descriptor instead.
-func (*DailySummaryResponse) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use EventSummaryResponse.ProtoReflect.Descriptor instead.
+func (*EventSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *DailySummaryResponse) GetSummaries() []*DailySummary {
+func (x *EventSummaryResponse) GetVehicles() []*VehicleEvents {
 	if x != nil {
-		return x.Summaries
+		return x.Vehicles
 	}
 	return nil
 }
 
-func (x *DailySummaryResponse) GetCarCc() string {
+func (x *EventSummaryResponse) GetDrivers() []*DriverEvents {
 	if x != nil {
-		return x.CarCc
+		return x.Drivers
 	}
-	return ""
+	return nil
 }
 
-func (x *DailySummaryResponse) GetPeriod() string {
+func (x *EventSummaryResponse) GetPeriods() []*EventPeriodSummary {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *EventSummaryResponse) GetTotal() *EventPeriodSummary {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *EventSummaryResponse) GetPeriod() string {
 	if x != nil {
 		return x.Period
 	}
 	return ""
 }
 
-// CSVエクスポートレスポンス
-type ExportCSVResponse struct {
+func (x *EventSummaryResponse) GetEventsAvailable() bool {
+	if x != nil {
+		return x.EventsAvailable
+	}
+	return false
+}
+
+// 経路取得リクエスト
+type GetTripRouteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CsvData       string                 `protobuf:"bytes,1,opt,name=csv_data,json=csvData,proto3" json:"csv_data,omitempty"` // CSV形式のデータ
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`              // 推奨ファイル名
+	OperationNo   string                 `protobuf:"bytes,1,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"` // 運行NO（必須）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExportCSVResponse) Reset() {
-	*x = ExportCSVResponse{}
-	mi := &file_dtako_rows_proto_msgTypes[9]
+func (x *GetTripRouteRequest) Reset() {
+	*x = GetTripRouteRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExportCSVResponse) String() string {
+func (x *GetTripRouteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportCSVResponse) ProtoMessage() {}
+func (*GetTripRouteRequest) ProtoMessage() {}
 
-func (x *ExportCSVResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[9]
+func (x *GetTripRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,48 +6338,49 @@ func (x *ExportCSVResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExportCSVResponse.ProtoReflect.Descriptor instead.
-func (*ExportCSVResponse) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *ExportCSVResponse) GetCsvData() string {
-	if x != nil {
-		return x.CsvData
-	}
-	return ""
+// Deprecated: Use GetTripRouteRequest.ProtoReflect.Descriptor instead.
+func (*GetTripRouteRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *ExportCSVResponse) GetFilename() string {
+func (x *GetTripRouteRequest) GetOperationNo() string {
 	if x != nil {
-		return x.Filename
+		return x.OperationNo
 	}
 	return ""
 }
 
-// 運行データ取得リクエスト
-type GetRowRequest struct {
+// 経路上の地点
+type RoutePoint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 運行データID
+	Time          string                 `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`                             // 通過日時（RFC3339形式、パースできない場合は空）
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`                   // 緯度（WGS84、度）
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`                 // 経度（WGS84、度）
+	PlaceName     string                 `protobuf:"bytes,4,opt,name=place_name,json=placeName,proto3" json:"place_name,omitempty"`  // 場所名（未設定の場合は市町村名）
+	CityName      string                 `protobuf:"bytes,5,opt,name=city_name,json=cityName,proto3" json:"city_name,omitempty"`     // 市町村名
+	EventCode     int32                  `protobuf:"varint,6,opt,name=event_code,json=eventCode,proto3" json:"event_code,omitempty"` // イベントCD（未設定の場合は-1）
+	EventName     string                 `protobuf:"bytes,7,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`  // イベント名
+	Edge          string                 `protobuf:"bytes,8,opt,name=edge,proto3" json:"edge,omitempty"`                             // start（イベントの開始位置） / end（イベントの終了位置）
+	Mileage       float64                `protobuf:"fixed64,9,opt,name=mileage,proto3" json:"mileage,omitempty"`                     // 走行距離計 (km)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRowRequest) Reset() {
-	*x = GetRowRequest{}
-	mi := &file_dtako_rows_proto_msgTypes[10]
+func (x *RoutePoint) Reset() {
+	*x = RoutePoint{}
+	mi := &file_dtako_rows_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRowRequest) String() string {
+func (x *RoutePoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRowRequest) ProtoMessage() {}
+func (*RoutePoint) ProtoMessage() {}
 
-func (x *GetRowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[10]
+func (x *RoutePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,88 +6391,103 @@ func (x *GetRowRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRowRequest.ProtoReflect.Descriptor instead.
-func (*GetRowRequest) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RoutePoint.ProtoReflect.Descriptor instead.
+func (*RoutePoint) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *GetRowRequest) GetId() string {
+func (x *RoutePoint) GetTime() string {
 	if x != nil {
-		return x.Id
+		return x.Time
 	}
 	return ""
 }
 
-// 運行データレスポンス
-type RowResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Row           *Row                   `protobuf:"bytes,1,opt,name=row,proto3" json:"row,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RoutePoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
 }
 
-func (x *RowResponse) Reset() {
-	*x = RowResponse{}
-	mi := &file_dtako_rows_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *RoutePoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
 }
 
-func (x *RowResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RoutePoint) GetPlaceName() string {
+	if x != nil {
+		return x.PlaceName
+	}
+	return ""
 }
 
-func (*RowResponse) ProtoMessage() {}
+func (x *RoutePoint) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
 
-func (x *RowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[11]
+func (x *RoutePoint) GetEventCode() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EventCode
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use RowResponse.ProtoReflect.Descriptor instead.
-func (*RowResponse) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{11}
+func (x *RoutePoint) GetEventName() string {
+	if x != nil {
+		return x.EventName
+	}
+	return ""
 }
 
-func (x *RowResponse) GetRow() *Row {
+func (x *RoutePoint) GetEdge() string {
 	if x != nil {
-		return x.Row
+		return x.Edge
 	}
-	return nil
+	return ""
 }
 
-// 運行データ一覧取得リクエスト
-type ListRowsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                         // 取得件数上限
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                       // オフセット
-	OrderBy       *string                `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3,oneof" json:"order_by,omitempty"` // ソート順
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RoutePoint) GetMileage() float64 {
+	if x != nil {
+		return x.Mileage
+	}
+	return 0
 }
 
-func (x *ListRowsRequest) Reset() {
-	*x = ListRowsRequest{}
-	mi := &file_dtako_rows_proto_msgTypes[12]
+// 経路取得レスポンス
+type TripRouteResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OperationNo     string                 `protobuf:"bytes,1,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"`
+	CarCc           string                 `protobuf:"bytes,2,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
+	Points          []*RoutePoint          `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`                                           // 通過順
+	EventCount      int32                  `protobuf:"varint,4,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"`                // イベントの件数
+	DroppedCount    int32                  `protobuf:"varint,5,opt,name=dropped_count,json=droppedCount,proto3" json:"dropped_count,omitempty"`          // GPSが無効・範囲外のため除いた位置の数
+	Distance        float64                `protobuf:"fixed64,6,opt,name=distance,proto3" json:"distance,omitempty"`                                     // 地点間の直線距離の合計 (km)
+	EventsAvailable bool                   `protobuf:"varint,7,opt,name=events_available,json=eventsAvailable,proto3" json:"events_available,omitempty"` // イベントデータを参照できたか
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TripRouteResponse) Reset() {
+	*x = TripRouteResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRowsRequest) String() string {
+func (x *TripRouteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRowsRequest) ProtoMessage() {}
+func (*TripRouteResponse) ProtoMessage() {}
 
-func (x *ListRowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[12]
+func (x *TripRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,56 +6498,123 @@ func (x *ListRowsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRowsRequest.ProtoReflect.Descriptor instead.
-func (*ListRowsRequest) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use TripRouteResponse.ProtoReflect.Descriptor instead.
+func (*TripRouteResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *ListRowsRequest) GetLimit() int32 {
+func (x *TripRouteResponse) GetOperationNo() string {
 	if x != nil {
-		return x.Limit
+		return x.OperationNo
+	}
+	return ""
+}
+
+func (x *TripRouteResponse) GetCarCc() string {
+	if x != nil {
+		return x.CarCc
+	}
+	return ""
+}
+
+func (x *TripRouteResponse) GetPoints() []*RoutePoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *TripRouteResponse) GetEventCount() int32 {
+	if x != nil {
+		return x.EventCount
 	}
 	return 0
 }
 
-func (x *ListRowsRequest) GetOffset() int32 {
+func (x *TripRouteResponse) GetDroppedCount() int32 {
 	if x != nil {
-		return x.Offset
+		return x.DroppedCount
 	}
 	return 0
 }
 
-func (x *ListRowsRequest) GetOrderBy() string {
-	if x != nil && x.OrderBy != nil {
-		return *x.OrderBy
+func (x *TripRouteResponse) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
 	}
-	return ""
+	return 0
+}
+
+func (x *TripRouteResponse) GetEventsAvailable() bool {
+	if x != nil {
+		return x.EventsAvailable
+	}
+	return false
+}
+
+// キャッシュ統計取得リクエスト
+type GetCacheStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheStatsRequest) Reset() {
+	*x = GetCacheStatsRequest{}
+	mi := &file_dtako_rows_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheStatsRequest) ProtoMessage() {}
+
+func (x *GetCacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-// 運行データ一覧レスポンス
-type ListRowsResponse struct {
+// Deprecated: Use GetCacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{79}
+}
+
+// RPCごとのキャッシュ統計
+type RPCCacheStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rows          []*Row                 `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Rpc           string                 `protobuf:"bytes,1,opt,name=rpc,proto3" json:"rpc,omitempty"` // RPC名
+	Hits          int64                  `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses        int64                  `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Entries       int32                  `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"` // 保持しているエントリ数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRowsResponse) Reset() {
-	*x = ListRowsResponse{}
-	mi := &file_dtako_rows_proto_msgTypes[13]
+func (x *RPCCacheStats) Reset() {
+	*x = RPCCacheStats{}
+	mi := &file_dtako_rows_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRowsResponse) String() string {
+func (x *RPCCacheStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRowsResponse) ProtoMessage() {}
+func (*RPCCacheStats) ProtoMessage() {}
 
-func (x *ListRowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[13]
+func (x *RPCCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -832,64 +6625,72 @@ func (x *ListRowsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRowsResponse.ProtoReflect.Descriptor instead.
-func (*ListRowsResponse) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use RPCCacheStats.ProtoReflect.Descriptor instead.
+func (*RPCCacheStats) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *ListRowsResponse) GetRows() []*Row {
+func (x *RPCCacheStats) GetRpc() string {
 	if x != nil {
-		return x.Rows
+		return x.Rpc
 	}
-	return nil
+	return ""
 }
 
-func (x *ListRowsResponse) GetTotalCount() int32 {
+func (x *RPCCacheStats) GetHits() int64 {
 	if x != nil {
-		return x.TotalCount
+		return x.Hits
 	}
 	return 0
 }
 
-// 運行データ
-type Row struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	OperationNo          string                 `protobuf:"bytes,2,opt,name=operation_no,json=operationNo,proto3" json:"operation_no,omitempty"`
-	ReadDate             string                 `protobuf:"bytes,3,opt,name=read_date,json=readDate,proto3" json:"read_date,omitempty"`
-	OperationDate        string                 `protobuf:"bytes,4,opt,name=operation_date,json=operationDate,proto3" json:"operation_date,omitempty"`
-	CarCode              int32                  `protobuf:"varint,5,opt,name=car_code,json=carCode,proto3" json:"car_code,omitempty"`
-	CarCc                string                 `protobuf:"bytes,6,opt,name=car_cc,json=carCc,proto3" json:"car_cc,omitempty"`
-	StartWorkDatetime    string                 `protobuf:"bytes,7,opt,name=start_work_datetime,json=startWorkDatetime,proto3" json:"start_work_datetime,omitempty"`
-	EndWorkDatetime      string                 `protobuf:"bytes,8,opt,name=end_work_datetime,json=endWorkDatetime,proto3" json:"end_work_datetime,omitempty"`
-	DepartureDatetime    string                 `protobuf:"bytes,9,opt,name=departure_datetime,json=departureDatetime,proto3" json:"departure_datetime,omitempty"`
-	ReturnDatetime       string                 `protobuf:"bytes,10,opt,name=return_datetime,json=returnDatetime,proto3" json:"return_datetime,omitempty"`
-	DepartureMeter       float64                `protobuf:"fixed64,11,opt,name=departure_meter,json=departureMeter,proto3" json:"departure_meter,omitempty"`
-	ReturnMeter          float64                `protobuf:"fixed64,12,opt,name=return_meter,json=returnMeter,proto3" json:"return_meter,omitempty"`
-	TotalDistance        float64                `protobuf:"fixed64,13,opt,name=total_distance,json=totalDistance,proto3" json:"total_distance,omitempty"`
-	DriverCode1          *int32                 `protobuf:"varint,14,opt,name=driver_code1,json=driverCode1,proto3,oneof" json:"driver_code1,omitempty"`
-	LoadedDistance       *float64               `protobuf:"fixed64,15,opt,name=loaded_distance,json=loadedDistance,proto3,oneof" json:"loaded_distance,omitempty"`
-	DestinationCityName  *string                `protobuf:"bytes,16,opt,name=destination_city_name,json=destinationCityName,proto3,oneof" json:"destination_city_name,omitempty"`
-	DestinationPlaceName *string                `protobuf:"bytes,17,opt,name=destination_place_name,json=destinationPlaceName,proto3,oneof" json:"destination_place_name,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+func (x *RPCCacheStats) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
 }
 
-func (x *Row) Reset() {
-	*x = Row{}
-	mi := &file_dtako_rows_proto_msgTypes[14]
+func (x *RPCCacheStats) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+// キャッシュ統計レスポンス
+type CacheStatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // キャッシュが有効か（AGGREGATE_CACHE=off で無効）
+	Hits              int64                  `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses            int64                  `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	HitRatio          float64                `protobuf:"fixed64,4,opt,name=hit_ratio,json=hitRatio,proto3" json:"hit_ratio,omitempty"`                            // ヒット率 (0〜1)
+	Entries           int32                  `protobuf:"varint,5,opt,name=entries,proto3" json:"entries,omitempty"`                                               // 保持しているエントリ数
+	Invalidations     int64                  `protobuf:"varint,6,opt,name=invalidations,proto3" json:"invalidations,omitempty"`                                   // 読取日の更新で無効化された件数
+	Expirations       int64                  `protobuf:"varint,7,opt,name=expirations,proto3" json:"expirations,omitempty"`                                       // TTL切れで破棄された件数
+	Evictions         int64                  `protobuf:"varint,8,opt,name=evictions,proto3" json:"evictions,omitempty"`                                           // 上限超過で破棄された件数
+	ReadDateWatermark string                 `protobuf:"bytes,9,opt,name=read_date_watermark,json=readDateWatermark,proto3" json:"read_date_watermark,omitempty"` // 確認済みの最新の読取日
+	LastCheckedAt     string                 `protobuf:"bytes,10,opt,name=last_checked_at,json=lastCheckedAt,proto3" json:"last_checked_at,omitempty"`            // 最後に読取日を確認した日時 (RFC3339)
+	Rpcs              []*RPCCacheStats       `protobuf:"bytes,11,rep,name=rpcs,proto3" json:"rpcs,omitempty"`                                                     // RPC名順
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CacheStatsResponse) Reset() {
+	*x = CacheStatsResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Row) String() string {
+func (x *CacheStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Row) ProtoMessage() {}
+func (*CacheStatsResponse) ProtoMessage() {}
 
-func (x *Row) ProtoReflect() protoreflect.Message {
-	mi := &file_dtako_rows_proto_msgTypes[14]
+func (x *CacheStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,126 +6701,198 @@ func (x *Row) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Row.ProtoReflect.Descriptor instead.
-func (*Row) Descriptor() ([]byte, []int) {
-	return file_dtako_rows_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use CacheStatsResponse.ProtoReflect.Descriptor instead.
+func (*CacheStatsResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *Row) GetId() string {
+func (x *CacheStatsResponse) GetEnabled() bool {
 	if x != nil {
-		return x.Id
+		return x.Enabled
 	}
-	return ""
+	return false
 }
 
-func (x *Row) GetOperationNo() string {
+func (x *CacheStatsResponse) GetHits() int64 {
 	if x != nil {
-		return x.OperationNo
+		return x.Hits
 	}
-	return ""
+	return 0
 }
 
-func (x *Row) GetReadDate() string {
+func (x *CacheStatsResponse) GetMisses() int64 {
 	if x != nil {
-		return x.ReadDate
+		return x.Misses
 	}
-	return ""
+	return 0
 }
 
-func (x *Row) GetOperationDate() string {
+func (x *CacheStatsResponse) GetHitRatio() float64 {
 	if x != nil {
-		return x.OperationDate
+		return x.HitRatio
 	}
-	return ""
+	return 0
 }
 
-func (x *Row) GetCarCode() int32 {
+func (x *CacheStatsResponse) GetEntries() int32 {
 	if x != nil {
-		return x.CarCode
+		return x.Entries
 	}
 	return 0
 }
 
-func (x *Row) GetCarCc() string {
+func (x *CacheStatsResponse) GetInvalidations() int64 {
 	if x != nil {
-		return x.CarCc
+		return x.Invalidations
 	}
-	return ""
+	return 0
 }
 
-func (x *Row) GetStartWorkDatetime() string {
+func (x *CacheStatsResponse) GetExpirations() int64 {
 	if x != nil {
-		return x.StartWorkDatetime
+		return x.Expirations
 	}
-	return ""
+	return 0
 }
 
-func (x *Row) GetEndWorkDatetime() string {
+func (x *CacheStatsResponse) GetEvictions() int64 {
 	if x != nil {
-		return x.EndWorkDatetime
+		return x.Evictions
 	}
-	return ""
+	return 0
 }
 
-func (x *Row) GetDepartureDatetime() string {
+func (x *CacheStatsResponse) GetReadDateWatermark() string {
 	if x != nil {
-		return x.DepartureDatetime
+		return x.ReadDateWatermark
 	}
 	return ""
 }
 
-func (x *Row) GetReturnDatetime() string {
+func (x *CacheStatsResponse) GetLastCheckedAt() string {
 	if x != nil {
-		return x.ReturnDatetime
+		return x.LastCheckedAt
 	}
 	return ""
 }
 
-func (x *Row) GetDepartureMeter() float64 {
+func (x *CacheStatsResponse) GetRpcs() []*RPCCacheStats {
 	if x != nil {
-		return x.DepartureMeter
+		return x.Rpcs
 	}
-	return 0
+	return nil
 }
 
-func (x *Row) GetReturnMeter() float64 {
+// 出力オプション
+type ExportOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Encoding      string                 `protobuf:"bytes,1,opt,name=encoding,proto3" json:"encoding,omitempty"` // CSVの文字コード: utf-8（省略時） / utf-8-bom / shift_jis
+	Columns       []string               `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`   // 出力する列のキー（指定順に出力、省略時は既定の列）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportOptions) Reset() {
+	*x = ExportOptions{}
+	mi := &file_dtako_rows_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportOptions) ProtoMessage() {}
+
+func (x *ExportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[82]
 	if x != nil {
-		return x.ReturnMeter
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Row) GetTotalDistance() float64 {
+// Deprecated: Use ExportOptions.ProtoReflect.Descriptor instead.
+func (*ExportOptions) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ExportOptions) GetEncoding() string {
 	if x != nil {
-		return x.TotalDistance
+		return x.Encoding
 	}
-	return 0
+	return ""
 }
 
-func (x *Row) GetDriverCode1() int32 {
-	if x != nil && x.DriverCode1 != nil {
-		return *x.DriverCode1
+func (x *ExportOptions) GetColumns() []string {
+	if x != nil {
+		return x.Columns
 	}
-	return 0
+	return nil
 }
 
-func (x *Row) GetLoadedDistance() float64 {
-	if x != nil && x.LoadedDistance != nil {
-		return *x.LoadedDistance
+// ファイル出力レスポンス
+type ExportFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                                  // ファイル内容
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // ファイル名
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // MIMEタイプ
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFileResponse) Reset() {
+	*x = ExportFileResponse{}
+	mi := &file_dtako_rows_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFileResponse) ProtoMessage() {}
+
+func (x *ExportFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dtako_rows_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Row) GetDestinationCityName() string {
-	if x != nil && x.DestinationCityName != nil {
-		return *x.DestinationCityName
+// Deprecated: Use ExportFileResponse.ProtoReflect.Descriptor instead.
+func (*ExportFileResponse) Descriptor() ([]byte, []int) {
+	return file_dtako_rows_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ExportFileResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportFileResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
 	}
 	return ""
 }
 
-func (x *Row) GetDestinationPlaceName() string {
-	if x != nil && x.DestinationPlaceName != nil {
-		return *x.DestinationPlaceName
+func (x *ExportFileResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
 	}
 	return ""
 }
@@ -1029,7 +6902,19 @@ var File_dtako_rows_proto protoreflect.FileDescriptor
 const file_dtako_rows_proto_rawDesc = "" +
 	"\n" +
 	"\x10dtako_rows.proto\x12\n" +
-	"dtako_rows\"\xdf\x01\n" +
+	"dtako_rows\"w\n" +
+	"\tBucketing\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vclosing_day\x18\x02 \x01(\x05R\n" +
+	"closingDay\x125\n" +
+	"\x17fiscal_year_start_month\x18\x03 \x01(\x05R\x14fiscalYearStartMonth\"\x8a\x01\n" +
+	"\fPeriodBucket\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x04 \x01(\tR\aendDate\x12\x18\n" +
+	"\apartial\x18\x05 \x01(\bR\apartial\"\x84\x05\n" +
 	"\x12MonthlyFuelSummary\x12\x15\n" +
 	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
 	"\n" +
@@ -1039,32 +6924,82 @@ const file_dtako_rows_proto_rawDesc = "" +
 	"total_fuel\x18\x04 \x01(\x01R\ttotalFuel\x12\x1d\n" +
 	"\n" +
 	"trip_count\x18\x05 \x01(\x05R\ttripCount\x12.\n" +
-	"\x13avg_fuel_efficiency\x18\x06 \x01(\x01R\x11avgFuelEfficiency\"s\n" +
+	"\x13avg_fuel_efficiency\x18\x06 \x01(\x01R\x11avgFuelEfficiency\x12'\n" +
+	"\x0ffuel_efficiency\x18\a \x01(\x01R\x0efuelEfficiency\x124\n" +
+	"\x16fuel_efficiency_source\x18\b \x01(\tR\x14fuelEfficiencySource\x12\x1d\n" +
+	"\n" +
+	"fuel_basis\x18\t \x01(\tR\tfuelBasis\x12#\n" +
+	"\rmeasured_fuel\x18\n" +
+	" \x01(\x01R\fmeasuredFuel\x12%\n" +
+	"\x0eestimated_fuel\x18\v \x01(\x01R\restimatedFuel\x12!\n" +
+	"\frefuel_count\x18\f \x01(\x05R\vrefuelCount\x120\n" +
+	"\x06bucket\x18\r \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\x12%\n" +
+	"\x0eferry_distance\x18\x0e \x01(\x01R\rferryDistance\x12.\n" +
+	"\x13distance_with_ferry\x18\x0f \x01(\x01R\x11distanceWithFerry\x12-\n" +
+	"\x12uncovered_distance\x18\x10 \x01(\x01R\x11uncoveredDistance\"\xea\x01\n" +
 	" GetMonthlyFuelConsumptionRequest\x12\x15\n" +
 	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
 	"\n" +
 	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x03 \x01(\tR\aendDate\"\x8d\x01\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x12@\n" +
+	"\x0eexport_options\x18\x04 \x01(\v2\x19.dtako_rows.ExportOptionsR\rexportOptions\x123\n" +
+	"\tbucketing\x18\x05 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\"\x8d\x01\n" +
 	"\x1eMonthlyFuelConsumptionResponse\x12<\n" +
 	"\tsummaries\x18\x01 \x03(\v2\x1e.dtako_rows.MonthlyFuelSummaryR\tsummaries\x12\x15\n" +
 	"\x06car_cc\x18\x02 \x01(\tR\x05carCc\x12\x16\n" +
-	"\x06period\x18\x03 \x01(\tR\x06period\"[\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\"\xae\x02\n" +
 	"\x1fGetVehicleMonthlySummaryRequest\x12\x1d\n" +
 	"\n" +
 	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x02 \x01(\tR\aendDate\"n\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12@\n" +
+	"\x0eexport_options\x18\x03 \x01(\v2\x19.dtako_rows.ExportOptionsR\rexportOptions\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\x12\x17\n" +
+	"\asort_by\x18\x05 \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x06 \x01(\bR\n" +
+	"descending\x12!\n" +
+	"\franking_size\x18\a \x01(\x05R\vrankingSize\"\xe7\x03\n" +
+	"\rSummaryTotals\x12%\n" +
+	"\x0etotal_distance\x18\x01 \x01(\x01R\rtotalDistance\x12\x1d\n" +
+	"\n" +
+	"total_fuel\x18\x02 \x01(\x01R\ttotalFuel\x12#\n" +
+	"\rmeasured_fuel\x18\x03 \x01(\x01R\fmeasuredFuel\x12%\n" +
+	"\x0eestimated_fuel\x18\x04 \x01(\x01R\restimatedFuel\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\x05 \x01(\x05R\ttripCount\x12!\n" +
+	"\frefuel_count\x18\x06 \x01(\x05R\vrefuelCount\x12.\n" +
+	"\x13avg_fuel_efficiency\x18\a \x01(\x01R\x11avgFuelEfficiency\x12%\n" +
+	"\x0eferry_distance\x18\b \x01(\x01R\rferryDistance\x12.\n" +
+	"\x13distance_with_ferry\x18\t \x01(\x01R\x11distanceWithFerry\x12)\n" +
+	"\x10measured_periods\x18\n" +
+	" \x01(\x05R\x0fmeasuredPeriods\x12+\n" +
+	"\x11estimated_periods\x18\v \x01(\x05R\x10estimatedPeriods\x12#\n" +
+	"\rmixed_periods\x18\f \x01(\x05R\fmixedPeriods\"\xa1\x01\n" +
 	"\x17VehicleMonthlySummaries\x12\x15\n" +
 	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12<\n" +
-	"\tsummaries\x18\x02 \x03(\v2\x1e.dtako_rows.MonthlyFuelSummaryR\tsummaries\"\xb0\x01\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1e.dtako_rows.MonthlyFuelSummaryR\tsummaries\x121\n" +
+	"\x06totals\x18\x03 \x01(\v2\x19.dtako_rows.SummaryTotalsR\x06totals\"P\n" +
+	"\rRankedVehicle\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x15\n" +
+	"\x06car_cc\x18\x02 \x01(\tR\x05carCc\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\"\x88\x01\n" +
+	"\x0eVehicleRanking\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12+\n" +
+	"\x03top\x18\x02 \x03(\v2\x19.dtako_rows.RankedVehicleR\x03top\x121\n" +
+	"\x06bottom\x18\x03 \x03(\v2\x19.dtako_rows.RankedVehicleR\x06bottom\"\xef\x02\n" +
 	"\x1dVehicleMonthlySummaryResponse\x12P\n" +
 	"\x11vehicle_summaries\x18\x01 \x03(\v2#.dtako_rows.VehicleMonthlySummariesR\x10vehicleSummaries\x12%\n" +
 	"\x0etotal_vehicles\x18\x02 \x01(\x05R\rtotalVehicles\x12\x16\n" +
-	"\x06period\x18\x03 \x01(\tR\x06period\"i\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\x12G\n" +
+	"\x0ffleet_summaries\x18\x04 \x03(\v2\x1e.dtako_rows.MonthlyFuelSummaryR\x0efleetSummaries\x12<\n" +
+	"\ffleet_totals\x18\x05 \x01(\v2\x19.dtako_rows.SummaryTotalsR\vfleetTotals\x126\n" +
+	"\brankings\x18\x06 \x03(\v2\x1a.dtako_rows.VehicleRankingR\brankings\"\x9e\x01\n" +
 	"\x16GetDailySummaryRequest\x12\x15\n" +
 	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
 	"\n" +
 	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x03 \x01(\tR\aendDate\"\x9e\x01\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\"\xec\x03\n" +
 	"\fDailySummary\x12\x15\n" +
 	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x12\n" +
 	"\x04date\x18\x02 \x01(\tR\x04date\x12%\n" +
@@ -1072,27 +7007,59 @@ const file_dtako_rows_proto_rawDesc = "" +
 	"\n" +
 	"total_fuel\x18\x04 \x01(\x01R\ttotalFuel\x12\x1d\n" +
 	"\n" +
-	"trip_count\x18\x05 \x01(\x05R\ttripCount\"}\n" +
+	"trip_count\x18\x05 \x01(\x05R\ttripCount\x12'\n" +
+	"\x0ffuel_efficiency\x18\x06 \x01(\x01R\x0efuelEfficiency\x124\n" +
+	"\x16fuel_efficiency_source\x18\a \x01(\tR\x14fuelEfficiencySource\x12\x1d\n" +
+	"\n" +
+	"fuel_basis\x18\b \x01(\tR\tfuelBasis\x12#\n" +
+	"\rmeasured_fuel\x18\t \x01(\x01R\fmeasuredFuel\x12%\n" +
+	"\x0eestimated_fuel\x18\n" +
+	" \x01(\x01R\restimatedFuel\x12!\n" +
+	"\frefuel_count\x18\v \x01(\x05R\vrefuelCount\x120\n" +
+	"\x06bucket\x18\f \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\x12-\n" +
+	"\x12uncovered_distance\x18\r \x01(\x01R\x11uncoveredDistance\"}\n" +
 	"\x14DailySummaryResponse\x126\n" +
 	"\tsummaries\x18\x01 \x03(\v2\x18.dtako_rows.DailySummaryR\tsummaries\x12\x15\n" +
 	"\x06car_cc\x18\x02 \x01(\tR\x05carCc\x12\x16\n" +
-	"\x06period\x18\x03 \x01(\tR\x06period\"J\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\"\x81\x01\n" +
 	"\x11ExportCSVResponse\x12\x19\n" +
 	"\bcsv_data\x18\x01 \x01(\tR\acsvData\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\"\x1f\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\"\x1f\n" +
 	"\rGetRowRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
 	"\vRowResponse\x12!\n" +
-	"\x03row\x18\x01 \x01(\v2\x0f.dtako_rows.RowR\x03row\"l\n" +
+	"\x03row\x18\x01 \x01(\v2\x0f.dtako_rows.RowR\x03row\"\xd3\x03\n" +
 	"\x0fListRowsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1e\n" +
-	"\border_by\x18\x03 \x01(\tH\x00R\aorderBy\x88\x01\x01B\v\n" +
-	"\t_order_by\"X\n" +
+	"\border_by\x18\x03 \x01(\tH\x00R\aorderBy\x88\x01\x01\x12\x1a\n" +
+	"\x06car_cc\x18\x04 \x01(\tH\x01R\x05carCc\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x06 \x01(\tR\aendDate\x12&\n" +
+	"\fmin_distance\x18\a \x01(\x01H\x02R\vminDistance\x88\x01\x01\x12#\n" +
+	"\roperation_nos\x18\b \x03(\tR\foperationNos\x122\n" +
+	"\x15exclude_zero_distance\x18\t \x01(\bR\x13excludeZeroDistance\x12$\n" +
+	"\vdriver_code\x18\n" +
+	" \x01(\x05H\x03R\n" +
+	"driverCode\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"use_cursor\x18\f \x01(\bR\tuseCursorB\v\n" +
+	"\t_order_byB\t\n" +
+	"\a_car_ccB\x0f\n" +
+	"\r_min_distanceB\x0e\n" +
+	"\f_driver_code\"\x9f\x01\n" +
 	"\x10ListRowsResponse\x12#\n" +
 	"\x04rows\x18\x01 \x03(\v2\x0f.dtako_rows.RowR\x04rows\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xf9\x05\n" +
+	"totalCount\x12\x1d\n" +
+	"\n" +
+	"query_path\x18\x03 \x01(\tR\tqueryPath\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\xf9\x05\n" +
 	"\x03Row\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\foperation_no\x18\x02 \x01(\tR\voperationNo\x12\x1b\n" +
@@ -1115,14 +7082,525 @@ const file_dtako_rows_proto_rawDesc = "" +
 	"\r_driver_code1B\x12\n" +
 	"\x10_loaded_distanceB\x18\n" +
 	"\x16_destination_city_nameB\x19\n" +
-	"\x17_destination_place_name2\xc0\x04\n" +
+	"\x17_destination_place_name\"t\n" +
+	"\x11StreamRowsRequest\x12\x1a\n" +
+	"\x06car_cc\x18\x01 \x01(\tH\x00R\x05carCc\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDateB\t\n" +
+	"\a_car_cc\"P\n" +
+	"\bRowBatch\x12#\n" +
+	"\x04rows\x18\x01 \x03(\v2\x0f.dtako_rows.RowR\x04rows\x12\x1f\n" +
+	"\vbatch_index\x18\x02 \x01(\x05R\n" +
+	"batchIndex\"\xbe\x01\n" +
+	"\x17GetDriverSummaryRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12$\n" +
+	"\vdriver_code\x18\x03 \x01(\x05H\x00R\n" +
+	"driverCode\x88\x01\x01\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketingB\x0e\n" +
+	"\f_driver_code\"\xfc\x03\n" +
+	"\x13DriverPeriodSummary\x12\x1f\n" +
+	"\vdriver_code\x18\x01 \x01(\tR\n" +
+	"driverCode\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12%\n" +
+	"\x0etotal_distance\x18\x03 \x01(\x01R\rtotalDistance\x12'\n" +
+	"\x0floaded_distance\x18\x04 \x01(\x01R\x0eloadedDistance\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\x05 \x01(\x05R\ttripCount\x125\n" +
+	"\x17general_road_drive_time\x18\x06 \x01(\x05R\x14generalRoadDriveTime\x12,\n" +
+	"\x12highway_drive_time\x18\a \x01(\x05R\x10highwayDriveTime\x12*\n" +
+	"\x11bypass_drive_time\x18\b \x01(\x05R\x0fbypassDriveTime\x12\x1d\n" +
+	"\n" +
+	"work1_time\x18\t \x01(\x05R\twork1Time\x12\x1d\n" +
+	"\n" +
+	"work2_time\x18\n" +
+	" \x01(\x05R\twork2Time\x12\x1d\n" +
+	"\n" +
+	"work3_time\x18\v \x01(\x05R\twork3Time\x12\x1d\n" +
+	"\n" +
+	"work4_time\x18\f \x01(\x05R\twork4Time\x120\n" +
+	"\x06bucket\x18\r \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\"q\n" +
+	"\x0fDriverSummaries\x12\x1f\n" +
+	"\vdriver_code\x18\x01 \x01(\tR\n" +
+	"driverCode\x12=\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1f.dtako_rows.DriverPeriodSummaryR\tsummaries\"\x9c\x01\n" +
+	"\x15DriverSummaryResponse\x12F\n" +
+	"\x10driver_summaries\x18\x01 \x03(\v2\x1b.dtako_rows.DriverSummariesR\x0fdriverSummaries\x12#\n" +
+	"\rtotal_drivers\x18\x02 \x01(\x05R\ftotalDrivers\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\"\x84\x02\n" +
+	"\x1cGetLoadedRatioSummaryRequest\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x120\n" +
+	"\x14poor_ratio_threshold\x18\x04 \x01(\x01R\x12poorRatioThreshold\x12,\n" +
+	"\x12poor_months_streak\x18\x05 \x01(\x05R\x10poorMonthsStreak\x123\n" +
+	"\tbucketing\x18\x06 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\"\xdc\x03\n" +
+	"\x12LoadedRatioSummary\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"year_month\x18\x02 \x01(\tR\tyearMonth\x12%\n" +
+	"\x0etotal_distance\x18\x03 \x01(\x01R\rtotalDistance\x12'\n" +
+	"\x0floaded_distance\x18\x04 \x01(\x01R\x0eloadedDistance\x12%\n" +
+	"\x0eempty_distance\x18\x05 \x01(\x01R\remptyDistance\x12*\n" +
+	"\x11loaded_drive_time\x18\x06 \x01(\x05R\x0floadedDriveTime\x12(\n" +
+	"\x10empty_drive_time\x18\a \x01(\x05R\x0eemptyDriveTime\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\b \x01(\x05R\ttripCount\x122\n" +
+	"\x15loaded_distance_ratio\x18\t \x01(\x01R\x13loadedDistanceRatio\x12*\n" +
+	"\x11loaded_time_ratio\x18\n" +
+	" \x01(\x01R\x0floadedTimeRatio\x12\x12\n" +
+	"\x04poor\x18\v \x01(\bR\x04poor\x120\n" +
+	"\x06bucket\x18\f \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\"\x84\x02\n" +
+	"\x12VehicleLoadedRatio\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12<\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1e.dtako_rows.LoadedRatioSummaryR\tsummaries\x122\n" +
+	"\x15loaded_distance_ratio\x18\x03 \x01(\x01R\x13loadedDistanceRatio\x12*\n" +
+	"\x11loaded_time_ratio\x18\x04 \x01(\x01R\x0floadedTimeRatio\x12\x1f\n" +
+	"\vpoor_months\x18\x05 \x01(\x05R\n" +
+	"poorMonths\x12\x18\n" +
+	"\aflagged\x18\x06 \x01(\bR\aflagged\"\x9b\x01\n" +
+	"\x1aLoadedRatioSummaryResponse\x12:\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x1e.dtako_rows.VehicleLoadedRatioR\bvehicles\x12)\n" +
+	"\x10flagged_vehicles\x18\x02 \x01(\x05R\x0fflaggedVehicles\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\"\x9d\x04\n" +
+	"\x14ComplianceThresholds\x12=\n" +
+	"\x1bmax_daily_restraint_minutes\x18\x01 \x01(\x05R\x18maxDailyRestraintMinutes\x12G\n" +
+	" extended_daily_restraint_minutes\x18\x02 \x01(\x05R\x1dextendedDailyRestraintMinutes\x129\n" +
+	"\x19min_rest_interval_minutes\x18\x03 \x01(\x05R\x16minRestIntervalMinutes\x12I\n" +
+	"!recommended_rest_interval_minutes\x18\x04 \x01(\x05R\x1erecommendedRestIntervalMinutes\x12C\n" +
+	"\x1emax_continuous_driving_minutes\x18\x05 \x01(\x05R\x1bmaxContinuousDrivingMinutes\x12*\n" +
+	"\x11min_break_minutes\x18\x06 \x01(\x05R\x0fminBreakMinutes\x12C\n" +
+	"\x1fmax_two_day_avg_driving_minutes\x18\a \x01(\x05R\x1amaxTwoDayAvgDrivingMinutes\x12A\n" +
+	"\x1dmax_monthly_restraint_minutes\x18\b \x01(\x05R\x1amaxMonthlyRestraintMinutes\"\xd0\x01\n" +
+	"\x1cCheckDriverComplianceRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12$\n" +
+	"\vdriver_code\x18\x03 \x01(\x05H\x00R\n" +
+	"driverCode\x88\x01\x01\x12@\n" +
+	"\n" +
+	"thresholds\x18\x04 \x01(\v2 .dtako_rows.ComplianceThresholdsR\n" +
+	"thresholdsB\x0e\n" +
+	"\f_driver_code\"\xf9\x01\n" +
+	"\x13ComplianceViolation\x12\x1f\n" +
+	"\vdriver_code\x18\x01 \x01(\tR\n" +
+	"driverCode\x12\x12\n" +
+	"\x04rule\x18\x02 \x01(\tR\x04rule\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x12\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\x12%\n" +
+	"\x0eactual_minutes\x18\x05 \x01(\x05R\ractualMinutes\x12#\n" +
+	"\rlimit_minutes\x18\x06 \x01(\x05R\flimitMinutes\x12\x17\n" +
+	"\arow_ids\x18\a \x03(\tR\x06rowIds\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\"\xaf\x01\n" +
+	"\x17DriverMonthlyCompliance\x12\x1d\n" +
+	"\n" +
+	"year_month\x18\x01 \x01(\tR\tyearMonth\x12+\n" +
+	"\x11restraint_minutes\x18\x02 \x01(\x05R\x10restraintMinutes\x12'\n" +
+	"\x0fdriving_minutes\x18\x03 \x01(\x05R\x0edrivingMinutes\x12\x1f\n" +
+	"\vshift_count\x18\x04 \x01(\x05R\n" +
+	"shiftCount\"\xba\x02\n" +
+	"\x10DriverCompliance\x12\x1f\n" +
+	"\vdriver_code\x18\x01 \x01(\tR\n" +
+	"driverCode\x12=\n" +
+	"\amonthly\x18\x02 \x03(\v2#.dtako_rows.DriverMonthlyComplianceR\amonthly\x12=\n" +
+	"\x1bmax_daily_restraint_minutes\x18\x03 \x01(\x05R\x18maxDailyRestraintMinutes\x129\n" +
+	"\x19min_rest_interval_minutes\x18\x04 \x01(\x05R\x16minRestIntervalMinutes\x12'\n" +
+	"\x0fviolation_count\x18\x05 \x01(\x05R\x0eviolationCount\x12#\n" +
+	"\rwarning_count\x18\x06 \x01(\x05R\fwarningCount\"\xfc\x01\n" +
+	"\x18DriverComplianceResponse\x126\n" +
+	"\adrivers\x18\x01 \x03(\v2\x1c.dtako_rows.DriverComplianceR\adrivers\x12?\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x1f.dtako_rows.ComplianceViolationR\n" +
+	"violations\x12O\n" +
+	"\x12applied_thresholds\x18\x03 \x01(\v2 .dtako_rows.ComplianceThresholdsR\x11appliedThresholds\x12\x16\n" +
+	"\x06period\x18\x04 \x01(\tR\x06period\"\xc8\x01\n" +
+	"\x13ValidateRowsRequest\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x12,\n" +
+	"\x12meter_tolerance_km\x18\x04 \x01(\x01R\x10meterToleranceKm\x122\n" +
+	"\x15distance_tolerance_km\x18\x05 \x01(\x01R\x13distanceToleranceKm\"\xa1\x01\n" +
+	"\x0fValidationIssue\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1a\n" +
+	"\bseverity\x18\x02 \x01(\tR\bseverity\x12\x15\n" +
+	"\x06car_cc\x18\x03 \x01(\tR\x05carCc\x12\x17\n" +
+	"\arow_ids\x18\x04 \x03(\tR\x06rowIds\x12\x14\n" +
+	"\x05field\x18\x05 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"\xf3\x01\n" +
+	"\x10ValidationReport\x123\n" +
+	"\x06issues\x18\x01 \x03(\v2\x1b.dtako_rows.ValidationIssueR\x06issues\x12!\n" +
+	"\frows_checked\x18\x02 \x01(\x05R\vrowsChecked\x12)\n" +
+	"\x10vehicles_checked\x18\x03 \x01(\x05R\x0fvehiclesChecked\x12\x1f\n" +
+	"\verror_count\x18\x04 \x01(\x05R\n" +
+	"errorCount\x12#\n" +
+	"\rwarning_count\x18\x05 \x01(\x05R\fwarningCount\x12\x16\n" +
+	"\x06period\x18\x06 \x01(\tR\x06period\"E\n" +
+	"\tDateRange\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\"\x99\x01\n" +
+	"\x1cCompareVehiclePeriodsRequest\x12/\n" +
+	"\acurrent\x18\x01 \x01(\v2\x15.dtako_rows.DateRangeR\acurrent\x121\n" +
+	"\bbaseline\x18\x02 \x01(\v2\x15.dtako_rows.DateRangeR\bbaseline\x12\x15\n" +
+	"\x06car_cc\x18\x03 \x01(\tR\x05carCc\"\x8d\x01\n" +
+	"\fPeriodTotals\x12%\n" +
+	"\x0etotal_distance\x18\x01 \x01(\x01R\rtotalDistance\x12\x1d\n" +
+	"\n" +
+	"total_fuel\x18\x02 \x01(\x01R\ttotalFuel\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\x03 \x01(\x05R\ttripCount\x12\x18\n" +
+	"\apresent\x18\x04 \x01(\bR\apresent\"T\n" +
+	"\vPeriodDelta\x12\x1a\n" +
+	"\babsolute\x18\x01 \x01(\x01R\babsolute\x12\x1d\n" +
+	"\apercent\x18\x02 \x01(\x01H\x00R\apercent\x88\x01\x01B\n" +
+	"\n" +
+	"\b_percent\"\xc7\x02\n" +
+	"\x17VehiclePeriodComparison\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1a\n" +
+	"\bpresence\x18\x02 \x01(\tR\bpresence\x122\n" +
+	"\acurrent\x18\x03 \x01(\v2\x18.dtako_rows.PeriodTotalsR\acurrent\x124\n" +
+	"\bbaseline\x18\x04 \x01(\v2\x18.dtako_rows.PeriodTotalsR\bbaseline\x123\n" +
+	"\bdistance\x18\x05 \x01(\v2\x17.dtako_rows.PeriodDeltaR\bdistance\x12+\n" +
+	"\x04fuel\x18\x06 \x01(\v2\x17.dtako_rows.PeriodDeltaR\x04fuel\x12-\n" +
+	"\x05trips\x18\a \x01(\v2\x17.dtako_rows.PeriodDeltaR\x05trips\"\xff\x01\n" +
+	"\x1dCompareVehiclePeriodsResponse\x12?\n" +
+	"\bvehicles\x18\x01 \x03(\v2#.dtako_rows.VehiclePeriodComparisonR\bvehicles\x129\n" +
+	"\x05fleet\x18\x02 \x01(\v2#.dtako_rows.VehiclePeriodComparisonR\x05fleet\x12/\n" +
+	"\acurrent\x18\x03 \x01(\v2\x15.dtako_rows.DateRangeR\acurrent\x121\n" +
+	"\bbaseline\x18\x04 \x01(\v2\x15.dtako_rows.DateRangeR\bbaseline\"\xc5\x01\n" +
+	"\x1eGetOfficeMonthlySummaryRequest\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12$\n" +
+	"\voffice_code\x18\x03 \x01(\x05H\x00R\n" +
+	"officeCode\x88\x01\x01\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketingB\x0e\n" +
+	"\f_office_code\"\xa7\x04\n" +
+	"\x13OfficePeriodSummary\x12\x1f\n" +
+	"\voffice_code\x18\x01 \x01(\tR\n" +
+	"officeCode\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12%\n" +
+	"\x0etotal_distance\x18\x03 \x01(\x01R\rtotalDistance\x12\x1d\n" +
+	"\n" +
+	"total_fuel\x18\x04 \x01(\x01R\ttotalFuel\x12#\n" +
+	"\rmeasured_fuel\x18\x05 \x01(\x01R\fmeasuredFuel\x12%\n" +
+	"\x0eestimated_fuel\x18\x06 \x01(\x01R\restimatedFuel\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\a \x01(\x05R\ttripCount\x12'\n" +
+	"\x0factive_vehicles\x18\b \x01(\x05R\x0eactiveVehicles\x127\n" +
+	"\x18avg_distance_per_vehicle\x18\t \x01(\x01R\x15avgDistancePerVehicle\x12/\n" +
+	"\x14avg_fuel_per_vehicle\x18\n" +
+	" \x01(\x01R\x11avgFuelPerVehicle\x121\n" +
+	"\x15avg_trips_per_vehicle\x18\v \x01(\x01R\x12avgTripsPerVehicle\x12.\n" +
+	"\x13avg_fuel_efficiency\x18\f \x01(\x01R\x11avgFuelEfficiency\x120\n" +
+	"\x06bucket\x18\r \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\"\xc1\x01\n" +
+	"\x0fOfficeSummaries\x12\x1f\n" +
+	"\voffice_code\x18\x01 \x01(\tR\n" +
+	"officeCode\x12=\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1f.dtako_rows.OfficePeriodSummaryR\tsummaries\x125\n" +
+	"\x05total\x18\x03 \x01(\v2\x1f.dtako_rows.OfficePeriodSummaryR\x05total\x12\x17\n" +
+	"\acar_ccs\x18\x04 \x03(\tR\x06carCcs\"\xc4\x01\n" +
+	"\x1cOfficeMonthlySummaryResponse\x125\n" +
+	"\aoffices\x18\x01 \x03(\v2\x1b.dtako_rows.OfficeSummariesR\aoffices\x12#\n" +
+	"\rtotal_offices\x18\x02 \x01(\x05R\ftotalOffices\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\x120\n" +
+	"\x14car_master_available\x18\x04 \x01(\bR\x12carMasterAvailable\"\x9d\x01\n" +
+	"\x15GetTollSummaryRequest\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\"\xc4\x02\n" +
+	"\bTripToll\x12\x15\n" +
+	"\x06row_id\x18\x01 \x01(\tR\x05rowId\x12!\n" +
+	"\foperation_no\x18\x02 \x01(\tR\voperationNo\x12\x15\n" +
+	"\x06car_cc\x18\x03 \x01(\tR\x05carCc\x12%\n" +
+	"\x0eoperation_date\x18\x04 \x01(\tR\roperationDate\x12%\n" +
+	"\x0etotal_distance\x18\x05 \x01(\x01R\rtotalDistance\x12\x1f\n" +
+	"\vtoll_amount\x18\x06 \x01(\x03R\n" +
+	"tollAmount\x12\x1d\n" +
+	"\n" +
+	"toll_count\x18\a \x01(\x05R\ttollCount\x12'\n" +
+	"\x0festimated_count\x18\b \x01(\x05R\x0eestimatedCount\x120\n" +
+	"\x06bucket\x18\t \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\"\xc6\x02\n" +
+	"\x11TollPeriodSummary\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12%\n" +
+	"\x0etotal_distance\x18\x03 \x01(\x01R\rtotalDistance\x12\x1f\n" +
+	"\vtoll_amount\x18\x04 \x01(\x03R\n" +
+	"tollAmount\x12\x1d\n" +
+	"\n" +
+	"toll_count\x18\x05 \x01(\x05R\ttollCount\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\x06 \x01(\x05R\ttripCount\x12*\n" +
+	"\x11tolled_trip_count\x18\a \x01(\x05R\x0ftolledTripCount\x12\x1e\n" +
+	"\vtoll_per_km\x18\b \x01(\x01R\ttollPerKm\x120\n" +
+	"\x06bucket\x18\t \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\"\x97\x01\n" +
+	"\fVehicleTolls\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12;\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1d.dtako_rows.TollPeriodSummaryR\tsummaries\x123\n" +
+	"\x05total\x18\x03 \x01(\v2\x1d.dtako_rows.TollPeriodSummaryR\x05total\"\xdc\x01\n" +
+	"\x12UnmatchedETCRecord\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x17\n" +
+	"\aused_at\x18\x02 \x01(\tR\x06usedAt\x12\x19\n" +
+	"\bentry_ic\x18\x03 \x01(\tR\aentryIc\x12\x17\n" +
+	"\aexit_ic\x18\x04 \x01(\tR\x06exitIc\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x05R\x05price\x12 \n" +
+	"\fetc_card_num\x18\x06 \x01(\tR\n" +
+	"etcCardNum\x12\x15\n" +
+	"\x06car_cc\x18\a \x01(\tR\x05carCc\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\"\x8b\x03\n" +
+	"\x13TollSummaryResponse\x12*\n" +
+	"\x05trips\x18\x01 \x03(\v2\x14.dtako_rows.TripTollR\x05trips\x124\n" +
+	"\bvehicles\x18\x02 \x03(\v2\x18.dtako_rows.VehicleTollsR\bvehicles\x127\n" +
+	"\aperiods\x18\x03 \x03(\v2\x1d.dtako_rows.TollPeriodSummaryR\aperiods\x123\n" +
+	"\x05total\x18\x04 \x01(\v2\x1d.dtako_rows.TollPeriodSummaryR\x05total\x12<\n" +
+	"\tunmatched\x18\x05 \x03(\v2\x1e.dtako_rows.UnmatchedETCRecordR\tunmatched\x12)\n" +
+	"\x10unmatched_amount\x18\x06 \x01(\x03R\x0funmatchedAmount\x12\x16\n" +
+	"\x06period\x18\a \x01(\tR\x06period\x12#\n" +
+	"\retc_available\x18\b \x01(\bR\fetcAvailable\"\x9e\x01\n" +
+	"\x16GetFerrySummaryRequest\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\"\xa0\x03\n" +
+	"\x12FerryPeriodSummary\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12\x1c\n" +
+	"\tcrossings\x18\x03 \x01(\x05R\tcrossings\x12\x1d\n" +
+	"\n" +
+	"ferry_cost\x18\x04 \x01(\x03R\tferryCost\x12#\n" +
+	"\rstandard_fare\x18\x05 \x01(\x03R\fstandardFare\x12'\n" +
+	"\x0fdeemed_distance\x18\x06 \x01(\x01R\x0edeemedDistance\x12%\n" +
+	"\x0etotal_distance\x18\a \x01(\x01R\rtotalDistance\x12.\n" +
+	"\x13distance_with_ferry\x18\b \x01(\x01R\x11distanceWithFerry\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\t \x01(\x05R\ttripCount\x12(\n" +
+	"\x10ferry_trip_count\x18\n" +
+	" \x01(\x05R\x0eferryTripCount\x120\n" +
+	"\x06bucket\x18\v \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\"\x9b\x01\n" +
+	"\x0eVehicleFerries\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12<\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1e.dtako_rows.FerryPeriodSummaryR\tsummaries\x124\n" +
+	"\x05total\x18\x03 \x01(\v2\x1e.dtako_rows.FerryPeriodSummaryR\x05total\"\xff\x01\n" +
+	"\x14FerrySummaryResponse\x126\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x1a.dtako_rows.VehicleFerriesR\bvehicles\x128\n" +
+	"\aperiods\x18\x02 \x03(\v2\x1e.dtako_rows.FerryPeriodSummaryR\aperiods\x124\n" +
+	"\x05total\x18\x03 \x01(\v2\x1e.dtako_rows.FerryPeriodSummaryR\x05total\x12\x16\n" +
+	"\x06period\x18\x04 \x01(\tR\x06period\x12'\n" +
+	"\x0fferry_available\x18\x05 \x01(\bR\x0eferryAvailable\"\xa2\x02\n" +
+	"\x1bGetTripProfitabilityRequest\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\x124\n" +
+	"\x14fuel_price_per_liter\x18\x05 \x01(\x01H\x00R\x11fuelPricePerLiter\x88\x01\x01\x12.\n" +
+	"\x13revenue_keihi_codes\x18\x06 \x03(\x05R\x11revenueKeihiCodesB\x17\n" +
+	"\x15_fuel_price_per_liter\"\x8f\x03\n" +
+	"\fProfitTotals\x12%\n" +
+	"\x0etotal_distance\x18\x01 \x01(\x01R\rtotalDistance\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\x02 \x01(\x05R\ttripCount\x12\x18\n" +
+	"\arevenue\x18\x03 \x01(\x01R\arevenue\x12\x1a\n" +
+	"\bexpenses\x18\x04 \x01(\x01R\bexpenses\x12\x1f\n" +
+	"\vfuel_liters\x18\x05 \x01(\x01R\n" +
+	"fuelLiters\x12\x1b\n" +
+	"\tfuel_cost\x18\x06 \x01(\x01R\bfuelCost\x12\x1b\n" +
+	"\ttoll_cost\x18\a \x01(\x01R\btollCost\x12\x1d\n" +
+	"\n" +
+	"ferry_cost\x18\b \x01(\x01R\tferryCost\x12\x1d\n" +
+	"\n" +
+	"total_cost\x18\t \x01(\x01R\ttotalCost\x12!\n" +
+	"\fgross_margin\x18\n" +
+	" \x01(\x01R\vgrossMargin\x12!\n" +
+	"\fmargin_ratio\x18\v \x01(\x01R\vmarginRatio\x12$\n" +
+	"\x0erevenue_per_km\x18\f \x01(\x01R\frevenuePerKm\"~\n" +
+	"\vKeihiAmount\x12\x17\n" +
+	"\akeihi_c\x18\x01 \x01(\x05R\x06keihiC\x12\x18\n" +
+	"\arevenue\x18\x02 \x01(\bR\arevenue\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x0e\n" +
+	"\x02km\x18\x04 \x01(\x01R\x02km\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x05R\x05count\"\x97\x02\n" +
+	"\n" +
+	"TripProfit\x12\x15\n" +
+	"\x06row_id\x18\x01 \x01(\tR\x05rowId\x12!\n" +
+	"\foperation_no\x18\x02 \x01(\tR\voperationNo\x12\x15\n" +
+	"\x06car_cc\x18\x03 \x01(\tR\x05carCc\x12%\n" +
+	"\x0eoperation_date\x18\x04 \x01(\tR\roperationDate\x120\n" +
+	"\x06bucket\x18\x05 \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\x120\n" +
+	"\x06totals\x18\x06 \x01(\v2\x18.dtako_rows.ProfitTotalsR\x06totals\x12-\n" +
+	"\x05lines\x18\a \x03(\v2\x17.dtako_rows.KeihiAmountR\x05lines\"\xa8\x01\n" +
+	"\x13ProfitPeriodSummary\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x120\n" +
+	"\x06bucket\x18\x03 \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\x120\n" +
+	"\x06totals\x18\x04 \x01(\v2\x18.dtako_rows.ProfitTotalsR\x06totals\"\x9c\x01\n" +
+	"\rVehicleProfit\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12=\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1f.dtako_rows.ProfitPeriodSummaryR\tsummaries\x125\n" +
+	"\x05total\x18\x03 \x01(\v2\x1f.dtako_rows.ProfitPeriodSummaryR\x05total\"\x94\x04\n" +
+	"\x19TripProfitabilityResponse\x12,\n" +
+	"\x05trips\x18\x01 \x03(\v2\x16.dtako_rows.TripProfitR\x05trips\x125\n" +
+	"\bvehicles\x18\x02 \x03(\v2\x19.dtako_rows.VehicleProfitR\bvehicles\x129\n" +
+	"\aperiods\x18\x03 \x03(\v2\x1f.dtako_rows.ProfitPeriodSummaryR\aperiods\x125\n" +
+	"\x05total\x18\x04 \x01(\v2\x1f.dtako_rows.ProfitPeriodSummaryR\x05total\x12\x16\n" +
+	"\x06period\x18\x05 \x01(\tR\x06period\x12/\n" +
+	"\x14fuel_price_per_liter\x18\x06 \x01(\x01R\x11fuelPricePerLiter\x12.\n" +
+	"\x13revenue_keihi_codes\x18\a \x03(\x05R\x11revenueKeihiCodes\x120\n" +
+	"\x14unlinked_keihi_count\x18\b \x01(\x05R\x12unlinkedKeihiCount\x12'\n" +
+	"\x0fkeihi_available\x18\t \x01(\bR\x0ekeihiAvailable\x12#\n" +
+	"\retc_available\x18\n" +
+	" \x01(\bR\fetcAvailable\x12'\n" +
+	"\x0fferry_available\x18\v \x01(\bR\x0eferryAvailable\"\xeb\x01\n" +
+	"\x0fEventCodeTotals\x12\x1d\n" +
+	"\n" +
+	"event_code\x18\x01 \x01(\x05R\teventCode\x12\x1d\n" +
+	"\n" +
+	"event_name\x18\x02 \x01(\tR\teventName\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x03R\x0fdurationSeconds\x12!\n" +
+	"\fsection_time\x18\x06 \x01(\x03R\vsectionTime\x12\x1a\n" +
+	"\bdistance\x18\a \x01(\x01R\bdistance\"\x8e\x01\n" +
+	"\x13EventCategoryTotals\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03R\x0fdurationSeconds\x12\x1a\n" +
+	"\bdistance\x18\x04 \x01(\x01R\bdistance\"\xeb\x01\n" +
+	"\x0eEventBreakdown\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\x01 \x01(\x05R\ttripCount\x12\x1f\n" +
+	"\vevent_count\x18\x02 \x01(\x05R\n" +
+	"eventCount\x121\n" +
+	"\x05codes\x18\x03 \x03(\v2\x1b.dtako_rows.EventCodeTotalsR\x05codes\x12?\n" +
+	"\n" +
+	"categories\x18\x04 \x03(\v2\x1f.dtako_rows.EventCategoryTotalsR\n" +
+	"categories\x12%\n" +
+	"\x0eidling_seconds\x18\x05 \x01(\x03R\ridlingSeconds\"?\n" +
+	"\x1aGetTripEventSummaryRequest\x12!\n" +
+	"\foperation_no\x18\x01 \x01(\tR\voperationNo\"\xde\x02\n" +
+	"\x18TripEventSummaryResponse\x12!\n" +
+	"\foperation_no\x18\x01 \x01(\tR\voperationNo\x12\x15\n" +
+	"\x06car_cc\x18\x02 \x01(\tR\x05carCc\x12!\n" +
+	"\fdriver_codes\x18\x03 \x03(\x05R\vdriverCodes\x12\x1f\n" +
+	"\vfirst_start\x18\x04 \x01(\tR\n" +
+	"firstStart\x12\x19\n" +
+	"\blast_end\x18\x05 \x01(\tR\alastEnd\x12!\n" +
+	"\fspan_seconds\x18\x06 \x01(\x03R\vspanSeconds\x12!\n" +
+	"\fidling_ratio\x18\a \x01(\x01R\vidlingRatio\x128\n" +
+	"\tbreakdown\x18\b \x01(\v2\x1a.dtako_rows.EventBreakdownR\tbreakdown\x12)\n" +
+	"\x10events_available\x18\t \x01(\bR\x0feventsAvailable\"\x9e\x01\n" +
+	"\x16GetEventSummaryRequest\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x03 \x01(\tR\aendDate\x123\n" +
+	"\tbucketing\x18\x04 \x01(\v2\x15.dtako_rows.BucketingR\tbucketing\"\xaf\x01\n" +
+	"\x12EventPeriodSummary\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x120\n" +
+	"\x06bucket\x18\x03 \x01(\v2\x18.dtako_rows.PeriodBucketR\x06bucket\x128\n" +
+	"\tbreakdown\x18\x04 \x01(\v2\x1a.dtako_rows.EventBreakdownR\tbreakdown\"\x9a\x01\n" +
+	"\rVehicleEvents\x12\x15\n" +
+	"\x06car_cc\x18\x01 \x01(\tR\x05carCc\x12<\n" +
+	"\tsummaries\x18\x02 \x03(\v2\x1e.dtako_rows.EventPeriodSummaryR\tsummaries\x124\n" +
+	"\x05total\x18\x03 \x01(\v2\x1e.dtako_rows.EventPeriodSummaryR\x05total\"i\n" +
+	"\fDriverEvents\x12\x1f\n" +
+	"\vdriver_code\x18\x01 \x01(\x05R\n" +
+	"driverCode\x128\n" +
+	"\tbreakdown\x18\x02 \x01(\v2\x1a.dtako_rows.EventBreakdownR\tbreakdown\"\xb4\x02\n" +
+	"\x14EventSummaryResponse\x125\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x19.dtako_rows.VehicleEventsR\bvehicles\x122\n" +
+	"\adrivers\x18\x02 \x03(\v2\x18.dtako_rows.DriverEventsR\adrivers\x128\n" +
+	"\aperiods\x18\x03 \x03(\v2\x1e.dtako_rows.EventPeriodSummaryR\aperiods\x124\n" +
+	"\x05total\x18\x04 \x01(\v2\x1e.dtako_rows.EventPeriodSummaryR\x05total\x12\x16\n" +
+	"\x06period\x18\x05 \x01(\tR\x06period\x12)\n" +
+	"\x10events_available\x18\x06 \x01(\bR\x0feventsAvailable\"8\n" +
+	"\x13GetTripRouteRequest\x12!\n" +
+	"\foperation_no\x18\x01 \x01(\tR\voperationNo\"\x82\x02\n" +
+	"\n" +
+	"RoutePoint\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\tR\x04time\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1d\n" +
+	"\n" +
+	"place_name\x18\x04 \x01(\tR\tplaceName\x12\x1b\n" +
+	"\tcity_name\x18\x05 \x01(\tR\bcityName\x12\x1d\n" +
+	"\n" +
+	"event_code\x18\x06 \x01(\x05R\teventCode\x12\x1d\n" +
+	"\n" +
+	"event_name\x18\a \x01(\tR\teventName\x12\x12\n" +
+	"\x04edge\x18\b \x01(\tR\x04edge\x12\x18\n" +
+	"\amileage\x18\t \x01(\x01R\amileage\"\x8a\x02\n" +
+	"\x11TripRouteResponse\x12!\n" +
+	"\foperation_no\x18\x01 \x01(\tR\voperationNo\x12\x15\n" +
+	"\x06car_cc\x18\x02 \x01(\tR\x05carCc\x12.\n" +
+	"\x06points\x18\x03 \x03(\v2\x16.dtako_rows.RoutePointR\x06points\x12\x1f\n" +
+	"\vevent_count\x18\x04 \x01(\x05R\n" +
+	"eventCount\x12#\n" +
+	"\rdropped_count\x18\x05 \x01(\x05R\fdroppedCount\x12\x1a\n" +
+	"\bdistance\x18\x06 \x01(\x01R\bdistance\x12)\n" +
+	"\x10events_available\x18\a \x01(\bR\x0feventsAvailable\"\x16\n" +
+	"\x14GetCacheStatsRequest\"g\n" +
+	"\rRPCCacheStats\x12\x10\n" +
+	"\x03rpc\x18\x01 \x01(\tR\x03rpc\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x03R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x03R\x06misses\x12\x18\n" +
+	"\aentries\x18\x04 \x01(\x05R\aentries\"\xfe\x02\n" +
+	"\x12CacheStatsResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x03R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x03R\x06misses\x12\x1b\n" +
+	"\thit_ratio\x18\x04 \x01(\x01R\bhitRatio\x12\x18\n" +
+	"\aentries\x18\x05 \x01(\x05R\aentries\x12$\n" +
+	"\rinvalidations\x18\x06 \x01(\x03R\rinvalidations\x12 \n" +
+	"\vexpirations\x18\a \x01(\x03R\vexpirations\x12\x1c\n" +
+	"\tevictions\x18\b \x01(\x03R\tevictions\x12.\n" +
+	"\x13read_date_watermark\x18\t \x01(\tR\x11readDateWatermark\x12&\n" +
+	"\x0flast_checked_at\x18\n" +
+	" \x01(\tR\rlastCheckedAt\x12-\n" +
+	"\x04rpcs\x18\v \x03(\v2\x19.dtako_rows.RPCCacheStatsR\x04rpcs\"E\n" +
+	"\rExportOptions\x12\x1a\n" +
+	"\bencoding\x18\x01 \x01(\tR\bencoding\x12\x18\n" +
+	"\acolumns\x18\x02 \x03(\tR\acolumns\"g\n" +
+	"\x12ExportFileResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType2\xbf\x13\n" +
 	"\x10DtakoRowsService\x12u\n" +
 	"\x19GetMonthlyFuelConsumption\x12,.dtako_rows.GetMonthlyFuelConsumptionRequest\x1a*.dtako_rows.MonthlyFuelConsumptionResponse\x12r\n" +
 	"\x18GetVehicleMonthlySummary\x12+.dtako_rows.GetVehicleMonthlySummaryRequest\x1a).dtako_rows.VehicleMonthlySummaryResponse\x12W\n" +
 	"\x0fGetDailySummary\x12\".dtako_rows.GetDailySummaryRequest\x1a .dtako_rows.DailySummaryResponse\x12c\n" +
-	"\x14ExportMonthlyFuelCSV\x12,.dtako_rows.GetMonthlyFuelConsumptionRequest\x1a\x1d.dtako_rows.ExportCSVResponse\x12<\n" +
+	"\x14ExportMonthlyFuelCSV\x12,.dtako_rows.GetMonthlyFuelConsumptionRequest\x1a\x1d.dtako_rows.ExportCSVResponse\x12e\n" +
+	"\x15ExportMonthlyFuelXLSX\x12,.dtako_rows.GetMonthlyFuelConsumptionRequest\x1a\x1e.dtako_rows.ExportFileResponse\x12n\n" +
+	"\x1fExportVehicleMonthlySummaryXLSX\x12+.dtako_rows.GetVehicleMonthlySummaryRequest\x1a\x1e.dtako_rows.ExportFileResponse\x12<\n" +
 	"\x06GetRow\x12\x19.dtako_rows.GetRowRequest\x1a\x17.dtako_rows.RowResponse\x12E\n" +
-	"\bListRows\x12\x1b.dtako_rows.ListRowsRequest\x1a\x1c.dtako_rows.ListRowsResponseB\x9d\x01\n" +
+	"\bListRows\x12\x1b.dtako_rows.ListRowsRequest\x1a\x1c.dtako_rows.ListRowsResponse\x12q\n" +
+	"\x1bStreamVehicleMonthlySummary\x12+.dtako_rows.GetVehicleMonthlySummaryRequest\x1a#.dtako_rows.VehicleMonthlySummaries0\x01\x12C\n" +
+	"\n" +
+	"StreamRows\x12\x1d.dtako_rows.StreamRowsRequest\x1a\x14.dtako_rows.RowBatch0\x01\x12a\n" +
+	"\x17GetDriverMonthlySummary\x12#.dtako_rows.GetDriverSummaryRequest\x1a!.dtako_rows.DriverSummaryResponse\x12_\n" +
+	"\x15GetDriverDailySummary\x12#.dtako_rows.GetDriverSummaryRequest\x1a!.dtako_rows.DriverSummaryResponse\x12i\n" +
+	"\x15GetLoadedRatioSummary\x12(.dtako_rows.GetLoadedRatioSummaryRequest\x1a&.dtako_rows.LoadedRatioSummaryResponse\x12g\n" +
+	"\x15CheckDriverCompliance\x12(.dtako_rows.CheckDriverComplianceRequest\x1a$.dtako_rows.DriverComplianceResponse\x12M\n" +
+	"\fValidateRows\x12\x1f.dtako_rows.ValidateRowsRequest\x1a\x1c.dtako_rows.ValidationReport\x12Q\n" +
+	"\rGetCacheStats\x12 .dtako_rows.GetCacheStatsRequest\x1a\x1e.dtako_rows.CacheStatsResponse\x12l\n" +
+	"\x15CompareVehiclePeriods\x12(.dtako_rows.CompareVehiclePeriodsRequest\x1a).dtako_rows.CompareVehiclePeriodsResponse\x12o\n" +
+	"\x17GetOfficeMonthlySummary\x12*.dtako_rows.GetOfficeMonthlySummaryRequest\x1a(.dtako_rows.OfficeMonthlySummaryResponse\x12T\n" +
+	"\x0eGetTollSummary\x12!.dtako_rows.GetTollSummaryRequest\x1a\x1f.dtako_rows.TollSummaryResponse\x12W\n" +
+	"\x0fGetFerrySummary\x12\".dtako_rows.GetFerrySummaryRequest\x1a .dtako_rows.FerrySummaryResponse\x12f\n" +
+	"\x14GetTripProfitability\x12'.dtako_rows.GetTripProfitabilityRequest\x1a%.dtako_rows.TripProfitabilityResponse\x12c\n" +
+	"\x13GetTripEventSummary\x12&.dtako_rows.GetTripEventSummaryRequest\x1a$.dtako_rows.TripEventSummaryResponse\x12W\n" +
+	"\x0fGetEventSummary\x12\".dtako_rows.GetEventSummaryRequest\x1a .dtako_rows.EventSummaryResponse\x12N\n" +
+	"\fGetTripRoute\x12\x1f.dtako_rows.GetTripRouteRequest\x1a\x1d.dtako_rows.TripRouteResponse\x12Y\n" +
+	"\x16ExportTripRouteGeoJSON\x12\x1f.dtako_rows.GetTripRouteRequest\x1a\x1e.dtako_rows.ExportFileResponse\x12U\n" +
+	"\x12ExportTripRouteGPX\x12\x1f.dtako_rows.GetTripRouteRequest\x1a\x1e.dtako_rows.ExportFileResponseB\x9d\x01\n" +
 	"\x0ecom.dtako_rowsB\x0eDtakoRowsProtoP\x01Z7github.com/yhonda-ohishi/dtako_rows/v3/proto;dtako_rows\xa2\x02\x03DXX\xaa\x02\tDtakoRows\xca\x02\tDtakoRows\xe2\x02\x15DtakoRows\\GPBMetadata\xea\x02\tDtakoRowsb\x06proto3"
 
 var (
@@ -1137,48 +7615,245 @@ func file_dtako_rows_proto_rawDescGZIP() []byte {
 	return file_dtako_rows_proto_rawDescData
 }
 
-var file_dtako_rows_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_dtako_rows_proto_msgTypes = make([]protoimpl.MessageInfo, 84)
 var file_dtako_rows_proto_goTypes = []any{
-	(*MonthlyFuelSummary)(nil),               // 0: dtako_rows.MonthlyFuelSummary
-	(*GetMonthlyFuelConsumptionRequest)(nil), // 1: dtako_rows.GetMonthlyFuelConsumptionRequest
-	(*MonthlyFuelConsumptionResponse)(nil),   // 2: dtako_rows.MonthlyFuelConsumptionResponse
-	(*GetVehicleMonthlySummaryRequest)(nil),  // 3: dtako_rows.GetVehicleMonthlySummaryRequest
-	(*VehicleMonthlySummaries)(nil),          // 4: dtako_rows.VehicleMonthlySummaries
-	(*VehicleMonthlySummaryResponse)(nil),    // 5: dtako_rows.VehicleMonthlySummaryResponse
-	(*GetDailySummaryRequest)(nil),           // 6: dtako_rows.GetDailySummaryRequest
-	(*DailySummary)(nil),                     // 7: dtako_rows.DailySummary
-	(*DailySummaryResponse)(nil),             // 8: dtako_rows.DailySummaryResponse
-	(*ExportCSVResponse)(nil),                // 9: dtako_rows.ExportCSVResponse
-	(*GetRowRequest)(nil),                    // 10: dtako_rows.GetRowRequest
-	(*RowResponse)(nil),                      // 11: dtako_rows.RowResponse
-	(*ListRowsRequest)(nil),                  // 12: dtako_rows.ListRowsRequest
-	(*ListRowsResponse)(nil),                 // 13: dtako_rows.ListRowsResponse
-	(*Row)(nil),                              // 14: dtako_rows.Row
+	(*Bucketing)(nil),                        // 0: dtako_rows.Bucketing
+	(*PeriodBucket)(nil),                     // 1: dtako_rows.PeriodBucket
+	(*MonthlyFuelSummary)(nil),               // 2: dtako_rows.MonthlyFuelSummary
+	(*GetMonthlyFuelConsumptionRequest)(nil), // 3: dtako_rows.GetMonthlyFuelConsumptionRequest
+	(*MonthlyFuelConsumptionResponse)(nil),   // 4: dtako_rows.MonthlyFuelConsumptionResponse
+	(*GetVehicleMonthlySummaryRequest)(nil),  // 5: dtako_rows.GetVehicleMonthlySummaryRequest
+	(*SummaryTotals)(nil),                    // 6: dtako_rows.SummaryTotals
+	(*VehicleMonthlySummaries)(nil),          // 7: dtako_rows.VehicleMonthlySummaries
+	(*RankedVehicle)(nil),                    // 8: dtako_rows.RankedVehicle
+	(*VehicleRanking)(nil),                   // 9: dtako_rows.VehicleRanking
+	(*VehicleMonthlySummaryResponse)(nil),    // 10: dtako_rows.VehicleMonthlySummaryResponse
+	(*GetDailySummaryRequest)(nil),           // 11: dtako_rows.GetDailySummaryRequest
+	(*DailySummary)(nil),                     // 12: dtako_rows.DailySummary
+	(*DailySummaryResponse)(nil),             // 13: dtako_rows.DailySummaryResponse
+	(*ExportCSVResponse)(nil),                // 14: dtako_rows.ExportCSVResponse
+	(*GetRowRequest)(nil),                    // 15: dtako_rows.GetRowRequest
+	(*RowResponse)(nil),                      // 16: dtako_rows.RowResponse
+	(*ListRowsRequest)(nil),                  // 17: dtako_rows.ListRowsRequest
+	(*ListRowsResponse)(nil),                 // 18: dtako_rows.ListRowsResponse
+	(*Row)(nil),                              // 19: dtako_rows.Row
+	(*StreamRowsRequest)(nil),                // 20: dtako_rows.StreamRowsRequest
+	(*RowBatch)(nil),                         // 21: dtako_rows.RowBatch
+	(*GetDriverSummaryRequest)(nil),          // 22: dtako_rows.GetDriverSummaryRequest
+	(*DriverPeriodSummary)(nil),              // 23: dtako_rows.DriverPeriodSummary
+	(*DriverSummaries)(nil),                  // 24: dtako_rows.DriverSummaries
+	(*DriverSummaryResponse)(nil),            // 25: dtako_rows.DriverSummaryResponse
+	(*GetLoadedRatioSummaryRequest)(nil),     // 26: dtako_rows.GetLoadedRatioSummaryRequest
+	(*LoadedRatioSummary)(nil),               // 27: dtako_rows.LoadedRatioSummary
+	(*VehicleLoadedRatio)(nil),               // 28: dtako_rows.VehicleLoadedRatio
+	(*LoadedRatioSummaryResponse)(nil),       // 29: dtako_rows.LoadedRatioSummaryResponse
+	(*ComplianceThresholds)(nil),             // 30: dtako_rows.ComplianceThresholds
+	(*CheckDriverComplianceRequest)(nil),     // 31: dtako_rows.CheckDriverComplianceRequest
+	(*ComplianceViolation)(nil),              // 32: dtako_rows.ComplianceViolation
+	(*DriverMonthlyCompliance)(nil),          // 33: dtako_rows.DriverMonthlyCompliance
+	(*DriverCompliance)(nil),                 // 34: dtako_rows.DriverCompliance
+	(*DriverComplianceResponse)(nil),         // 35: dtako_rows.DriverComplianceResponse
+	(*ValidateRowsRequest)(nil),              // 36: dtako_rows.ValidateRowsRequest
+	(*ValidationIssue)(nil),                  // 37: dtako_rows.ValidationIssue
+	(*ValidationReport)(nil),                 // 38: dtako_rows.ValidationReport
+	(*DateRange)(nil),                        // 39: dtako_rows.DateRange
+	(*CompareVehiclePeriodsRequest)(nil),     // 40: dtako_rows.CompareVehiclePeriodsRequest
+	(*PeriodTotals)(nil),                     // 41: dtako_rows.PeriodTotals
+	(*PeriodDelta)(nil),                      // 42: dtako_rows.PeriodDelta
+	(*VehiclePeriodComparison)(nil),          // 43: dtako_rows.VehiclePeriodComparison
+	(*CompareVehiclePeriodsResponse)(nil),    // 44: dtako_rows.CompareVehiclePeriodsResponse
+	(*GetOfficeMonthlySummaryRequest)(nil),   // 45: dtako_rows.GetOfficeMonthlySummaryRequest
+	(*OfficePeriodSummary)(nil),              // 46: dtako_rows.OfficePeriodSummary
+	(*OfficeSummaries)(nil),                  // 47: dtako_rows.OfficeSummaries
+	(*OfficeMonthlySummaryResponse)(nil),     // 48: dtako_rows.OfficeMonthlySummaryResponse
+	(*GetTollSummaryRequest)(nil),            // 49: dtako_rows.GetTollSummaryRequest
+	(*TripToll)(nil),                         // 50: dtako_rows.TripToll
+	(*TollPeriodSummary)(nil),                // 51: dtako_rows.TollPeriodSummary
+	(*VehicleTolls)(nil),                     // 52: dtako_rows.VehicleTolls
+	(*UnmatchedETCRecord)(nil),               // 53: dtako_rows.UnmatchedETCRecord
+	(*TollSummaryResponse)(nil),              // 54: dtako_rows.TollSummaryResponse
+	(*GetFerrySummaryRequest)(nil),           // 55: dtako_rows.GetFerrySummaryRequest
+	(*FerryPeriodSummary)(nil),               // 56: dtako_rows.FerryPeriodSummary
+	(*VehicleFerries)(nil),                   // 57: dtako_rows.VehicleFerries
+	(*FerrySummaryResponse)(nil),             // 58: dtako_rows.FerrySummaryResponse
+	(*GetTripProfitabilityRequest)(nil),      // 59: dtako_rows.GetTripProfitabilityRequest
+	(*ProfitTotals)(nil),                     // 60: dtako_rows.ProfitTotals
+	(*KeihiAmount)(nil),                      // 61: dtako_rows.KeihiAmount
+	(*TripProfit)(nil),                       // 62: dtako_rows.TripProfit
+	(*ProfitPeriodSummary)(nil),              // 63: dtako_rows.ProfitPeriodSummary
+	(*VehicleProfit)(nil),                    // 64: dtako_rows.VehicleProfit
+	(*TripProfitabilityResponse)(nil),        // 65: dtako_rows.TripProfitabilityResponse
+	(*EventCodeTotals)(nil),                  // 66: dtako_rows.EventCodeTotals
+	(*EventCategoryTotals)(nil),              // 67: dtako_rows.EventCategoryTotals
+	(*EventBreakdown)(nil),                   // 68: dtako_rows.EventBreakdown
+	(*GetTripEventSummaryRequest)(nil),       // 69: dtako_rows.GetTripEventSummaryRequest
+	(*TripEventSummaryResponse)(nil),         // 70: dtako_rows.TripEventSummaryResponse
+	(*GetEventSummaryRequest)(nil),           // 71: dtako_rows.GetEventSummaryRequest
+	(*EventPeriodSummary)(nil),               // 72: dtako_rows.EventPeriodSummary
+	(*VehicleEvents)(nil),                    // 73: dtako_rows.VehicleEvents
+	(*DriverEvents)(nil),                     // 74: dtako_rows.DriverEvents
+	(*EventSummaryResponse)(nil),             // 75: dtako_rows.EventSummaryResponse
+	(*GetTripRouteRequest)(nil),              // 76: dtako_rows.GetTripRouteRequest
+	(*RoutePoint)(nil),                       // 77: dtako_rows.RoutePoint
+	(*TripRouteResponse)(nil),                // 78: dtako_rows.TripRouteResponse
+	(*GetCacheStatsRequest)(nil),             // 79: dtako_rows.GetCacheStatsRequest
+	(*RPCCacheStats)(nil),                    // 80: dtako_rows.RPCCacheStats
+	(*CacheStatsResponse)(nil),               // 81: dtako_rows.CacheStatsResponse
+	(*ExportOptions)(nil),                    // 82: dtako_rows.ExportOptions
+	(*ExportFileResponse)(nil),               // 83: dtako_rows.ExportFileResponse
 }
 var file_dtako_rows_proto_depIdxs = []int32{
-	0,  // 0: dtako_rows.MonthlyFuelConsumptionResponse.summaries:type_name -> dtako_rows.MonthlyFuelSummary
-	0,  // 1: dtako_rows.VehicleMonthlySummaries.summaries:type_name -> dtako_rows.MonthlyFuelSummary
-	4,  // 2: dtako_rows.VehicleMonthlySummaryResponse.vehicle_summaries:type_name -> dtako_rows.VehicleMonthlySummaries
-	7,  // 3: dtako_rows.DailySummaryResponse.summaries:type_name -> dtako_rows.DailySummary
-	14, // 4: dtako_rows.RowResponse.row:type_name -> dtako_rows.Row
-	14, // 5: dtako_rows.ListRowsResponse.rows:type_name -> dtako_rows.Row
-	1,  // 6: dtako_rows.DtakoRowsService.GetMonthlyFuelConsumption:input_type -> dtako_rows.GetMonthlyFuelConsumptionRequest
-	3,  // 7: dtako_rows.DtakoRowsService.GetVehicleMonthlySummary:input_type -> dtako_rows.GetVehicleMonthlySummaryRequest
-	6,  // 8: dtako_rows.DtakoRowsService.GetDailySummary:input_type -> dtako_rows.GetDailySummaryRequest
-	1,  // 9: dtako_rows.DtakoRowsService.ExportMonthlyFuelCSV:input_type -> dtako_rows.GetMonthlyFuelConsumptionRequest
-	10, // 10: dtako_rows.DtakoRowsService.GetRow:input_type -> dtako_rows.GetRowRequest
-	12, // 11: dtako_rows.DtakoRowsService.ListRows:input_type -> dtako_rows.ListRowsRequest
-	2,  // 12: dtako_rows.DtakoRowsService.GetMonthlyFuelConsumption:output_type -> dtako_rows.MonthlyFuelConsumptionResponse
-	5,  // 13: dtako_rows.DtakoRowsService.GetVehicleMonthlySummary:output_type -> dtako_rows.VehicleMonthlySummaryResponse
-	8,  // 14: dtako_rows.DtakoRowsService.GetDailySummary:output_type -> dtako_rows.DailySummaryResponse
-	9,  // 15: dtako_rows.DtakoRowsService.ExportMonthlyFuelCSV:output_type -> dtako_rows.ExportCSVResponse
-	11, // 16: dtako_rows.DtakoRowsService.GetRow:output_type -> dtako_rows.RowResponse
-	13, // 17: dtako_rows.DtakoRowsService.ListRows:output_type -> dtako_rows.ListRowsResponse
-	12, // [12:18] is the sub-list for method output_type
-	6,  // [6:12] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	1,   // 0: dtako_rows.MonthlyFuelSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	82,  // 1: dtako_rows.GetMonthlyFuelConsumptionRequest.export_options:type_name -> dtako_rows.ExportOptions
+	0,   // 2: dtako_rows.GetMonthlyFuelConsumptionRequest.bucketing:type_name -> dtako_rows.Bucketing
+	2,   // 3: dtako_rows.MonthlyFuelConsumptionResponse.summaries:type_name -> dtako_rows.MonthlyFuelSummary
+	82,  // 4: dtako_rows.GetVehicleMonthlySummaryRequest.export_options:type_name -> dtako_rows.ExportOptions
+	0,   // 5: dtako_rows.GetVehicleMonthlySummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	2,   // 6: dtako_rows.VehicleMonthlySummaries.summaries:type_name -> dtako_rows.MonthlyFuelSummary
+	6,   // 7: dtako_rows.VehicleMonthlySummaries.totals:type_name -> dtako_rows.SummaryTotals
+	8,   // 8: dtako_rows.VehicleRanking.top:type_name -> dtako_rows.RankedVehicle
+	8,   // 9: dtako_rows.VehicleRanking.bottom:type_name -> dtako_rows.RankedVehicle
+	7,   // 10: dtako_rows.VehicleMonthlySummaryResponse.vehicle_summaries:type_name -> dtako_rows.VehicleMonthlySummaries
+	2,   // 11: dtako_rows.VehicleMonthlySummaryResponse.fleet_summaries:type_name -> dtako_rows.MonthlyFuelSummary
+	6,   // 12: dtako_rows.VehicleMonthlySummaryResponse.fleet_totals:type_name -> dtako_rows.SummaryTotals
+	9,   // 13: dtako_rows.VehicleMonthlySummaryResponse.rankings:type_name -> dtako_rows.VehicleRanking
+	0,   // 14: dtako_rows.GetDailySummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 15: dtako_rows.DailySummary.bucket:type_name -> dtako_rows.PeriodBucket
+	12,  // 16: dtako_rows.DailySummaryResponse.summaries:type_name -> dtako_rows.DailySummary
+	19,  // 17: dtako_rows.RowResponse.row:type_name -> dtako_rows.Row
+	19,  // 18: dtako_rows.ListRowsResponse.rows:type_name -> dtako_rows.Row
+	19,  // 19: dtako_rows.RowBatch.rows:type_name -> dtako_rows.Row
+	0,   // 20: dtako_rows.GetDriverSummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 21: dtako_rows.DriverPeriodSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	23,  // 22: dtako_rows.DriverSummaries.summaries:type_name -> dtako_rows.DriverPeriodSummary
+	24,  // 23: dtako_rows.DriverSummaryResponse.driver_summaries:type_name -> dtako_rows.DriverSummaries
+	0,   // 24: dtako_rows.GetLoadedRatioSummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 25: dtako_rows.LoadedRatioSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	27,  // 26: dtako_rows.VehicleLoadedRatio.summaries:type_name -> dtako_rows.LoadedRatioSummary
+	28,  // 27: dtako_rows.LoadedRatioSummaryResponse.vehicles:type_name -> dtako_rows.VehicleLoadedRatio
+	30,  // 28: dtako_rows.CheckDriverComplianceRequest.thresholds:type_name -> dtako_rows.ComplianceThresholds
+	33,  // 29: dtako_rows.DriverCompliance.monthly:type_name -> dtako_rows.DriverMonthlyCompliance
+	34,  // 30: dtako_rows.DriverComplianceResponse.drivers:type_name -> dtako_rows.DriverCompliance
+	32,  // 31: dtako_rows.DriverComplianceResponse.violations:type_name -> dtako_rows.ComplianceViolation
+	30,  // 32: dtako_rows.DriverComplianceResponse.applied_thresholds:type_name -> dtako_rows.ComplianceThresholds
+	37,  // 33: dtako_rows.ValidationReport.issues:type_name -> dtako_rows.ValidationIssue
+	39,  // 34: dtako_rows.CompareVehiclePeriodsRequest.current:type_name -> dtako_rows.DateRange
+	39,  // 35: dtako_rows.CompareVehiclePeriodsRequest.baseline:type_name -> dtako_rows.DateRange
+	41,  // 36: dtako_rows.VehiclePeriodComparison.current:type_name -> dtako_rows.PeriodTotals
+	41,  // 37: dtako_rows.VehiclePeriodComparison.baseline:type_name -> dtako_rows.PeriodTotals
+	42,  // 38: dtako_rows.VehiclePeriodComparison.distance:type_name -> dtako_rows.PeriodDelta
+	42,  // 39: dtako_rows.VehiclePeriodComparison.fuel:type_name -> dtako_rows.PeriodDelta
+	42,  // 40: dtako_rows.VehiclePeriodComparison.trips:type_name -> dtako_rows.PeriodDelta
+	43,  // 41: dtako_rows.CompareVehiclePeriodsResponse.vehicles:type_name -> dtako_rows.VehiclePeriodComparison
+	43,  // 42: dtako_rows.CompareVehiclePeriodsResponse.fleet:type_name -> dtako_rows.VehiclePeriodComparison
+	39,  // 43: dtako_rows.CompareVehiclePeriodsResponse.current:type_name -> dtako_rows.DateRange
+	39,  // 44: dtako_rows.CompareVehiclePeriodsResponse.baseline:type_name -> dtako_rows.DateRange
+	0,   // 45: dtako_rows.GetOfficeMonthlySummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 46: dtako_rows.OfficePeriodSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	46,  // 47: dtako_rows.OfficeSummaries.summaries:type_name -> dtako_rows.OfficePeriodSummary
+	46,  // 48: dtako_rows.OfficeSummaries.total:type_name -> dtako_rows.OfficePeriodSummary
+	47,  // 49: dtako_rows.OfficeMonthlySummaryResponse.offices:type_name -> dtako_rows.OfficeSummaries
+	0,   // 50: dtako_rows.GetTollSummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 51: dtako_rows.TripToll.bucket:type_name -> dtako_rows.PeriodBucket
+	1,   // 52: dtako_rows.TollPeriodSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	51,  // 53: dtako_rows.VehicleTolls.summaries:type_name -> dtako_rows.TollPeriodSummary
+	51,  // 54: dtako_rows.VehicleTolls.total:type_name -> dtako_rows.TollPeriodSummary
+	50,  // 55: dtako_rows.TollSummaryResponse.trips:type_name -> dtako_rows.TripToll
+	52,  // 56: dtako_rows.TollSummaryResponse.vehicles:type_name -> dtako_rows.VehicleTolls
+	51,  // 57: dtako_rows.TollSummaryResponse.periods:type_name -> dtako_rows.TollPeriodSummary
+	51,  // 58: dtako_rows.TollSummaryResponse.total:type_name -> dtako_rows.TollPeriodSummary
+	53,  // 59: dtako_rows.TollSummaryResponse.unmatched:type_name -> dtako_rows.UnmatchedETCRecord
+	0,   // 60: dtako_rows.GetFerrySummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 61: dtako_rows.FerryPeriodSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	56,  // 62: dtako_rows.VehicleFerries.summaries:type_name -> dtako_rows.FerryPeriodSummary
+	56,  // 63: dtako_rows.VehicleFerries.total:type_name -> dtako_rows.FerryPeriodSummary
+	57,  // 64: dtako_rows.FerrySummaryResponse.vehicles:type_name -> dtako_rows.VehicleFerries
+	56,  // 65: dtako_rows.FerrySummaryResponse.periods:type_name -> dtako_rows.FerryPeriodSummary
+	56,  // 66: dtako_rows.FerrySummaryResponse.total:type_name -> dtako_rows.FerryPeriodSummary
+	0,   // 67: dtako_rows.GetTripProfitabilityRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 68: dtako_rows.TripProfit.bucket:type_name -> dtako_rows.PeriodBucket
+	60,  // 69: dtako_rows.TripProfit.totals:type_name -> dtako_rows.ProfitTotals
+	61,  // 70: dtako_rows.TripProfit.lines:type_name -> dtako_rows.KeihiAmount
+	1,   // 71: dtako_rows.ProfitPeriodSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	60,  // 72: dtako_rows.ProfitPeriodSummary.totals:type_name -> dtako_rows.ProfitTotals
+	63,  // 73: dtako_rows.VehicleProfit.summaries:type_name -> dtako_rows.ProfitPeriodSummary
+	63,  // 74: dtako_rows.VehicleProfit.total:type_name -> dtako_rows.ProfitPeriodSummary
+	62,  // 75: dtako_rows.TripProfitabilityResponse.trips:type_name -> dtako_rows.TripProfit
+	64,  // 76: dtako_rows.TripProfitabilityResponse.vehicles:type_name -> dtako_rows.VehicleProfit
+	63,  // 77: dtako_rows.TripProfitabilityResponse.periods:type_name -> dtako_rows.ProfitPeriodSummary
+	63,  // 78: dtako_rows.TripProfitabilityResponse.total:type_name -> dtako_rows.ProfitPeriodSummary
+	66,  // 79: dtako_rows.EventBreakdown.codes:type_name -> dtako_rows.EventCodeTotals
+	67,  // 80: dtako_rows.EventBreakdown.categories:type_name -> dtako_rows.EventCategoryTotals
+	68,  // 81: dtako_rows.TripEventSummaryResponse.breakdown:type_name -> dtako_rows.EventBreakdown
+	0,   // 82: dtako_rows.GetEventSummaryRequest.bucketing:type_name -> dtako_rows.Bucketing
+	1,   // 83: dtako_rows.EventPeriodSummary.bucket:type_name -> dtako_rows.PeriodBucket
+	68,  // 84: dtako_rows.EventPeriodSummary.breakdown:type_name -> dtako_rows.EventBreakdown
+	72,  // 85: dtako_rows.VehicleEvents.summaries:type_name -> dtako_rows.EventPeriodSummary
+	72,  // 86: dtako_rows.VehicleEvents.total:type_name -> dtako_rows.EventPeriodSummary
+	68,  // 87: dtako_rows.DriverEvents.breakdown:type_name -> dtako_rows.EventBreakdown
+	73,  // 88: dtako_rows.EventSummaryResponse.vehicles:type_name -> dtako_rows.VehicleEvents
+	74,  // 89: dtako_rows.EventSummaryResponse.drivers:type_name -> dtako_rows.DriverEvents
+	72,  // 90: dtako_rows.EventSummaryResponse.periods:type_name -> dtako_rows.EventPeriodSummary
+	72,  // 91: dtako_rows.EventSummaryResponse.total:type_name -> dtako_rows.EventPeriodSummary
+	77,  // 92: dtako_rows.TripRouteResponse.points:type_name -> dtako_rows.RoutePoint
+	80,  // 93: dtako_rows.CacheStatsResponse.rpcs:type_name -> dtako_rows.RPCCacheStats
+	3,   // 94: dtako_rows.DtakoRowsService.GetMonthlyFuelConsumption:input_type -> dtako_rows.GetMonthlyFuelConsumptionRequest
+	5,   // 95: dtako_rows.DtakoRowsService.GetVehicleMonthlySummary:input_type -> dtako_rows.GetVehicleMonthlySummaryRequest
+	11,  // 96: dtako_rows.DtakoRowsService.GetDailySummary:input_type -> dtako_rows.GetDailySummaryRequest
+	3,   // 97: dtako_rows.DtakoRowsService.ExportMonthlyFuelCSV:input_type -> dtako_rows.GetMonthlyFuelConsumptionRequest
+	3,   // 98: dtako_rows.DtakoRowsService.ExportMonthlyFuelXLSX:input_type -> dtako_rows.GetMonthlyFuelConsumptionRequest
+	5,   // 99: dtako_rows.DtakoRowsService.ExportVehicleMonthlySummaryXLSX:input_type -> dtako_rows.GetVehicleMonthlySummaryRequest
+	15,  // 100: dtako_rows.DtakoRowsService.GetRow:input_type -> dtako_rows.GetRowRequest
+	17,  // 101: dtako_rows.DtakoRowsService.ListRows:input_type -> dtako_rows.ListRowsRequest
+	5,   // 102: dtako_rows.DtakoRowsService.StreamVehicleMonthlySummary:input_type -> dtako_rows.GetVehicleMonthlySummaryRequest
+	20,  // 103: dtako_rows.DtakoRowsService.StreamRows:input_type -> dtako_rows.StreamRowsRequest
+	22,  // 104: dtako_rows.DtakoRowsService.GetDriverMonthlySummary:input_type -> dtako_rows.GetDriverSummaryRequest
+	22,  // 105: dtako_rows.DtakoRowsService.GetDriverDailySummary:input_type -> dtako_rows.GetDriverSummaryRequest
+	26,  // 106: dtako_rows.DtakoRowsService.GetLoadedRatioSummary:input_type -> dtako_rows.GetLoadedRatioSummaryRequest
+	31,  // 107: dtako_rows.DtakoRowsService.CheckDriverCompliance:input_type -> dtako_rows.CheckDriverComplianceRequest
+	36,  // 108: dtako_rows.DtakoRowsService.ValidateRows:input_type -> dtako_rows.ValidateRowsRequest
+	79,  // 109: dtako_rows.DtakoRowsService.GetCacheStats:input_type -> dtako_rows.GetCacheStatsRequest
+	40,  // 110: dtako_rows.DtakoRowsService.CompareVehiclePeriods:input_type -> dtako_rows.CompareVehiclePeriodsRequest
+	45,  // 111: dtako_rows.DtakoRowsService.GetOfficeMonthlySummary:input_type -> dtako_rows.GetOfficeMonthlySummaryRequest
+	49,  // 112: dtako_rows.DtakoRowsService.GetTollSummary:input_type -> dtako_rows.GetTollSummaryRequest
+	55,  // 113: dtako_rows.DtakoRowsService.GetFerrySummary:input_type -> dtako_rows.GetFerrySummaryRequest
+	59,  // 114: dtako_rows.DtakoRowsService.GetTripProfitability:input_type -> dtako_rows.GetTripProfitabilityRequest
+	69,  // 115: dtako_rows.DtakoRowsService.GetTripEventSummary:input_type -> dtako_rows.GetTripEventSummaryRequest
+	71,  // 116: dtako_rows.DtakoRowsService.GetEventSummary:input_type -> dtako_rows.GetEventSummaryRequest
+	76,  // 117: dtako_rows.DtakoRowsService.GetTripRoute:input_type -> dtako_rows.GetTripRouteRequest
+	76,  // 118: dtako_rows.DtakoRowsService.ExportTripRouteGeoJSON:input_type -> dtako_rows.GetTripRouteRequest
+	76,  // 119: dtako_rows.DtakoRowsService.ExportTripRouteGPX:input_type -> dtako_rows.GetTripRouteRequest
+	4,   // 120: dtako_rows.DtakoRowsService.GetMonthlyFuelConsumption:output_type -> dtako_rows.MonthlyFuelConsumptionResponse
+	10,  // 121: dtako_rows.DtakoRowsService.GetVehicleMonthlySummary:output_type -> dtako_rows.VehicleMonthlySummaryResponse
+	13,  // 122: dtako_rows.DtakoRowsService.GetDailySummary:output_type -> dtako_rows.DailySummaryResponse
+	14,  // 123: dtako_rows.DtakoRowsService.ExportMonthlyFuelCSV:output_type -> dtako_rows.ExportCSVResponse
+	83,  // 124: dtako_rows.DtakoRowsService.ExportMonthlyFuelXLSX:output_type -> dtako_rows.ExportFileResponse
+	83,  // 125: dtako_rows.DtakoRowsService.ExportVehicleMonthlySummaryXLSX:output_type -> dtako_rows.ExportFileResponse
+	16,  // 126: dtako_rows.DtakoRowsService.GetRow:output_type -> dtako_rows.RowResponse
+	18,  // 127: dtako_rows.DtakoRowsService.ListRows:output_type -> dtako_rows.ListRowsResponse
+	7,   // 128: dtako_rows.DtakoRowsService.StreamVehicleMonthlySummary:output_type -> dtako_rows.VehicleMonthlySummaries
+	21,  // 129: dtako_rows.DtakoRowsService.StreamRows:output_type -> dtako_rows.RowBatch
+	25,  // 130: dtako_rows.DtakoRowsService.GetDriverMonthlySummary:output_type -> dtako_rows.DriverSummaryResponse
+	25,  // 131: dtako_rows.DtakoRowsService.GetDriverDailySummary:output_type -> dtako_rows.DriverSummaryResponse
+	29,  // 132: dtako_rows.DtakoRowsService.GetLoadedRatioSummary:output_type -> dtako_rows.LoadedRatioSummaryResponse
+	35,  // 133: dtako_rows.DtakoRowsService.CheckDriverCompliance:output_type -> dtako_rows.DriverComplianceResponse
+	38,  // 134: dtako_rows.DtakoRowsService.ValidateRows:output_type -> dtako_rows.ValidationReport
+	81,  // 135: dtako_rows.DtakoRowsService.GetCacheStats:output_type -> dtako_rows.CacheStatsResponse
+	44,  // 136: dtako_rows.DtakoRowsService.CompareVehiclePeriods:output_type -> dtako_rows.CompareVehiclePeriodsResponse
+	48,  // 137: dtako_rows.DtakoRowsService.GetOfficeMonthlySummary:output_type -> dtako_rows.OfficeMonthlySummaryResponse
+	54,  // 138: dtako_rows.DtakoRowsService.GetTollSummary:output_type -> dtako_rows.TollSummaryResponse
+	58,  // 139: dtako_rows.DtakoRowsService.GetFerrySummary:output_type -> dtako_rows.FerrySummaryResponse
+	65,  // 140: dtako_rows.DtakoRowsService.GetTripProfitability:output_type -> dtako_rows.TripProfitabilityResponse
+	70,  // 141: dtako_rows.DtakoRowsService.GetTripEventSummary:output_type -> dtako_rows.TripEventSummaryResponse
+	75,  // 142: dtako_rows.DtakoRowsService.GetEventSummary:output_type -> dtako_rows.EventSummaryResponse
+	78,  // 143: dtako_rows.DtakoRowsService.GetTripRoute:output_type -> dtako_rows.TripRouteResponse
+	83,  // 144: dtako_rows.DtakoRowsService.ExportTripRouteGeoJSON:output_type -> dtako_rows.ExportFileResponse
+	83,  // 145: dtako_rows.DtakoRowsService.ExportTripRouteGPX:output_type -> dtako_rows.ExportFileResponse
+	120, // [120:146] is the sub-list for method output_type
+	94,  // [94:120] is the sub-list for method input_type
+	94,  // [94:94] is the sub-list for extension type_name
+	94,  // [94:94] is the sub-list for extension extendee
+	0,   // [0:94] is the sub-list for field type_name
 }
 
 func init() { file_dtako_rows_proto_init() }
@@ -1186,15 +7861,21 @@ func file_dtako_rows_proto_init() {
 	if File_dtako_rows_proto != nil {
 		return
 	}
-	file_dtako_rows_proto_msgTypes[12].OneofWrappers = []any{}
-	file_dtako_rows_proto_msgTypes[14].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[17].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[19].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[20].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[22].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[31].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[42].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[45].OneofWrappers = []any{}
+	file_dtako_rows_proto_msgTypes[59].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dtako_rows_proto_rawDesc), len(file_dtako_rows_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   84,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DtakoRowsService_GetMonthlyFuelConsumption_FullMethodName = "/dtako_rows.DtakoRowsService/GetMonthlyFuelConsumption"
-	DtakoRowsService_GetVehicleMonthlySummary_FullMethodName  = "/dtako_rows.DtakoRowsService/GetVehicleMonthlySummary"
-	DtakoRowsService_GetDailySummary_FullMethodName           = "/dtako_rows.DtakoRowsService/GetDailySummary"
-	DtakoRowsService_ExportMonthlyFuelCSV_FullMethodName      = "/dtako_rows.DtakoRowsService/ExportMonthlyFuelCSV"
-	DtakoRowsService_GetRow_FullMethodName                    = "/dtako_rows.DtakoRowsService/GetRow"
-	DtakoRowsService_ListRows_FullMethodName                  = "/dtako_rows.DtakoRowsService/ListRows"
+	DtakoRowsService_GetMonthlyFuelConsumption_FullMethodName       = "/dtako_rows.DtakoRowsService/GetMonthlyFuelConsumption"
+	DtakoRowsService_GetVehicleMonthlySummary_FullMethodName        = "/dtako_rows.DtakoRowsService/GetVehicleMonthlySummary"
+	DtakoRowsService_GetDailySummary_FullMethodName                 = "/dtako_rows.DtakoRowsService/GetDailySummary"
+	DtakoRowsService_ExportMonthlyFuelCSV_FullMethodName            = "/dtako_rows.DtakoRowsService/ExportMonthlyFuelCSV"
+	DtakoRowsService_ExportMonthlyFuelXLSX_FullMethodName           = "/dtako_rows.DtakoRowsService/ExportMonthlyFuelXLSX"
+	DtakoRowsService_ExportVehicleMonthlySummaryXLSX_FullMethodName = "/dtako_rows.DtakoRowsService/ExportVehicleMonthlySummaryXLSX"
+	DtakoRowsService_GetRow_FullMethodName                          = "/dtako_rows.DtakoRowsService/GetRow"
+	DtakoRowsService_ListRows_FullMethodName                        = "/dtako_rows.DtakoRowsService/ListRows"
+	DtakoRowsService_StreamVehicleMonthlySummary_FullMethodName     = "/dtako_rows.DtakoRowsService/StreamVehicleMonthlySummary"
+	DtakoRowsService_StreamRows_FullMethodName                      = "/dtako_rows.DtakoRowsService/StreamRows"
+	DtakoRowsService_GetDriverMonthlySummary_FullMethodName         = "/dtako_rows.DtakoRowsService/GetDriverMonthlySummary"
+	DtakoRowsService_GetDriverDailySummary_FullMethodName           = "/dtako_rows.DtakoRowsService/GetDriverDailySummary"
+	DtakoRowsService_GetLoadedRatioSummary_FullMethodName           = "/dtako_rows.DtakoRowsService/GetLoadedRatioSummary"
+	DtakoRowsService_CheckDriverCompliance_FullMethodName           = "/dtako_rows.DtakoRowsService/CheckDriverCompliance"
+	DtakoRowsService_ValidateRows_FullMethodName                    = "/dtako_rows.DtakoRowsService/ValidateRows"
+	DtakoRowsService_GetCacheStats_FullMethodName                   = "/dtako_rows.DtakoRowsService/GetCacheStats"
+	DtakoRowsService_CompareVehiclePeriods_FullMethodName           = "/dtako_rows.DtakoRowsService/CompareVehiclePeriods"
+	DtakoRowsService_GetOfficeMonthlySummary_FullMethodName         = "/dtako_rows.DtakoRowsService/GetOfficeMonthlySummary"
+	DtakoRowsService_GetTollSummary_FullMethodName                  = "/dtako_rows.DtakoRowsService/GetTollSummary"
+	DtakoRowsService_GetFerrySummary_FullMethodName                 = "/dtako_rows.DtakoRowsService/GetFerrySummary"
+	DtakoRowsService_GetTripProfitability_FullMethodName            = "/dtako_rows.DtakoRowsService/GetTripProfitability"
+	DtakoRowsService_GetTripEventSummary_FullMethodName             = "/dtako_rows.DtakoRowsService/GetTripEventSummary"
+	DtakoRowsService_GetEventSummary_FullMethodName                 = "/dtako_rows.DtakoRowsService/GetEventSummary"
+	DtakoRowsService_GetTripRoute_FullMethodName                    = "/dtako_rows.DtakoRowsService/GetTripRoute"
+	DtakoRowsService_ExportTripRouteGeoJSON_FullMethodName          = "/dtako_rows.DtakoRowsService/ExportTripRouteGeoJSON"
+	DtakoRowsService_ExportTripRouteGPX_FullMethodName              = "/dtako_rows.DtakoRowsService/ExportTripRouteGPX"
 )
 
 // DtakoRowsServiceClient is the client API for DtakoRowsService service.
@@ -41,10 +61,50 @@ type DtakoRowsServiceClient interface {
 	GetDailySummary(ctx context.Context, in *GetDailySummaryRequest, opts ...grpc.CallOption) (*DailySummaryResponse, error)
 	// CSV形式でエクスポート
 	ExportMonthlyFuelCSV(ctx context.Context, in *GetMonthlyFuelConsumptionRequest, opts ...grpc.CallOption) (*ExportCSVResponse, error)
+	// Excel出力（月次給油量、車両シート + 集計シート）
+	ExportMonthlyFuelXLSX(ctx context.Context, in *GetMonthlyFuelConsumptionRequest, opts ...grpc.CallOption) (*ExportFileResponse, error)
+	// Excel出力（全車両の月次サマリー、車両ごとのシート + 集計シート）
+	ExportVehicleMonthlySummaryXLSX(ctx context.Context, in *GetVehicleMonthlySummaryRequest, opts ...grpc.CallOption) (*ExportFileResponse, error)
 	// 運行データ取得（db_serviceプロキシ）
 	GetRow(ctx context.Context, in *GetRowRequest, opts ...grpc.CallOption) (*RowResponse, error)
 	// 運行データ一覧取得（db_serviceプロキシ）
 	ListRows(ctx context.Context, in *ListRowsRequest, opts ...grpc.CallOption) (*ListRowsResponse, error)
+	// 全車両の月次サマリーを車両単位でストリーミング
+	StreamVehicleMonthlySummary(ctx context.Context, in *GetVehicleMonthlySummaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VehicleMonthlySummaries], error)
+	// 運行データをページ単位でストリーミング
+	StreamRows(ctx context.Context, in *StreamRowsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RowBatch], error)
+	// 乗務員ごとの月次サマリー取得
+	GetDriverMonthlySummary(ctx context.Context, in *GetDriverSummaryRequest, opts ...grpc.CallOption) (*DriverSummaryResponse, error)
+	// 乗務員ごとの日次サマリー取得
+	GetDriverDailySummary(ctx context.Context, in *GetDriverSummaryRequest, opts ...grpc.CallOption) (*DriverSummaryResponse, error)
+	// 車両ごとの月次実車率（実車/空車走行）集計
+	GetLoadedRatioSummary(ctx context.Context, in *GetLoadedRatioSummaryRequest, opts ...grpc.CallOption) (*LoadedRatioSummaryResponse, error)
+	// 乗務員の改善基準告示（拘束時間・休息期間・運転時間）チェック
+	CheckDriverCompliance(ctx context.Context, in *CheckDriverComplianceRequest, opts ...grpc.CallOption) (*DriverComplianceResponse, error)
+	// 運行データの品質チェック（メーター連続性・日時の整合性）
+	ValidateRows(ctx context.Context, in *ValidateRowsRequest, opts ...grpc.CallOption) (*ValidationReport, error)
+	// 集計キャッシュの統計（ヒット・ミス数）
+	GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error)
+	// 車両ごとの2期間比較（前年同月比・前月比など）
+	CompareVehiclePeriods(ctx context.Context, in *CompareVehiclePeriodsRequest, opts ...grpc.CallOption) (*CompareVehiclePeriodsResponse, error)
+	// 事業所（車両マスタの所属事業所）ごとの月次サマリー取得
+	GetOfficeMonthlySummary(ctx context.Context, in *GetOfficeMonthlySummaryRequest, opts ...grpc.CallOption) (*OfficeMonthlySummaryResponse, error)
+	// ETC明細の通行料金を運行・車両・期間ごとに集計
+	GetTollSummary(ctx context.Context, in *GetTollSummaryRequest, opts ...grpc.CallOption) (*TollSummaryResponse, error)
+	// フェリー利用（料金・乗船回数・見なし距離）を車両・期間ごとに集計
+	GetFerrySummary(ctx context.Context, in *GetFerrySummaryRequest, opts ...grpc.CallOption) (*FerrySummaryResponse, error)
+	// 売上・経費・燃料費・通行料金・フェリー料金から運行・車両・期間ごとの採算を集計
+	GetTripProfitability(ctx context.Context, in *GetTripProfitabilityRequest, opts ...grpc.CallOption) (*TripProfitabilityResponse, error)
+	// 運行のイベント（アイドリング・休憩・荷役・速度超過など）をイベントCD・分類ごとに集計
+	GetTripEventSummary(ctx context.Context, in *GetTripEventSummaryRequest, opts ...grpc.CallOption) (*TripEventSummaryResponse, error)
+	// イベントを車両・乗務員・期間ごとに集計
+	GetEventSummary(ctx context.Context, in *GetEventSummaryRequest, opts ...grpc.CallOption) (*EventSummaryResponse, error)
+	// 運行のイベントのGPS位置から経路を復元
+	GetTripRoute(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*TripRouteResponse, error)
+	// 運行の経路をGeoJSONで出力
+	ExportTripRouteGeoJSON(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*ExportFileResponse, error)
+	// 運行の経路をGPXで出力
+	ExportTripRouteGPX(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*ExportFileResponse, error)
 }
 
 type dtakoRowsServiceClient struct {
@@ -95,6 +155,26 @@ func (c *dtakoRowsServiceClient) ExportMonthlyFuelCSV(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *dtakoRowsServiceClient) ExportMonthlyFuelXLSX(ctx context.Context, in *GetMonthlyFuelConsumptionRequest, opts ...grpc.CallOption) (*ExportFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportFileResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_ExportMonthlyFuelXLSX_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) ExportVehicleMonthlySummaryXLSX(ctx context.Context, in *GetVehicleMonthlySummaryRequest, opts ...grpc.CallOption) (*ExportFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportFileResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_ExportVehicleMonthlySummaryXLSX_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dtakoRowsServiceClient) GetRow(ctx context.Context, in *GetRowRequest, opts ...grpc.CallOption) (*RowResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RowResponse)
@@ -115,6 +195,204 @@ func (c *dtakoRowsServiceClient) ListRows(ctx context.Context, in *ListRowsReque
 	return out, nil
 }
 
+func (c *dtakoRowsServiceClient) StreamVehicleMonthlySummary(ctx context.Context, in *GetVehicleMonthlySummaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VehicleMonthlySummaries], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DtakoRowsService_ServiceDesc.Streams[0], DtakoRowsService_StreamVehicleMonthlySummary_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetVehicleMonthlySummaryRequest, VehicleMonthlySummaries]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DtakoRowsService_StreamVehicleMonthlySummaryClient = grpc.ServerStreamingClient[VehicleMonthlySummaries]
+
+func (c *dtakoRowsServiceClient) StreamRows(ctx context.Context, in *StreamRowsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RowBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DtakoRowsService_ServiceDesc.Streams[1], DtakoRowsService_StreamRows_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRowsRequest, RowBatch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DtakoRowsService_StreamRowsClient = grpc.ServerStreamingClient[RowBatch]
+
+func (c *dtakoRowsServiceClient) GetDriverMonthlySummary(ctx context.Context, in *GetDriverSummaryRequest, opts ...grpc.CallOption) (*DriverSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverSummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetDriverMonthlySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetDriverDailySummary(ctx context.Context, in *GetDriverSummaryRequest, opts ...grpc.CallOption) (*DriverSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverSummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetDriverDailySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetLoadedRatioSummary(ctx context.Context, in *GetLoadedRatioSummaryRequest, opts ...grpc.CallOption) (*LoadedRatioSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadedRatioSummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetLoadedRatioSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) CheckDriverCompliance(ctx context.Context, in *CheckDriverComplianceRequest, opts ...grpc.CallOption) (*DriverComplianceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DriverComplianceResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_CheckDriverCompliance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) ValidateRows(ctx context.Context, in *ValidateRowsRequest, opts ...grpc.CallOption) (*ValidationReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationReport)
+	err := c.cc.Invoke(ctx, DtakoRowsService_ValidateRows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetCacheStats(ctx context.Context, in *GetCacheStatsRequest, opts ...grpc.CallOption) (*CacheStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CacheStatsResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetCacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) CompareVehiclePeriods(ctx context.Context, in *CompareVehiclePeriodsRequest, opts ...grpc.CallOption) (*CompareVehiclePeriodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareVehiclePeriodsResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_CompareVehiclePeriods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetOfficeMonthlySummary(ctx context.Context, in *GetOfficeMonthlySummaryRequest, opts ...grpc.CallOption) (*OfficeMonthlySummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfficeMonthlySummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetOfficeMonthlySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetTollSummary(ctx context.Context, in *GetTollSummaryRequest, opts ...grpc.CallOption) (*TollSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TollSummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetTollSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetFerrySummary(ctx context.Context, in *GetFerrySummaryRequest, opts ...grpc.CallOption) (*FerrySummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FerrySummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetFerrySummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetTripProfitability(ctx context.Context, in *GetTripProfitabilityRequest, opts ...grpc.CallOption) (*TripProfitabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripProfitabilityResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetTripProfitability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetTripEventSummary(ctx context.Context, in *GetTripEventSummaryRequest, opts ...grpc.CallOption) (*TripEventSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripEventSummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetTripEventSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetEventSummary(ctx context.Context, in *GetEventSummaryRequest, opts ...grpc.CallOption) (*EventSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventSummaryResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetEventSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) GetTripRoute(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*TripRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripRouteResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_GetTripRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) ExportTripRouteGeoJSON(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*ExportFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportFileResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_ExportTripRouteGeoJSON_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dtakoRowsServiceClient) ExportTripRouteGPX(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*ExportFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportFileResponse)
+	err := c.cc.Invoke(ctx, DtakoRowsService_ExportTripRouteGPX_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DtakoRowsServiceServer is the server API for DtakoRowsService service.
 // All implementations must embed UnimplementedDtakoRowsServiceServer
 // for forward compatibility.
@@ -129,10 +407,50 @@ type DtakoRowsServiceServer interface {
 	GetDailySummary(context.Context, *GetDailySummaryRequest) (*DailySummaryResponse, error)
 	// CSV形式でエクスポート
 	ExportMonthlyFuelCSV(context.Context, *GetMonthlyFuelConsumptionRequest) (*ExportCSVResponse, error)
+	// Excel出力（月次給油量、車両シート + 集計シート）
+	ExportMonthlyFuelXLSX(context.Context, *GetMonthlyFuelConsumptionRequest) (*ExportFileResponse, error)
+	// Excel出力（全車両の月次サマリー、車両ごとのシート + 集計シート）
+	ExportVehicleMonthlySummaryXLSX(context.Context, *GetVehicleMonthlySummaryRequest) (*ExportFileResponse, error)
 	// 運行データ取得（db_serviceプロキシ）
 	GetRow(context.Context, *GetRowRequest) (*RowResponse, error)
 	// 運行データ一覧取得（db_serviceプロキシ）
 	ListRows(context.Context, *ListRowsRequest) (*ListRowsResponse, error)
+	// 全車両の月次サマリーを車両単位でストリーミング
+	StreamVehicleMonthlySummary(*GetVehicleMonthlySummaryRequest, grpc.ServerStreamingServer[VehicleMonthlySummaries]) error
+	// 運行データをページ単位でストリーミング
+	StreamRows(*StreamRowsRequest, grpc.ServerStreamingServer[RowBatch]) error
+	// 乗務員ごとの月次サマリー取得
+	GetDriverMonthlySummary(context.Context, *GetDriverSummaryRequest) (*DriverSummaryResponse, error)
+	// 乗務員ごとの日次サマリー取得
+	GetDriverDailySummary(context.Context, *GetDriverSummaryRequest) (*DriverSummaryResponse, error)
+	// 車両ごとの月次実車率（実車/空車走行）集計
+	GetLoadedRatioSummary(context.Context, *GetLoadedRatioSummaryRequest) (*LoadedRatioSummaryResponse, error)
+	// 乗務員の改善基準告示（拘束時間・休息期間・運転時間）チェック
+	CheckDriverCompliance(context.Context, *CheckDriverComplianceRequest) (*DriverComplianceResponse, error)
+	// 運行データの品質チェック（メーター連続性・日時の整合性）
+	ValidateRows(context.Context, *ValidateRowsRequest) (*ValidationReport, error)
+	// 集計キャッシュの統計（ヒット・ミス数）
+	GetCacheStats(context.Context, *GetCacheStatsRequest) (*CacheStatsResponse, error)
+	// 車両ごとの2期間比較（前年同月比・前月比など）
+	CompareVehiclePeriods(context.Context, *CompareVehiclePeriodsRequest) (*CompareVehiclePeriodsResponse, error)
+	// 事業所（車両マスタの所属事業所）ごとの月次サマリー取得
+	GetOfficeMonthlySummary(context.Context, *GetOfficeMonthlySummaryRequest) (*OfficeMonthlySummaryResponse, error)
+	// ETC明細の通行料金を運行・車両・期間ごとに集計
+	GetTollSummary(context.Context, *GetTollSummaryRequest) (*TollSummaryResponse, error)
+	// フェリー利用（料金・乗船回数・見なし距離）を車両・期間ごとに集計
+	GetFerrySummary(context.Context, *GetFerrySummaryRequest) (*FerrySummaryResponse, error)
+	// 売上・経費・燃料費・通行料金・フェリー料金から運行・車両・期間ごとの採算を集計
+	GetTripProfitability(context.Context, *GetTripProfitabilityRequest) (*TripProfitabilityResponse, error)
+	// 運行のイベント（アイドリング・休憩・荷役・速度超過など）をイベントCD・分類ごとに集計
+	GetTripEventSummary(context.Context, *GetTripEventSummaryRequest) (*TripEventSummaryResponse, error)
+	// イベントを車両・乗務員・期間ごとに集計
+	GetEventSummary(context.Context, *GetEventSummaryRequest) (*EventSummaryResponse, error)
+	// 運行のイベントのGPS位置から経路を復元
+	GetTripRoute(context.Context, *GetTripRouteRequest) (*TripRouteResponse, error)
+	// 運行の経路をGeoJSONで出力
+	ExportTripRouteGeoJSON(context.Context, *GetTripRouteRequest) (*ExportFileResponse, error)
+	// 運行の経路をGPXで出力
+	ExportTripRouteGPX(context.Context, *GetTripRouteRequest) (*ExportFileResponse, error)
 	mustEmbedUnimplementedDtakoRowsServiceServer()
 }
 
@@ -155,12 +473,72 @@ func (UnimplementedDtakoRowsServiceServer) GetDailySummary(context.Context, *Get
 func (UnimplementedDtakoRowsServiceServer) ExportMonthlyFuelCSV(context.Context, *GetMonthlyFuelConsumptionRequest) (*ExportCSVResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportMonthlyFuelCSV not implemented")
 }
+func (UnimplementedDtakoRowsServiceServer) ExportMonthlyFuelXLSX(context.Context, *GetMonthlyFuelConsumptionRequest) (*ExportFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMonthlyFuelXLSX not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) ExportVehicleMonthlySummaryXLSX(context.Context, *GetVehicleMonthlySummaryRequest) (*ExportFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportVehicleMonthlySummaryXLSX not implemented")
+}
 func (UnimplementedDtakoRowsServiceServer) GetRow(context.Context, *GetRowRequest) (*RowResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRow not implemented")
 }
 func (UnimplementedDtakoRowsServiceServer) ListRows(context.Context, *ListRowsRequest) (*ListRowsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRows not implemented")
 }
+func (UnimplementedDtakoRowsServiceServer) StreamVehicleMonthlySummary(*GetVehicleMonthlySummaryRequest, grpc.ServerStreamingServer[VehicleMonthlySummaries]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVehicleMonthlySummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) StreamRows(*StreamRowsRequest, grpc.ServerStreamingServer[RowBatch]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRows not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetDriverMonthlySummary(context.Context, *GetDriverSummaryRequest) (*DriverSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverMonthlySummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetDriverDailySummary(context.Context, *GetDriverSummaryRequest) (*DriverSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverDailySummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetLoadedRatioSummary(context.Context, *GetLoadedRatioSummaryRequest) (*LoadedRatioSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoadedRatioSummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) CheckDriverCompliance(context.Context, *CheckDriverComplianceRequest) (*DriverComplianceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDriverCompliance not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) ValidateRows(context.Context, *ValidateRowsRequest) (*ValidationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRows not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetCacheStats(context.Context, *GetCacheStatsRequest) (*CacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheStats not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) CompareVehiclePeriods(context.Context, *CompareVehiclePeriodsRequest) (*CompareVehiclePeriodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareVehiclePeriods not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetOfficeMonthlySummary(context.Context, *GetOfficeMonthlySummaryRequest) (*OfficeMonthlySummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOfficeMonthlySummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetTollSummary(context.Context, *GetTollSummaryRequest) (*TollSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTollSummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetFerrySummary(context.Context, *GetFerrySummaryRequest) (*FerrySummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFerrySummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetTripProfitability(context.Context, *GetTripProfitabilityRequest) (*TripProfitabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripProfitability not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetTripEventSummary(context.Context, *GetTripEventSummaryRequest) (*TripEventSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripEventSummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetEventSummary(context.Context, *GetEventSummaryRequest) (*EventSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventSummary not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) GetTripRoute(context.Context, *GetTripRouteRequest) (*TripRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripRoute not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) ExportTripRouteGeoJSON(context.Context, *GetTripRouteRequest) (*ExportFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTripRouteGeoJSON not implemented")
+}
+func (UnimplementedDtakoRowsServiceServer) ExportTripRouteGPX(context.Context, *GetTripRouteRequest) (*ExportFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTripRouteGPX not implemented")
+}
 func (UnimplementedDtakoRowsServiceServer) mustEmbedUnimplementedDtakoRowsServiceServer() {}
 func (UnimplementedDtakoRowsServiceServer) testEmbeddedByValue()                          {}
 
@@ -254,6 +632,42 @@ func _DtakoRowsService_ExportMonthlyFuelCSV_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DtakoRowsService_ExportMonthlyFuelXLSX_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonthlyFuelConsumptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).ExportMonthlyFuelXLSX(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_ExportMonthlyFuelXLSX_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).ExportMonthlyFuelXLSX(ctx, req.(*GetMonthlyFuelConsumptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_ExportVehicleMonthlySummaryXLSX_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleMonthlySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).ExportVehicleMonthlySummaryXLSX(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_ExportVehicleMonthlySummaryXLSX_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).ExportVehicleMonthlySummaryXLSX(ctx, req.(*GetVehicleMonthlySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DtakoRowsService_GetRow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRowRequest)
 	if err := dec(in); err != nil {
@@ -290,6 +704,316 @@ func _DtakoRowsService_ListRows_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DtakoRowsService_StreamVehicleMonthlySummary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetVehicleMonthlySummaryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DtakoRowsServiceServer).StreamVehicleMonthlySummary(m, &grpc.GenericServerStream[GetVehicleMonthlySummaryRequest, VehicleMonthlySummaries]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DtakoRowsService_StreamVehicleMonthlySummaryServer = grpc.ServerStreamingServer[VehicleMonthlySummaries]
+
+func _DtakoRowsService_StreamRows_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRowsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DtakoRowsServiceServer).StreamRows(m, &grpc.GenericServerStream[StreamRowsRequest, RowBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DtakoRowsService_StreamRowsServer = grpc.ServerStreamingServer[RowBatch]
+
+func _DtakoRowsService_GetDriverMonthlySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetDriverMonthlySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetDriverMonthlySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetDriverMonthlySummary(ctx, req.(*GetDriverSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetDriverDailySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetDriverDailySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetDriverDailySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetDriverDailySummary(ctx, req.(*GetDriverSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetLoadedRatioSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoadedRatioSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetLoadedRatioSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetLoadedRatioSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetLoadedRatioSummary(ctx, req.(*GetLoadedRatioSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_CheckDriverCompliance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDriverComplianceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).CheckDriverCompliance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_CheckDriverCompliance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).CheckDriverCompliance(ctx, req.(*CheckDriverComplianceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_ValidateRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).ValidateRows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_ValidateRows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).ValidateRows(ctx, req.(*ValidateRowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetCacheStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetCacheStats(ctx, req.(*GetCacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_CompareVehiclePeriods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareVehiclePeriodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).CompareVehiclePeriods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_CompareVehiclePeriods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).CompareVehiclePeriods(ctx, req.(*CompareVehiclePeriodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetOfficeMonthlySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOfficeMonthlySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetOfficeMonthlySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetOfficeMonthlySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetOfficeMonthlySummary(ctx, req.(*GetOfficeMonthlySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetTollSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTollSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetTollSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetTollSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetTollSummary(ctx, req.(*GetTollSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetFerrySummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFerrySummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetFerrySummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetFerrySummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetFerrySummary(ctx, req.(*GetFerrySummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetTripProfitability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripProfitabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetTripProfitability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetTripProfitability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetTripProfitability(ctx, req.(*GetTripProfitabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetTripEventSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripEventSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetTripEventSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetTripEventSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetTripEventSummary(ctx, req.(*GetTripEventSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetEventSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetEventSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetEventSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetEventSummary(ctx, req.(*GetEventSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_GetTripRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).GetTripRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_GetTripRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).GetTripRoute(ctx, req.(*GetTripRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_ExportTripRouteGeoJSON_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).ExportTripRouteGeoJSON(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_ExportTripRouteGeoJSON_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).ExportTripRouteGeoJSON(ctx, req.(*GetTripRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DtakoRowsService_ExportTripRouteGPX_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DtakoRowsServiceServer).ExportTripRouteGPX(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DtakoRowsService_ExportTripRouteGPX_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DtakoRowsServiceServer).ExportTripRouteGPX(ctx, req.(*GetTripRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DtakoRowsService_ServiceDesc is the grpc.ServiceDesc for DtakoRowsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -313,6 +1037,14 @@ var DtakoRowsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportMonthlyFuelCSV",
 			Handler:    _DtakoRowsService_ExportMonthlyFuelCSV_Handler,
 		},
+		{
+			MethodName: "ExportMonthlyFuelXLSX",
+			Handler:    _DtakoRowsService_ExportMonthlyFuelXLSX_Handler,
+		},
+		{
+			MethodName: "ExportVehicleMonthlySummaryXLSX",
+			Handler:    _DtakoRowsService_ExportVehicleMonthlySummaryXLSX_Handler,
+		},
 		{
 			MethodName: "GetRow",
 			Handler:    _DtakoRowsService_GetRow_Handler,
@@ -321,7 +1053,82 @@ var DtakoRowsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRows",
 			Handler:    _DtakoRowsService_ListRows_Handler,
 		},
+		{
+			MethodName: "GetDriverMonthlySummary",
+			Handler:    _DtakoRowsService_GetDriverMonthlySummary_Handler,
+		},
+		{
+			MethodName: "GetDriverDailySummary",
+			Handler:    _DtakoRowsService_GetDriverDailySummary_Handler,
+		},
+		{
+			MethodName: "GetLoadedRatioSummary",
+			Handler:    _DtakoRowsService_GetLoadedRatioSummary_Handler,
+		},
+		{
+			MethodName: "CheckDriverCompliance",
+			Handler:    _DtakoRowsService_CheckDriverCompliance_Handler,
+		},
+		{
+			MethodName: "ValidateRows",
+			Handler:    _DtakoRowsService_ValidateRows_Handler,
+		},
+		{
+			MethodName: "GetCacheStats",
+			Handler:    _DtakoRowsService_GetCacheStats_Handler,
+		},
+		{
+			MethodName: "CompareVehiclePeriods",
+			Handler:    _DtakoRowsService_CompareVehiclePeriods_Handler,
+		},
+		{
+			MethodName: "GetOfficeMonthlySummary",
+			Handler:    _DtakoRowsService_GetOfficeMonthlySummary_Handler,
+		},
+		{
+			MethodName: "GetTollSummary",
+			Handler:    _DtakoRowsService_GetTollSummary_Handler,
+		},
+		{
+			MethodName: "GetFerrySummary",
+			Handler:    _DtakoRowsService_GetFerrySummary_Handler,
+		},
+		{
+			MethodName: "GetTripProfitability",
+			Handler:    _DtakoRowsService_GetTripProfitability_Handler,
+		},
+		{
+			MethodName: "GetTripEventSummary",
+			Handler:    _DtakoRowsService_GetTripEventSummary_Handler,
+		},
+		{
+			MethodName: "GetEventSummary",
+			Handler:    _DtakoRowsService_GetEventSummary_Handler,
+		},
+		{
+			MethodName: "GetTripRoute",
+			Handler:    _DtakoRowsService_GetTripRoute_Handler,
+		},
+		{
+			MethodName: "ExportTripRouteGeoJSON",
+			Handler:    _DtakoRowsService_ExportTripRouteGeoJSON_Handler,
+		},
+		{
+			MethodName: "ExportTripRouteGPX",
+			Handler:    _DtakoRowsService_ExportTripRouteGPX_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamVehicleMonthlySummary",
+			Handler:       _DtakoRowsService_StreamVehicleMonthlySummary_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRows",
+			Handler:       _DtakoRowsService_StreamRows_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "dtako_rows.proto",
 }